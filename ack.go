@@ -0,0 +1,332 @@
+// ack.go: At-least-once delivery with consumer acknowledgement
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/agilira/iris"
+)
+
+// AckIDKey is the field key of the delivery identifier stamped on records in
+// acknowledgement mode.
+const AckIDKey = "ack_id"
+
+// AckConfig configures acknowledgement mode.
+type AckConfig struct {
+	// Timeout is how long a delivered record may stay unacknowledged before
+	// it is delivered again; 30s when zero.
+	Timeout time.Duration
+
+	// MaxAttempts bounds the deliveries of a record; a record that is still
+	// not acknowledged is then given up, counted in Stats().AckFailed and
+	// reported on Errors. Zero means no limit.
+	MaxAttempts int
+}
+
+// DeliveryError reports a record given up after AckConfig.MaxAttempts
+// unacknowledged deliveries.
+type DeliveryError struct {
+	Message  string // Message of the record
+	Attempts int    // Number of deliveries
+}
+
+// Error implements error.
+func (e *DeliveryError) Error() string {
+	return fmt.Sprintf("slog provider record %q not acknowledged after %d deliveries", e.Message, e.Attempts)
+}
+
+// WithAcknowledgement enables at-least-once delivery for audit-grade
+// streams: every record returned by Read carries an AckIDKey field, and
+// remains tracked by the provider until the consumer acknowledges it. Records
+// that are negatively acknowledged, or not acknowledged within cfg.Timeout,
+// are returned by Read again, ahead of buffered records. A record at the Iris
+// field limit gives up its last field for the AckIDKey field.
+//
+// With Iris, wrap the output in an AckWriter, which acknowledges each record
+// once its encoded form was written successfully:
+//
+//	provider := slogprovider.New(1000, slogprovider.WithAcknowledgement(slogprovider.AckConfig{}))
+//	config.Output = provider.AckWriter(config.Output)
+//	logger, _ := iris.NewReaderLogger(config, []iris.SyncReader{provider})
+//
+// Other consumers call Ack or Nack with the identifier returned by AckID.
+// Delivery is at least once, so consumers must tolerate duplicates. The
+// buffer lives in memory: records are redelivered while the process runs,
+// and records still unacknowledged at the end of the stream are reported in
+// Stats().Unacked rather than waited for.
+func WithAcknowledgement(cfg AckConfig) Option {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 30 * time.Second
+	}
+	return func(o *options) { o.ack = &cfg }
+}
+
+// ackTracker tracks delivered records until they are acknowledged.
+type ackTracker struct {
+	cfg      AckConfig
+	mu       sync.Mutex
+	next     uint64               // Last assigned delivery identifier
+	inflight map[uint64]*delivery // Unacknowledged deliveries
+	order    []uint64             // Delivery identifiers in deadline order, lazily pruned
+	retry    []uint64             // Negatively acknowledged deliveries
+}
+
+// delivery is an unacknowledged delivery of an entry.
+type delivery struct {
+	e        entry
+	deadline time.Time
+	attempts int
+}
+
+// newAckTracker creates a tracker for cfg, or nil if cfg is nil.
+func newAckTracker(cfg *AckConfig) *ackTracker {
+	if cfg == nil {
+		return nil
+	}
+	return &ackTracker{cfg: *cfg, inflight: make(map[uint64]*delivery)}
+}
+
+// track registers a delivery of e as the attempts-th and stamps record with
+// its identifier.
+func (a *ackTracker) track(e entry, attempts int, record *iris.Record) {
+	a.mu.Lock()
+	a.next++
+	id := a.next
+	a.inflight[id] = &delivery{e: e, deadline: time.Now().Add(a.cfg.Timeout), attempts: attempts}
+	a.order = append(a.order, id)
+	a.mu.Unlock()
+
+	stampAckID(record, id)
+}
+
+// stampAckID adds the AckIDKey field for id to record. A record at the Iris
+// field limit gives up its last field instead, since a record without an
+// identifier could never be acknowledged.
+func stampAckID(record *iris.Record, id uint64) {
+	if record.AddField(iris.Uint64(AckIDKey, id)) {
+		return
+	}
+	fields := make([]iris.Field, record.FieldCount()-1)
+	for i := range fields {
+		fields[i] = record.GetField(i)
+	}
+	level, msg, logger, caller, stack := record.Level, record.Msg, record.Logger, record.Caller, record.Stack
+	record.Reset()
+	record.Level, record.Msg, record.Logger, record.Caller, record.Stack = level, msg, logger, caller, stack
+	for _, field := range fields {
+		record.AddField(field)
+	}
+	record.AddField(iris.Uint64(AckIDKey, id))
+}
+
+// settle removes the delivery id, reporting whether it was tracked. With
+// retry, the delivery is queued for redelivery instead.
+func (a *ackTracker) settle(id uint64, retry bool) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if _, ok := a.inflight[id]; !ok {
+		return false
+	}
+	if retry {
+		a.retry = append(a.retry, id)
+	} else {
+		delete(a.inflight, id)
+	}
+	return true
+}
+
+// take removes and returns a delivery to redeliver, if any.
+func (a *ackTracker) take() (*delivery, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for len(a.retry) > 0 {
+		id := a.retry[0]
+		a.retry = a.retry[1:]
+		if d, ok := a.inflight[id]; ok {
+			delete(a.inflight, id)
+			return d, true
+		}
+	}
+	if d, id, ok := a.front(); ok && !time.Now().Before(d.deadline) {
+		delete(a.inflight, id)
+		a.order = a.order[1:]
+		return d, true
+	}
+	return nil, false
+}
+
+// front returns the unacknowledged delivery with the earliest deadline,
+// pruning settled ones. a.mu must be held.
+func (a *ackTracker) front() (*delivery, uint64, bool) {
+	for len(a.order) > 0 {
+		id := a.order[0]
+		if d, ok := a.inflight[id]; ok {
+			return d, id, true
+		}
+		a.order = a.order[1:]
+	}
+	return nil, 0, false
+}
+
+// redeliverable reports whether take would return a delivery now.
+func (a *ackTracker) redeliverable() bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for _, id := range a.retry {
+		if _, ok := a.inflight[id]; ok {
+			return true
+		}
+	}
+	d, _, ok := a.front()
+	return ok && !time.Now().Before(d.deadline)
+}
+
+// pending returns the number of unacknowledged deliveries.
+func (a *ackTracker) pending() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return len(a.inflight)
+}
+
+// ackDue returns a channel that fires when the earliest unacknowledged
+// delivery times out, or nil when none is pending.
+func (p *Provider) ackDue() <-chan time.Time {
+	a := p.acks
+	if a == nil {
+		return nil
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	d, _, ok := a.front()
+	if !ok {
+		return nil
+	}
+	return time.After(time.Until(d.deadline))
+}
+
+// redeliver returns the next record to deliver again, or nil if none.
+func (p *Provider) redeliver() *iris.Record {
+	for {
+		d, ok := p.acks.take()
+		if !ok {
+			return nil
+		}
+		if max := p.acks.cfg.MaxAttempts; max > 0 && d.attempts >= max {
+			p.stats.ackFailed.Add(1)
+			p.reportError(&DeliveryError{Message: d.e.record.Message, Attempts: d.attempts})
+			continue
+		}
+		p.stats.redelivered.Add(1)
+		if record := p.deliver(d.e, d.attempts+1); record != nil {
+			return record
+		}
+	}
+}
+
+// Ack acknowledges the delivery id, see WithAcknowledgement. It reports
+// whether id was pending; acknowledging a redelivered or unknown id is a
+// no-op.
+func (p *Provider) Ack(id uint64) bool {
+	return p.acks != nil && p.acks.settle(id, false)
+}
+
+// Nack reports that the delivery id failed, so that Read returns the record
+// again. It reports whether id was pending.
+func (p *Provider) Nack(id uint64) bool {
+	if p.acks == nil || !p.acks.settle(id, true) {
+		return false
+	}
+	p.queue.signal()
+	return true
+}
+
+// AckID returns the delivery identifier of a record returned by Read in
+// acknowledgement mode.
+func AckID(record *iris.Record) (uint64, bool) {
+	for i := record.FieldCount() - 1; i >= 0; i-- {
+		if f := record.GetField(i); f.Key() == AckIDKey && f.IsUint() {
+			return f.UintValue(), true
+		}
+	}
+	return 0, false
+}
+
+// AckWriter is an iris.WriteSyncer that acknowledges records once their
+// encoded form has been written, and negatively acknowledges them when the
+// write fails. It recognizes the AckIDKey field in JSON ("ack_id":N) and
+// text (ack_id=N) encodings, one record per line.
+type AckWriter struct {
+	p *Provider
+	w iris.WriteSyncer
+}
+
+// AckWriter wraps w so that writes acknowledge the records they contain.
+func (p *Provider) AckWriter(w iris.WriteSyncer) *AckWriter {
+	return &AckWriter{p: p, w: w}
+}
+
+// Write writes b to the wrapped writer and settles the deliveries it
+// contains according to the result.
+func (a *AckWriter) Write(b []byte) (int, error) {
+	n, err := a.w.Write(b)
+	for _, id := range scanAckIDs(b) {
+		if err != nil {
+			a.p.Nack(id)
+		} else {
+			a.p.Ack(id)
+		}
+	}
+	return n, err
+}
+
+// Sync implements iris.WriteSyncer.
+func (a *AckWriter) Sync() error {
+	return a.w.Sync()
+}
+
+// scanAckIDs returns the delivery identifiers found in encoded records, one
+// per line. The identifier is the last field added to a record, so the last
+// occurrence in a line wins over look-alikes in messages or other values.
+func scanAckIDs(b []byte) []uint64 {
+	var ids []uint64
+	for _, line := range bytes.Split(b, []byte("\n")) {
+		if id, ok := lastAckID(line); ok {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// lastAckID returns the last AckIDKey value in an encoded record.
+func lastAckID(line []byte) (uint64, bool) {
+	key := []byte(AckIDKey)
+	for end := len(line); ; {
+		i := bytes.LastIndex(line[:end], key)
+		if i < 0 {
+			return 0, false
+		}
+		end = i
+		rest := bytes.TrimPrefix(line[i+len(key):], []byte(`"`))
+		if len(rest) == 0 || (rest[0] != ':' && rest[0] != '=') {
+			continue
+		}
+		rest = bytes.TrimLeft(rest[1:], " ")
+		var id uint64
+		digits := 0
+		for digits < len(rest) && rest[digits] >= '0' && rest[digits] <= '9' {
+			id = id*10 + uint64(rest[digits]-'0')
+			digits++
+		}
+		if digits > 0 {
+			return id, true
+		}
+	}
+}
@@ -0,0 +1,226 @@
+// ack_test.go: Tests for acknowledgement mode
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/agilira/iris"
+)
+
+// readAckID reads a record and returns its message and delivery identifier.
+func readAckID(t *testing.T, provider *Provider) (string, uint64) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	record, err := provider.Read(ctx)
+	if err != nil || record == nil {
+		t.Fatalf("Read failed: %v", err)
+	}
+	id, ok := AckID(record)
+	if !ok {
+		t.Fatalf("Record %q lacks %s", record.Msg, AckIDKey)
+	}
+	return record.Msg, id
+}
+
+func TestWithAcknowledgement_NackRedelivers(t *testing.T) {
+	provider := New(100, WithAcknowledgement(AckConfig{}))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+	logger := slog.New(provider)
+
+	logger.Info("first")
+	logger.Info("second")
+
+	_, id := readAckID(t, provider)
+	if !provider.Nack(id) {
+		t.Fatal("Expected Nack of a pending delivery to succeed")
+	}
+	msg, redelivered := readAckID(t, provider)
+	if msg != "first" || redelivered == id {
+		t.Errorf("Expected first redelivered with a new id, got %q id %d", msg, redelivered)
+	}
+	provider.Ack(redelivered)
+
+	msg, next := readAckID(t, provider)
+	if msg != "second" {
+		t.Errorf("Expected second after the redelivery, got %q", msg)
+	}
+	provider.Ack(next)
+
+	stats := provider.Stats()
+	if stats.Redelivered != 1 || stats.Unacked != 0 || stats.Converted != 2 {
+		t.Errorf("Unexpected stats %+v", stats)
+	}
+	if err := provider.Verify(); err != nil {
+		t.Errorf("Verify failed: %v", err)
+	}
+}
+
+func TestWithAcknowledgement_FullRecord(t *testing.T) {
+	provider := New(10, WithAcknowledgement(AckConfig{}))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	args := make([]any, 0, 80)
+	for i := range 40 {
+		args = append(args, fmt.Sprintf("k%02d", i), i)
+	}
+	slog.New(provider).Info("full", args...)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	record, err := provider.Read(ctx)
+	if err != nil || record == nil {
+		t.Fatalf("Read failed: %v", err)
+	}
+	if record.FieldCount() != 32 {
+		t.Fatalf("Expected a full record, got %d fields", record.FieldCount())
+	}
+	id, ok := AckID(record)
+	if !ok {
+		t.Fatalf("Full record lacks %s", AckIDKey)
+	}
+	if record.GetField(0).Key() != slog.TimeKey || record.GetField(30).Key() != "k29" {
+		t.Errorf("Expected only the last field to make room, got %s and %s", record.GetField(0).Key(), record.GetField(30).Key())
+	}
+	if !provider.Ack(id) || provider.Stats().Unacked != 0 {
+		t.Error("Expected the full record to be acknowledged")
+	}
+}
+
+func TestWithAcknowledgement_TimeoutRedelivers(t *testing.T) {
+	provider := New(100, WithAcknowledgement(AckConfig{Timeout: 20 * time.Millisecond}))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	slog.New(provider).Info("unacked")
+	_, id := readAckID(t, provider)
+
+	msg, again := readAckID(t, provider) // Blocks until the timeout
+	if msg != "unacked" || again == id {
+		t.Errorf("Expected redelivery after timeout, got %q id %d", msg, again)
+	}
+	if provider.Ack(id) {
+		t.Error("Expected the superseded delivery to be unknown")
+	}
+}
+
+func TestWithAcknowledgement_MaxAttempts(t *testing.T) {
+	provider := New(100, WithAcknowledgement(AckConfig{MaxAttempts: 2}))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	slog.New(provider).Info("poison")
+	for i := 0; i < 2; i++ {
+		_, id := readAckID(t, provider)
+		provider.Nack(id)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if record, _ := provider.Read(ctx); record != nil {
+		t.Errorf("Expected the record given up, got %q", record.Msg)
+	}
+	if stats := provider.Stats(); stats.AckFailed != 1 || stats.Unacked != 0 {
+		t.Errorf("Unexpected stats %+v", stats)
+	}
+
+	var derr *DeliveryError
+	select {
+	case err := <-provider.Errors():
+		if !errors.As(err, &derr) || derr.Attempts != 2 {
+			t.Errorf("Expected DeliveryError after 2 attempts, got %v", err)
+		}
+	default:
+		t.Error("Expected a DeliveryError on Errors")
+	}
+}
+
+func TestWithAcknowledgement_EndOfStream(t *testing.T) {
+	provider := New(100, WithAcknowledgement(AckConfig{}))
+	slog.New(provider).Info("last")
+	_, id := readAckID(t, provider)
+	_ = provider.Close()
+
+	provider.Nack(id)
+	if msg, _ := readAckID(t, provider); msg != "last" {
+		t.Errorf("Expected redelivery after Close, got %q", msg)
+	}
+	if record, err := provider.Read(context.Background()); record != nil || err != nil {
+		t.Errorf("Expected end of stream with the redelivery pending ack, got %v (err=%v)", record, err)
+	}
+	if provider.Stats().Unacked != 1 {
+		t.Errorf("Expected the unacknowledged record reported")
+	}
+}
+
+// flakyWriter fails the first write.
+type flakyWriter struct {
+	mu     sync.Mutex
+	failed bool
+	out    strings.Builder
+}
+
+func (w *flakyWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if !w.failed {
+		w.failed = true
+		return 0, errors.New("disk full")
+	}
+	return w.out.Write(p)
+}
+
+func (w *flakyWriter) Sync() error { return nil }
+
+func (w *flakyWriter) String() string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.out.String()
+}
+
+func TestAckWriter_RedeliversFailedWrites(t *testing.T) {
+	provider := New(100, WithAcknowledgement(AckConfig{}))
+	out := &flakyWriter{}
+
+	logger, err := iris.NewReaderLogger(iris.Config{
+		Output:  provider.AckWriter(out),
+		Encoder: iris.NewJSONEncoder(),
+		Level:   iris.Debug,
+	}, []iris.SyncReader{provider})
+	if err != nil {
+		t.Fatalf("Failed to create ReaderLogger: %v", err)
+	}
+	defer func() { _ = logger.Close() }() // Ignore error in test cleanup
+	logger.Start()
+
+	slog.New(provider).Info("audit event")
+
+	deadline := time.Now().Add(2 * time.Second)
+	for provider.Stats().Redelivered == 0 || provider.Stats().Unacked != 0 {
+		if time.Now().After(deadline) {
+			t.Fatalf("Record not redelivered and acknowledged, stats %+v", provider.Stats())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if !strings.Contains(out.String(), "audit event") {
+		t.Errorf("Expected the redelivered record written, got %q", out.String())
+	}
+}
+
+func TestScanAckIDs(t *testing.T) {
+	b := []byte(`{"msg":"ack_id=5","ack_id":12}` + "\n" + `level=info msg=b ack_id=7` + "\n" + `{"ack_ids":3}` + "\n")
+	ids := scanAckIDs(b)
+	if len(ids) != 2 || ids[0] != 12 || ids[1] != 7 {
+		t.Errorf("scanAckIDs = %v, want [12 7]", ids)
+	}
+}
@@ -0,0 +1,197 @@
+// aggregator.go: Multiplexing many providers into one iris.SyncReader
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"slices"
+	"sync"
+
+	"github.com/agilira/iris"
+)
+
+// Aggregator exposes many providers as a single iris.SyncReader.
+//
+// Iris takes its readers at construction, while applications often create
+// slog handlers across many libraries over their lifetime. An Aggregator is
+// registered with Iris once, and providers are added and removed at runtime:
+//
+//	agg := slogprovider.NewAggregator()
+//	logger, _ := iris.NewReaderLogger(config, []iris.SyncReader{agg})
+//
+//	db := slogprovider.New(1000, slogprovider.WithMinLevel(slog.LevelWarn))
+//	agg.Add(db)
+//	dbLogger := slog.New(db)
+//
+// Each provider keeps its own buffer and options; the Aggregator reads from
+// them in round-robin order, so a busy provider cannot starve the others.
+// Providers must not be read by anyone else while they are members. A member
+// that is closed is removed automatically once its buffer is drained.
+type Aggregator struct {
+	mu      sync.Mutex
+	members []*Provider
+	next    int           // Round-robin start for the next read
+	changed chan struct{} // Wakes readers when members are added
+	closed  chan struct{}
+	once    sync.Once
+}
+
+// NewAggregator creates an Aggregator with the given initial members.
+func NewAggregator(members ...*Provider) *Aggregator {
+	a := &Aggregator{
+		changed: make(chan struct{}, 1),
+		closed:  make(chan struct{}),
+	}
+	for _, p := range members {
+		a.Add(p)
+	}
+	return a
+}
+
+// Add adds p to the aggregated providers. Adding a provider twice, or adding
+// a nil provider, has no effect. Providers added after Close are closed.
+func (a *Aggregator) Add(p *Provider) {
+	if p == nil {
+		return
+	}
+	select {
+	case <-a.closed:
+		_ = p.Close() // Close never fails
+		return
+	default:
+	}
+
+	a.mu.Lock()
+	if !slices.Contains(a.members, p) {
+		a.members = append(a.members, p)
+	}
+	a.mu.Unlock()
+
+	select {
+	case a.changed <- struct{}{}:
+	default:
+	}
+}
+
+// Remove removes p from the aggregated providers and reports whether it was
+// a member. The provider is not closed and its buffered records stay in it.
+func (a *Aggregator) Remove(p *Provider) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	i := slices.Index(a.members, p)
+	if i < 0 {
+		return false
+	}
+	a.members = slices.Delete(a.members, i, i+1)
+	return true
+}
+
+// Members returns the current members.
+func (a *Aggregator) Members() []*Provider {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return slices.Clone(a.members)
+}
+
+// Read implements iris.SyncReader, returning the next record of any member.
+//
+// Read blocks until a member has a record, ctx is cancelled, or the
+// Aggregator is closed and all members are drained, in which case it
+// reports end of stream with (nil, nil).
+func (a *Aggregator) Read(ctx context.Context) (*iris.Record, error) {
+	for {
+		members, start := a.snapshot()
+		for i := range members {
+			p := members[(start+i)%len(members)]
+			if record := p.poll(); record != nil {
+				a.advance(start + i + 1)
+				return record, nil
+			}
+		}
+
+		live := a.prune(members)
+		if len(live) == 0 {
+			select {
+			case <-a.closed:
+				return nil, nil
+			default:
+			}
+		}
+		if err := a.wait(ctx, live); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// Close implements io.Closer. It closes the Aggregator and all members;
+// Read returns their remaining records and then reports end of stream.
+// Close is idempotent.
+func (a *Aggregator) Close() error {
+	var errs []error
+	a.once.Do(func() {
+		close(a.closed)
+		for _, p := range a.Members() {
+			errs = append(errs, p.Close())
+		}
+	})
+	return errors.Join(errs...)
+}
+
+// snapshot returns the members and the round-robin start index.
+func (a *Aggregator) snapshot() ([]*Provider, int) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if len(a.members) == 0 {
+		return nil, 0
+	}
+	return slices.Clone(a.members), a.next % len(a.members)
+}
+
+// advance sets the round-robin start for the next read.
+func (a *Aggregator) advance(next int) {
+	a.mu.Lock()
+	a.next = next
+	a.mu.Unlock()
+}
+
+// prune removes drained members and returns the remaining ones.
+func (a *Aggregator) prune(members []*Provider) []*Provider {
+	live := members[:0]
+	for _, p := range members {
+		if p.drained() {
+			a.Remove(p)
+			continue
+		}
+		live = append(live, p)
+	}
+	return live
+}
+
+// wait blocks until a member may have a record, membership changes, the
+// Aggregator is closed, or ctx is done.
+func (a *Aggregator) wait(ctx context.Context, members []*Provider) error {
+	cases := make([]reflect.SelectCase, 0, 3+4*len(members))
+	cases = append(cases,
+		reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ctx.Done())},
+		reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(a.changed)},
+		reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(a.closed)},
+	)
+	for _, p := range members {
+		cases = append(cases,
+			reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(p.queue.notify)},
+			reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(p.closed)},
+			reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(p.resequenceDue())},
+			reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(p.ackDue())},
+		)
+	}
+	if chosen, _, _ := reflect.Select(cases); chosen == 0 {
+		return ctx.Err()
+	}
+	return nil
+}
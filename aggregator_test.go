@@ -0,0 +1,139 @@
+// aggregator_test.go: Tests for the provider aggregator
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/agilira/iris"
+)
+
+// Compile-time check that Aggregator is an iris.SyncReader.
+var _ iris.SyncReader = (*Aggregator)(nil)
+
+func TestAggregator_ReadsAllMembersRoundRobin(t *testing.T) {
+	a, b := New(10), New(10)
+	agg := NewAggregator(a, b)
+	defer func() { _ = agg.Close() }() // Ignore error in test cleanup
+
+	for i := 0; i < 3; i++ {
+		slog.New(a).Info("a")
+		slog.New(b).Info("b")
+	}
+
+	var got []string
+	for i := 0; i < 6; i++ {
+		got = append(got, readWithTimeout(t, agg).Msg)
+	}
+	want := []string{"a", "b", "a", "b", "a", "b"}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("Expected round-robin order %v, got %v", want, got)
+		}
+	}
+}
+
+func TestAggregator_AddWakesBlockedReader(t *testing.T) {
+	agg := NewAggregator()
+	defer func() { _ = agg.Close() }() // Ignore error in test cleanup
+
+	done := make(chan string, 1)
+	go func() {
+		record, err := agg.Read(context.Background())
+		if err != nil || record == nil {
+			done <- ""
+			return
+		}
+		done <- record.Msg
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	p := New(10)
+	agg.Add(p)
+	slog.New(p).Info("late")
+
+	select {
+	case msg := <-done:
+		if msg != "late" {
+			t.Errorf("Expected record from added member, got %q", msg)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Read did not return after a member was added")
+	}
+}
+
+func TestAggregator_Remove(t *testing.T) {
+	p := New(10)
+	agg := NewAggregator(p)
+	defer func() { _ = agg.Close() }() // Ignore error in test cleanup
+
+	agg.Add(p)
+	if len(agg.Members()) != 1 {
+		t.Fatalf("Expected duplicate Add to be ignored, got %d members", len(agg.Members()))
+	}
+	if !agg.Remove(p) || agg.Remove(p) {
+		t.Error("Expected Remove to report membership once")
+	}
+
+	slog.New(p).Info("kept")
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := agg.Read(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected no records from a removed member, got err=%v", err)
+	}
+	if p.Len() != 1 {
+		t.Error("Expected removed member to keep its records")
+	}
+}
+
+func TestAggregator_PrunesClosedMembers(t *testing.T) {
+	p := New(10)
+	agg := NewAggregator(p)
+	defer func() { _ = agg.Close() }() // Ignore error in test cleanup
+
+	slog.New(p).Info("last")
+	_ = p.Close()
+
+	if record := readWithTimeout(t, agg); record.Msg != "last" {
+		t.Errorf("Expected buffered record of closed member, got %q", record.Msg)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, _ = agg.Read(ctx)
+	if len(agg.Members()) != 0 {
+		t.Error("Expected drained closed member to be removed")
+	}
+}
+
+func TestAggregator_CloseDrainsMembers(t *testing.T) {
+	p := New(10)
+	agg := NewAggregator(p)
+	slog.New(p).Info("pending")
+
+	if err := agg.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if err := slog.New(p).Handler().Handle(context.Background(), slog.Record{}); !errors.Is(err, ErrClosed) {
+		t.Errorf("Expected members to be closed, got %v", err)
+	}
+	if record := readWithTimeout(t, agg); record == nil || record.Msg != "pending" {
+		t.Fatal("Expected pending record after Close")
+	}
+	if record := readWithTimeout(t, agg); record != nil {
+		t.Errorf("Expected end of stream, got %q", record.Msg)
+	}
+
+	late := New(10)
+	agg.Add(late)
+	if err := late.Handle(context.Background(), slog.Record{}); !errors.Is(err, ErrClosed) {
+		t.Error("Expected providers added after Close to be closed")
+	}
+}
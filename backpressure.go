@@ -0,0 +1,193 @@
+// backpressure.go: Adaptive admission driven by Iris-side backpressure
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"log/slog"
+	"math"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/agilira/iris"
+)
+
+// BackpressureStage is the admission policy applied from a pressure level
+// upwards.
+type BackpressureStage struct {
+	// Pressure is the pressure, between 0 and 1, from which the stage
+	// applies.
+	Pressure float64
+
+	// MinLevel drops records below it.
+	MinLevel slog.Level
+
+	// SampleEvery admits one in SampleEvery of the remaining records below
+	// slog.LevelError; values below 2 disable sampling.
+	SampleEvery int
+}
+
+// BackpressureConfig configures WithBackpressure.
+type BackpressureConfig struct {
+	// Stages are the admission policies by pressure. Defaults to sampling
+	// one in 2 records below Error from pressure 0.5, and to dropping
+	// records below Warn and sampling one in 4 of the rest from 0.8.
+	Stages []BackpressureStage
+
+	// SlowWrite is the write latency at which a BackpressureWriter reports
+	// full pressure; latencies below it report proportionally less. 100ms
+	// when zero.
+	SlowWrite time.Duration
+}
+
+// WithBackpressure adapts admission to backpressure reported by the Iris
+// side of the pipeline, so a slow writer makes the provider shed low-value
+// records at the source instead of filling every buffer on the way:
+//
+//	provider := slogprovider.New(10000, slogprovider.WithBackpressure(slogprovider.BackpressureConfig{}))
+//	logger, _ := iris.New(iris.Config{
+//	    Output: iris.MultiWriter(
+//	        provider.BackpressureWriter("file", fileWriter),
+//	        provider.BackpressureWriter("network", networkWriter),
+//	    ),
+//	})
+//
+// Pressure is reported per source, between 0 (idle) and 1 (saturated), by
+// BackpressureWriter or directly with ReportBackpressure. The provider
+// applies the stage of the highest pressure across all sources, so one slow
+// writer among several is enough to back off. Records rejected by a stage
+// are counted in Stats().BackpressureDropped, and Stats().Backpressure
+// reports the current pressure.
+func WithBackpressure(cfg BackpressureConfig) Option {
+	if cfg.Stages == nil {
+		cfg.Stages = []BackpressureStage{
+			{Pressure: 0.5, MinLevel: slog.LevelDebug, SampleEvery: 2},
+			{Pressure: 0.8, MinLevel: slog.LevelWarn, SampleEvery: 4},
+		}
+	}
+	cfg.Stages = append([]BackpressureStage(nil), cfg.Stages...)
+	sort.SliceStable(cfg.Stages, func(i, j int) bool { return cfg.Stages[i].Pressure < cfg.Stages[j].Pressure })
+	if cfg.SlowWrite <= 0 {
+		cfg.SlowWrite = 100 * time.Millisecond
+	}
+	return func(o *options) { o.backpressure = &cfg }
+}
+
+// backpressure tracks the reported pressure and the active stage.
+type backpressure struct {
+	cfg     BackpressureConfig
+	mu      sync.Mutex
+	sources map[string]float64
+	current atomic.Uint64 // math.Float64bits of the highest pressure
+	stage   atomic.Int32  // Index of the active stage, -1 for none
+	counter atomic.Uint64
+}
+
+// newBackpressure creates the state for cfg, or nil if cfg is nil.
+func newBackpressure(cfg *BackpressureConfig) *backpressure {
+	if cfg == nil {
+		return nil
+	}
+	b := &backpressure{cfg: *cfg, sources: make(map[string]float64)}
+	b.stage.Store(-1)
+	return b
+}
+
+// report records the pressure of source and selects the active stage.
+func (b *backpressure) report(source string, pressure float64) {
+	if math.IsNaN(pressure) {
+		pressure = 0
+	}
+	pressure = min(max(pressure, 0), 1)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.sources[source] = pressure
+	highest := 0.0
+	for _, v := range b.sources {
+		highest = max(highest, v)
+	}
+	stage := -1
+	for i, s := range b.cfg.Stages {
+		if highest >= s.Pressure {
+			stage = i
+		}
+	}
+	b.current.Store(math.Float64bits(highest))
+	b.stage.Store(int32(stage)) // #nosec G115 -- stage count is small
+}
+
+// pressureOrZero returns the highest reported pressure, or 0 when b is nil.
+func (b *backpressure) pressureOrZero() float64 {
+	if b == nil {
+		return 0
+	}
+	return math.Float64frombits(b.current.Load())
+}
+
+// admit applies the active stage to a record at level.
+func (b *backpressure) admit(level slog.Level) bool {
+	i := b.stage.Load()
+	if i < 0 {
+		return true
+	}
+	s := &b.cfg.Stages[i]
+	if level < s.MinLevel {
+		return false
+	}
+	if level >= slog.LevelError || s.SampleEvery < 2 {
+		return true
+	}
+	return b.counter.Add(1)%uint64(s.SampleEvery) == 1 // #nosec G115 -- SampleEvery is positive
+}
+
+// ReportBackpressure reports the pressure of source, between 0 (idle) and 1
+// (saturated), e.g. from the fill level of an Iris writer queue. It has no
+// effect unless WithBackpressure is configured.
+func (p *Provider) ReportBackpressure(source string, pressure float64) {
+	if p.backpressure != nil {
+		p.backpressure.report(source, pressure)
+	}
+}
+
+// BackpressureWriter is an iris.WriteSyncer that reports the latency of
+// its writes as backpressure of one source.
+type BackpressureWriter struct {
+	p    *Provider
+	name string
+	w    iris.WriteSyncer
+	slow time.Duration
+}
+
+// BackpressureWriter wraps w so that its writes report backpressure under
+// the source name: the latency of each write relative to SlowWrite, or full
+// pressure when the write fails.
+func (p *Provider) BackpressureWriter(name string, w iris.WriteSyncer) *BackpressureWriter {
+	slow := 100 * time.Millisecond
+	if p.opts.backpressure != nil {
+		slow = p.opts.backpressure.SlowWrite
+	}
+	return &BackpressureWriter{p: p, name: name, w: w, slow: slow}
+}
+
+// Write writes data to the wrapped writer and reports its latency.
+func (b *BackpressureWriter) Write(data []byte) (int, error) {
+	start := time.Now()
+	n, err := b.w.Write(data)
+	pressure := 1.0
+	if err == nil {
+		pressure = float64(time.Since(start)) / float64(b.slow)
+	}
+	b.p.ReportBackpressure(b.name, pressure)
+	return n, err
+}
+
+// Sync implements iris.WriteSyncer.
+func (b *BackpressureWriter) Sync() error {
+	return b.w.Sync()
+}
@@ -0,0 +1,105 @@
+// backpressure_test.go: Tests for adaptive admission under Iris backpressure
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+// slowWriter is an iris.WriteSyncer whose writes take delay or fail.
+type slowWriter struct {
+	bytes.Buffer
+	delay time.Duration
+	err   error
+}
+
+func (w *slowWriter) Write(b []byte) (int, error) {
+	time.Sleep(w.delay)
+	if w.err != nil {
+		return 0, w.err
+	}
+	return w.Buffer.Write(b)
+}
+
+func (w *slowWriter) Sync() error { return nil }
+
+func TestWithBackpressure_Stages(t *testing.T) {
+	provider := New(100, WithMinLevel(slog.LevelDebug), WithBackpressure(BackpressureConfig{}))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+	logger := slog.New(provider)
+
+	logAll := func() {
+		for i := 0; i < 4; i++ {
+			logger.Debug("debug")
+			logger.Info("info")
+			logger.Error("error")
+		}
+	}
+
+	logAll()
+	if n := provider.Len(); n != 12 {
+		t.Fatalf("Expected all 12 records without pressure, got %d", n)
+	}
+
+	provider.ReportBackpressure("file", 0.6)
+	logAll()
+	if n := provider.Len(); n != 12+4+4 {
+		t.Errorf("Expected half of debug and info plus all errors at 0.6, got %d new", n-12)
+	}
+
+	provider.ReportBackpressure("network", 0.9)
+	logAll()
+	if n := provider.Len(); n != 20+4 {
+		t.Errorf("Expected only errors at 0.9, got %d new", n-20)
+	}
+
+	stats := provider.Stats()
+	if stats.Backpressure != 0.9 || stats.BackpressureDropped != 4+8 {
+		t.Errorf("Unexpected stats %+v", stats)
+	}
+
+	// Pressure is the highest across sources.
+	provider.ReportBackpressure("network", 0)
+	if got := provider.Stats().Backpressure; got != 0.6 {
+		t.Errorf("Expected pressure 0.6 after network recovered, got %v", got)
+	}
+	provider.ReportBackpressure("file", 0)
+	logAll()
+	if n := provider.Len(); n != 24+12 {
+		t.Errorf("Expected all records after recovery, got %d new", n-24)
+	}
+}
+
+func TestBackpressureWriter_ReportsLatency(t *testing.T) {
+	provider := New(100, WithBackpressure(BackpressureConfig{SlowWrite: 10 * time.Millisecond}))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	fast := provider.BackpressureWriter("fast", &slowWriter{})
+	if _, err := fast.Write([]byte("line\n")); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	if got := provider.Stats().Backpressure; got >= 0.5 {
+		t.Errorf("Expected low pressure for a fast writer, got %v", got)
+	}
+
+	slow := provider.BackpressureWriter("slow", &slowWriter{delay: 20 * time.Millisecond})
+	if _, err := slow.Write([]byte("line\n")); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	if got := provider.Stats().Backpressure; got != 1 {
+		t.Errorf("Expected full pressure for a slow writer, got %v", got)
+	}
+
+	failing := provider.BackpressureWriter("failing", &slowWriter{err: errors.New("disk full")})
+	if _, err := failing.Write([]byte("line\n")); err == nil {
+		t.Error("Expected the write error to be returned")
+	}
+}
@@ -0,0 +1,63 @@
+// banner.go: Startup record documenting the provider configuration
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"log/slog"
+	"time"
+)
+
+// Startup banner record emitted with WithStartupBanner.
+const (
+	BannerMessage = "slog provider started" // Message of the banner record
+	ConfigKey     = "config"                // Effective configuration as a JSON document
+	ConfigHashKey = "config.hash"           // Hex SHA-256 of the configuration document
+)
+
+// WithStartupBanner buffers one record describing the provider's effective
+// configuration ahead of the first handled record, so log archives document
+// how the bridge was configured when the data was produced:
+//
+//	{"level":"info","msg":"slog provider started","config.hash":"9f2c...",
+//	 "config":"{\"buffer_capacity\":1000,\"drop_oldest\":true,...}"}
+//
+// The configuration document is the "config" object of DumpJSON and the hash
+// is that of ConfigHash, so identically configured providers share a hash.
+// The banner is buffered at Info level regardless of the minimum level,
+// filters and sampling, but it is dropped like any record when the buffer is
+// full.
+func WithStartupBanner() Option {
+	return func(o *options) { o.banner = true }
+}
+
+// ConfigHash returns a fingerprint of the provider's effective configuration:
+// the hex SHA-256 of the "config" document of DumpJSON.
+func (p *Provider) ConfigHash() string {
+	_, hash := p.configDocument()
+	return hash
+}
+
+// configDocument returns the JSON configuration document and its hash.
+func (p *Provider) configDocument() (string, string) {
+	data, err := json.Marshal(p.configSnapshot())
+	if err != nil {
+		return "", "" // Unreachable: the snapshot holds only plain values
+	}
+	sum := sha256.Sum256(data)
+	return string(data), hex.EncodeToString(sum[:])
+}
+
+// emitBanner buffers the WithStartupBanner record.
+func (p *Provider) emitBanner() {
+	doc, hash := p.configDocument()
+	record := slog.NewRecord(time.Now(), slog.LevelInfo, BannerMessage, 0)
+	record.AddAttrs(slog.String(ConfigHashKey, hash), slog.String(ConfigKey, doc))
+	_ = p.enqueue(entry{record: record}) // Closed providers have no reader left
+}
@@ -0,0 +1,71 @@
+// banner_test.go: Tests for the startup configuration record
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"encoding/json"
+	"log/slog"
+	"testing"
+)
+
+func TestWithStartupBanner_PrecedesFirstRecord(t *testing.T) {
+	provider := New(10, WithStartupBanner(), WithMinLevel(slog.LevelWarn), WithDropOldest())
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	logger := slog.New(provider)
+	if provider.Len() != 0 {
+		t.Fatal("Expected no banner before the first record")
+	}
+	logger.Warn("first")
+	logger.Warn("second")
+
+	msgs := readMessages(t, provider, 3)
+	if msgs[0] != BannerMessage || msgs[1] != "first" || msgs[2] != "second" {
+		t.Fatalf("Expected a single banner ahead of the records, got %v", msgs)
+	}
+}
+
+func TestWithStartupBanner_DescribesConfiguration(t *testing.T) {
+	provider := New(10, WithStartupBanner(), WithDropOldest())
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	record := readRecord(t, provider, func(l *slog.Logger) { l.Info("hello") })
+	if record.Msg != BannerMessage {
+		t.Fatalf("Expected the banner first, got %q", record.Msg)
+	}
+	hash, ok := findField(record, ConfigHashKey)
+	if !ok || hash.StringValue() != provider.ConfigHash() {
+		t.Errorf("%s = %v, want %q", ConfigHashKey, hash, provider.ConfigHash())
+	}
+	doc, _ := findField(record, ConfigKey)
+	var config map[string]any
+	if err := json.Unmarshal([]byte(doc.StringValue()), &config); err != nil {
+		t.Fatalf("Invalid configuration document %q: %v", doc.StringValue(), err)
+	}
+	if config["buffer_capacity"] != float64(10) || config["drop_oldest"] != true || config["startup_banner"] != true {
+		t.Errorf("Unexpected configuration document %v", config)
+	}
+}
+
+func TestProvider_ConfigHash(t *testing.T) {
+	a, b, c := New(10, WithSequence()), New(10, WithSequence()), New(20, WithSequence())
+	defer func() { _, _, _ = a.Close(), b.Close(), c.Close() }() // Ignore error in test cleanup
+
+	if a.ConfigHash() != b.ConfigHash() {
+		t.Error("Expected identical configurations to share a hash")
+	}
+	if a.ConfigHash() == c.ConfigHash() {
+		t.Error("Expected different configurations to have different hashes")
+	}
+	if len(a.ConfigHash()) != 64 {
+		t.Errorf("ConfigHash() = %q, want a hex SHA-256", a.ConfigHash())
+	}
+	slog.New(a).Info("no banner")
+	if a.Len() != 1 {
+		t.Error("Expected no banner without WithStartupBanner")
+	}
+}
@@ -0,0 +1,173 @@
+// boost.go: Temporary verbosity boost triggered by error records
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// BoostConfig configures the verbosity boost enabled by WithErrorBoost.
+type BoostConfig struct {
+	// Trigger is the level of the records that start a boost; slog.LevelError
+	// when nil.
+	Trigger slog.Leveler
+
+	// Level is the minimum level accepted while boosted; slog.LevelDebug
+	// when nil.
+	Level slog.Leveler
+
+	// Duration is how long a boost lasts after the last trigger; 30s when
+	// zero.
+	Duration time.Duration
+
+	// TraceKey, if set, scopes boosts to a trace: only records carrying the
+	// same value under this attribute as a triggering record are boosted.
+	// When empty, boosts apply to every record.
+	TraceKey string
+
+	// TraceID, if set, replaces the TraceKey lookup, e.g. to read the span
+	// context of a tracing library from ctx. Records for which it returns
+	// "" are not boosted.
+	TraceID func(ctx context.Context, record slog.Record) string
+}
+
+// WithErrorBoost temporarily lowers the minimum level after an error, so the
+// detailed context around a failure is captured exactly when it happens:
+//
+//	provider := slogprovider.New(10000,
+//	    slogprovider.WithMinLevel(slog.LevelInfo),
+//	    slogprovider.WithErrorBoost(slogprovider.BoostConfig{Duration: 30 * time.Second}),
+//	)
+//
+// Each record at or above Trigger starts or extends a boost, during which
+// records at or above Level pass the level checks of WithMinLevel,
+// WithLevelOverrides and level rules. With TraceKey or TraceID, each trace
+// is boosted separately and records outside a boosted trace are unaffected.
+// Filters, samplers and the other admission checks still apply. Records
+// admitted only because of a boost are counted in Stats().Boosted.
+func WithErrorBoost(cfg BoostConfig) Option {
+	if cfg.Trigger == nil {
+		cfg.Trigger = slog.LevelError
+	}
+	if cfg.Level == nil {
+		cfg.Level = slog.LevelDebug
+	}
+	if cfg.Duration <= 0 {
+		cfg.Duration = 30 * time.Second
+	}
+	return func(o *options) { o.boost = &cfg }
+}
+
+// booster tracks the active boosts.
+type booster struct {
+	cfg   BoostConfig
+	until atomic.Int64 // Unix nanoseconds at which the latest boost ends
+
+	mu     sync.Mutex
+	traces map[string]int64 // Trace ID -> Unix nanoseconds at which its boost ends
+}
+
+// newBooster creates a booster for cfg, or nil if cfg is nil.
+func newBooster(cfg *BoostConfig) *booster {
+	if cfg == nil {
+		return nil
+	}
+	return &booster{cfg: *cfg, traces: make(map[string]int64)}
+}
+
+// scoped reports whether boosts apply per trace.
+func (b *booster) scoped() bool {
+	return b.cfg.TraceKey != "" || b.cfg.TraceID != nil
+}
+
+// traceID returns the trace ID of record, or "" if it has none.
+func (b *booster) traceID(ctx context.Context, record slog.Record) string {
+	if b.cfg.TraceID != nil {
+		return b.cfg.TraceID(ctx, record)
+	}
+	var id string
+	record.Attrs(func(attr slog.Attr) bool {
+		if attr.Key == b.cfg.TraceKey {
+			id = attr.Value.Resolve().String()
+			return false
+		}
+		return true
+	})
+	return id
+}
+
+// observe starts or extends a boost if record is a trigger.
+func (b *booster) observe(ctx context.Context, record slog.Record) {
+	if record.Level < b.cfg.Trigger.Level() {
+		return
+	}
+	now := time.Now().UnixNano()
+	until := now + int64(b.cfg.Duration)
+	if b.scoped() {
+		id := b.traceID(ctx, record)
+		if id == "" {
+			return
+		}
+		b.mu.Lock()
+		for trace, end := range b.traces {
+			if end <= now {
+				delete(b.traces, trace)
+			}
+		}
+		b.traces[id] = until
+		b.mu.Unlock()
+	}
+	for {
+		current := b.until.Load()
+		if current >= until || b.until.CompareAndSwap(current, until) {
+			return
+		}
+	}
+}
+
+// enabled reports whether level may be boosted at all right now. With
+// per-trace boosts it is true while any trace is boosted.
+func (b *booster) enabled(level slog.Level) bool {
+	return level >= b.cfg.Level.Level() && time.Now().UnixNano() < b.until.Load()
+}
+
+// admits reports whether record is boosted.
+func (b *booster) admits(ctx context.Context, record slog.Record) bool {
+	if !b.enabled(record.Level) {
+		return false
+	}
+	if !b.scoped() {
+		return true
+	}
+	id := b.traceID(ctx, record)
+	if id == "" {
+		return false
+	}
+	b.mu.Lock()
+	until, ok := b.traces[id]
+	b.mu.Unlock()
+	return ok && time.Now().UnixNano() < until
+}
+
+// boostEnabled reports whether an active boost may admit level.
+func (p *Provider) boostEnabled(level slog.Level) bool {
+	return p.boost != nil && p.boost.enabled(level)
+}
+
+// boosted reports whether record, rejected by the level checks, is admitted
+// by an active boost.
+func (p *Provider) boosted(ctx context.Context, record slog.Record) bool {
+	if p.boost == nil || !p.boost.admits(ctx, record) {
+		return false
+	}
+	p.stats.boosted.Add(1)
+	return true
+}
@@ -0,0 +1,95 @@
+// boost_test.go: Tests for the error-triggered verbosity boost
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func TestWithErrorBoost_AdmitsDebugAfterError(t *testing.T) {
+	provider := New(100, WithMinLevel(slog.LevelInfo), WithErrorBoost(BoostConfig{Duration: time.Hour}))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+	logger := slog.New(provider)
+
+	if provider.Enabled(context.Background(), slog.LevelDebug) {
+		t.Error("Debug must be disabled before a boost")
+	}
+	logger.Debug("before error")
+	logger.Error("failure")
+	if !provider.Enabled(context.Background(), slog.LevelDebug) {
+		t.Error("Debug must be enabled during a boost")
+	}
+	logger.Debug("after error")
+	logger.Log(context.Background(), slog.LevelDebug-4, "below boost level")
+
+	msgs := readMessages(t, provider, 2)
+	if msgs[0] != "failure" || msgs[1] != "after error" {
+		t.Errorf("Unexpected records %v", msgs)
+	}
+	if n := provider.Len(); n != 0 {
+		t.Errorf("Expected an empty buffer, got %d records", n)
+	}
+	if stats := provider.Stats(); stats.Boosted != 1 {
+		t.Errorf("Expected 1 boosted record, got %d", stats.Boosted)
+	}
+}
+
+func TestWithErrorBoost_Expires(t *testing.T) {
+	provider := New(100, WithMinLevel(slog.LevelInfo), WithErrorBoost(BoostConfig{Duration: 10 * time.Millisecond}))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+	logger := slog.New(provider)
+
+	logger.Error("failure")
+	time.Sleep(20 * time.Millisecond)
+	if provider.Enabled(context.Background(), slog.LevelDebug) {
+		t.Error("Debug must be disabled after the boost ended")
+	}
+	logger.Debug("too late")
+	if n := provider.Len(); n != 1 {
+		t.Errorf("Expected only the error to be buffered, got %d records", n)
+	}
+}
+
+func TestWithErrorBoost_PerTrace(t *testing.T) {
+	provider := New(100, WithMinLevel(slog.LevelInfo), WithErrorBoost(BoostConfig{TraceKey: "trace_id", Duration: time.Hour}))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+	logger := slog.New(provider)
+
+	logger.Error("failure", "trace_id", "a")
+	logger.Debug("same trace", "trace_id", "a")
+	logger.Debug("other trace", "trace_id", "b")
+	logger.Debug("untraced")
+
+	msgs := readMessages(t, provider, 2)
+	if msgs[0] != "failure" || msgs[1] != "same trace" {
+		t.Errorf("Unexpected records %v", msgs)
+	}
+	if n := provider.Len(); n != 0 {
+		t.Errorf("Expected an empty buffer, got %d records", n)
+	}
+}
+
+func TestWithErrorBoost_GroupHandler(t *testing.T) {
+	provider := New(100,
+		WithLevelOverrides(map[string]slog.Leveler{"db": slog.LevelWarn}),
+		WithErrorBoost(BoostConfig{Duration: time.Hour}),
+	)
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+	db := slog.New(provider).WithGroup("db")
+
+	db.Info("ignored")
+	db.Error("failure")
+	db.Info("boosted")
+
+	msgs := readMessages(t, provider, 2)
+	if msgs[0] != "failure" || msgs[1] != "boosted" {
+		t.Errorf("Unexpected records %v", msgs)
+	}
+}
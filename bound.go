@@ -0,0 +1,133 @@
+// bound.go: Attributes bound to derived handlers with WithAttrs
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"context"
+	"log/slog"
+	"sync/atomic"
+
+	"github.com/agilira/iris"
+)
+
+// boundAttrs is a node in the prefix tree of attributes bound with
+// WithAttrs.
+//
+// Each node is an immutable view of the first n attributes of a segment,
+// preceded by the attributes of its parent, so derived handlers share their
+// common prefix and binding never copies the attributes bound before. The
+// full field set is reconstructed at conversion by walking from the root.
+//
+// Attributes are converted to iris fields once, when they are bound, and the
+// cached fields are copied into every record emitted through the handler.
+//
+// Segments are copy-on-write: the first WithAttrs call extending a view
+// whose segment has spare capacity appends in place and returns a longer
+// view of the same segment, which keeps chains of With calls shallow.
+// Further calls extending the same view would overwrite the claimed slots,
+// so they start a new segment below it instead.
+type boundAttrs struct {
+	parent *boundAttrs
+	group  string       // Group path qualifying the keys, e.g. "req" after WithGroup("req")
+	seg    *attrSegment // Shared field storage
+	n      int          // Number of fields of seg in this view
+}
+
+// attrSegment is the shared storage of bound attribute views, holding the
+// converted fields.
+type attrSegment struct {
+	fields  []iris.Field // Backing array, never reallocated
+	claimed atomic.Int64 // Number of slots written so far
+}
+
+// Segment capacity bounds. A new segment reserves room for as many further
+// attributes as it starts with, or doubles the full segment it extends,
+// within these limits.
+const (
+	minSegmentCap = 4
+	maxSegmentCap = 64
+)
+
+// bind returns the view of attrs bound under the group path group below
+// parent. The attributes are converted now, once, rather than for every
+// record, so LogValuer values are resolved when bound, as slog's own handlers
+// do; with WithReplaceAttr, they are rewritten first, and with WithEncryption,
+// the configured attributes are then encrypted.
+func (p *Provider) bind(parent *boundAttrs, group string, attrs []slog.Attr) *boundAttrs {
+	if p.opts.replaceAttr != nil {
+		groups, replaced := loggerGroups(group), attrs[:0:0]
+		for _, attr := range attrs {
+			replaced = append(replaced, p.opts.replaceAttrs(groups, attr)...)
+		}
+		attrs = replaced
+	}
+	var buf [8]iris.Field // Avoids a temporary allocation for typical bindings
+	fields := buf[:0]
+	for _, attr := range attrs {
+		if c := p.opts.encrypt; c != nil {
+			encrypted, err := c.encryptAttr(context.Background(), group, attr)
+			if err != nil {
+				// Omit the attribute rather than keep it in plaintext.
+				p.stats.encryptionErrors.Add(1)
+				p.reportError(err)
+				continue
+			}
+			attr = encrypted
+		}
+		prefix := group
+		if prov := p.opts.provenance; prov != nil && prov.Bound != "" {
+			prefix = prov.Bound
+			if group != "" {
+				prefix = joinPath(prov.Bound, group)
+			}
+		}
+		p.flattenAttr(prefix, attr, func(f iris.Field) bool {
+			fields = append(fields, f)
+			return true
+		})
+	}
+	if len(fields) == 0 {
+		return parent
+	}
+
+	if parent != nil && parent.group == group {
+		seg, n, k := parent.seg, parent.n, len(fields)
+		if n+k <= len(seg.fields) && seg.claimed.CompareAndSwap(int64(n), int64(n+k)) {
+			copy(seg.fields[n:n+k], fields)
+			return &boundAttrs{parent: parent.parent, group: group, seg: seg, n: n + k}
+		}
+	}
+
+	// Grow geometrically along chains that outgrew their segment, but keep
+	// branches off a shared view small: they are typically per request.
+	size := 2 * len(fields)
+	if parent != nil && parent.n == len(parent.seg.fields) {
+		size = max(size, 2*parent.n)
+	}
+	size = max(min(max(size, minSegmentCap), maxSegmentCap), len(fields))
+	seg := &attrSegment{fields: make([]iris.Field, size)}
+	copy(seg.fields, fields)
+	seg.claimed.Store(int64(len(fields)))
+	return &boundAttrs{parent: parent, group: group, seg: seg, n: len(fields)}
+}
+
+// addBoundFields adds the fields of b and its ancestors to record, oldest
+// binding first, reporting false once the record is full.
+func (p *Provider) addBoundFields(record *iris.Record, b *boundAttrs) bool {
+	if b == nil {
+		return true
+	}
+	if !p.addBoundFields(record, b.parent) {
+		return false
+	}
+	for _, field := range b.seg.fields[:b.n] {
+		if !record.AddField(field) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,273 @@
+// bound_test.go: Tests for attributes bound with WithAttrs
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/agilira/iris"
+)
+
+// fieldKeys returns the keys of the fields of record, in order.
+func fieldKeys(record *iris.Record) []string {
+	keys := make([]string, 0, record.FieldCount())
+	for i := 0; i < record.FieldCount(); i++ {
+		if key := record.GetField(i).Key(); key != slog.TimeKey {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+func TestWithAttrs_BindsAttributes(t *testing.T) {
+	provider := New(10)
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	record := readRecord(t, provider, func(logger *slog.Logger) {
+		logger.With("service", "api", "version", 3).Info("started", "port", 8080)
+	})
+
+	if got := strings.Join(fieldKeys(record), ","); got != "service,version,port" {
+		t.Errorf("Field order = %s, want service,version,port", got)
+	}
+	if f, _ := findField(record, "version"); f.IntValue() != 3 {
+		t.Errorf("Expected a typed version field, got %v", f)
+	}
+}
+
+func TestWithAttrs_DeepChains(t *testing.T) {
+	provider := New(10)
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	record := readRecord(t, provider, func(logger *slog.Logger) {
+		logger.With("a", 1).
+			WithGroup("req").With("id", 7).
+			WithGroup("user").With("name", "alice").With("role", "admin").
+			Info("deep", "n", 1)
+	})
+
+	want := "a,req.id,req.user.name,req.user.role,req.user.n"
+	if got := strings.Join(fieldKeys(record), ","); got != want {
+		t.Errorf("Fields = %s, want %s", got, want)
+	}
+}
+
+func TestWithAttrs_SharedPrefixes(t *testing.T) {
+	provider := New(10)
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	base := slog.New(provider).With("svc", "api")
+	left := base.With("side", "left")
+	right := base.With("side", "right")
+
+	for _, tc := range []struct {
+		logger *slog.Logger
+		want   string
+	}{
+		{right, "right"},
+		{left, "left"},
+	} {
+		record := readRecord(t, provider, func(*slog.Logger) { tc.logger.Info("msg") })
+		if got := strings.Join(fieldKeys(record), ","); got != "svc,side" {
+			t.Errorf("Fields = %s, want svc,side", got)
+		}
+		if f, _ := findField(record, "side"); f.StringValue() != tc.want {
+			t.Errorf("side = %q, want %q", f.StringValue(), tc.want)
+		}
+	}
+}
+
+func TestWithAttrs_ThousandsOfBindings(t *testing.T) {
+	provider := New(10)
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	logger := slog.New(provider)
+	for i := 0; i < 5000; i++ {
+		logger = logger.With(fmt.Sprintf("k%d", i), i)
+	}
+	record := readRecord(t, provider, func(*slog.Logger) { logger.Info("full") })
+
+	// Iris keeps the first 32 fields: the record time and k0 to k30.
+	if n := record.FieldCount(); n != 32 {
+		t.Fatalf("Expected 32 fields, got %d", n)
+	}
+	if f := record.GetField(31); f.Key() != "k30" {
+		t.Errorf("Last field = %s, want k30", f.Key())
+	}
+}
+
+func TestWithAttrs_LevelOverridesUseGroupPath(t *testing.T) {
+	provider := New(10, WithLevelOverrides(map[string]slog.Leveler{"db": slog.LevelWarn}))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	db := slog.New(provider).WithGroup("db").With("pool", 1)
+	if db.Enabled(context.Background(), slog.LevelInfo) {
+		t.Error("Expected WithAttrs to keep the group's level")
+	}
+}
+
+func TestWithAttrs_EncryptsBoundAttributes(t *testing.T) {
+	kms := &xorKMS{}
+	provider := New(10, WithEncryption(EncryptionConfig{Keys: []string{"req.token"}, KMS: kms}))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	logger := slog.New(provider).WithGroup("req").With("token", "secret")
+	logger.Info("first")
+	logger.Info("second")
+
+	for i := 0; i < 2; i++ {
+		record := readRecord(t, provider, func(*slog.Logger) {})
+		f, ok := findField(record, "req.token")
+		if !ok || f.StringValue() == "secret" {
+			t.Fatalf("Expected an encrypted req.token field, got %v", f)
+		}
+		if got, err := DecryptValue(context.Background(), kms, "req.token", f.StringValue()); err != nil || got != "secret" {
+			t.Errorf("DecryptValue() = %q, %v", got, err)
+		}
+	}
+	if n := kms.wraps.Load(); n != 1 {
+		t.Errorf("Expected one data key for the bound attribute, got %d", n)
+	}
+}
+
+func TestWithAttrs_SiblingsOfInPlaceAppend(t *testing.T) {
+	provider := New(10)
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	base := slog.New(provider).With("svc", "api")
+	first := base.With("n", 1)         // Appends in place to the segment of base
+	second := base.With("n", 2)        // Must not overwrite the slot of first
+	nested := first.With("extra", "x") // Extends the view of first
+
+	for _, tc := range []struct {
+		logger *slog.Logger
+		keys   string
+		n      int64
+	}{
+		{first, "svc,n", 1},
+		{second, "svc,n", 2},
+		{nested, "svc,n,extra", 1},
+		{base, "svc", 0},
+	} {
+		record := readRecord(t, provider, func(*slog.Logger) { tc.logger.Info("msg") })
+		if got := strings.Join(fieldKeys(record), ","); got != tc.keys {
+			t.Errorf("Fields = %s, want %s", got, tc.keys)
+		}
+		if f, ok := findField(record, "n"); ok && f.IntValue() != tc.n {
+			t.Errorf("n = %d, want %d", f.IntValue(), tc.n)
+		}
+	}
+}
+
+func TestWithAttrs_LinearChainsShareSegments(t *testing.T) {
+	provider := New(10)
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	h := slog.Handler(provider)
+	for i := 0; i < 1000; i++ {
+		h = h.WithAttrs([]slog.Attr{slog.Int(fmt.Sprintf("k%d", i), i)})
+	}
+
+	depth := 0
+	for b := h.(*groupHandler).bound; b != nil; b = b.parent {
+		depth++
+	}
+	if depth > 1000/maxSegmentCap+8 {
+		t.Errorf("Expected chained bindings to share segments, got depth %d", depth)
+	}
+}
+
+func TestWithAttrs_AllocationsIndependentOfBoundSet(t *testing.T) {
+	provider := New(10)
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	base := slog.Handler(provider)
+	for i := 0; i < 50; i++ {
+		base = base.WithAttrs([]slog.Attr{slog.Int(fmt.Sprintf("k%d", i), i)})
+	}
+	attrs := []slog.Attr{slog.String("request_id", "abc")}
+
+	// A derived handler, its view and at most one new segment.
+	allocs := testing.AllocsPerRun(100, func() { _ = base.WithAttrs(attrs) })
+	if allocs > 4 {
+		t.Errorf("WithAttrs allocated %.0f times, want at most 4", allocs)
+	}
+}
+
+func BenchmarkWithAttrs_PerRequest(b *testing.B) {
+	provider := New(100)
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	base := slog.New(provider).With("service", "api", "version", 3, "region", "eu")
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = base.With("request_id", i)
+	}
+}
+
+func TestWithAttrs_ConvertsOnceWhenBound(t *testing.T) {
+	var calls atomic.Int64
+	provider := New(10, WithFieldConverter(FieldConverterFunc(func(key string, value slog.Value) iris.Field {
+		calls.Add(1)
+		return DefaultFieldConverter.ConvertField(key, value)
+	})))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	logger := slog.New(provider).WithGroup("req").With("id", 7, "user", "alice")
+	if n := calls.Load(); n != 2 {
+		t.Fatalf("Expected 2 conversions when binding, got %d", n)
+	}
+
+	for i := 0; i < 3; i++ {
+		record := readRecord(t, provider, func(*slog.Logger) { logger.Info("msg") })
+		if f, ok := findField(record, "req.id"); !ok || f.IntValue() != 7 {
+			t.Errorf("Expected req.id=7, got %v", f)
+		}
+	}
+	if n := calls.Load(); n != 2 {
+		t.Errorf("Expected bound attributes not to be reconverted, got %d conversions", n)
+	}
+}
+
+func TestWithAttrs_JournaldQualifiedKeys(t *testing.T) {
+	provider := New(10, WithJournald(JournaldConfig{UppercaseFields: true}))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	record := readRecord(t, provider, func(logger *slog.Logger) {
+		logger.WithGroup("req").With("id", 7).Info("msg")
+	})
+	if _, ok := findField(record, "REQ_ID"); !ok {
+		t.Errorf("Expected the qualified key in journald form, got %v", fieldKeys(record))
+	}
+}
+
+func TestWithAttrs_ReachesIrisOutput(t *testing.T) {
+	out := &lockedWriter{}
+	logger, shutdown, err := Setup(out)
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+
+	logger.With("request_id", "r-1").Info("direct")
+	slog.New(NewContextHandler(logger.Handler())).With("tenant", "acme").Info("wrapped")
+	if err := shutdown(); err != nil {
+		t.Fatalf("shutdown failed: %v", err)
+	}
+
+	output := out.String()
+	for _, want := range []string{`"request_id":"r-1"`, `"tenant":"acme"`} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected bound attribute %s in Iris output, got:\n%s", want, output)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+// builder.go: Fluent builder for composing providers
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"log/slog"
+	"maps"
+	"sync"
+	"time"
+)
+
+// ProviderBuilder composes a provider configuration step by step, see
+// Builder.
+type ProviderBuilder struct {
+	mu  sync.Mutex
+	cfg Config
+}
+
+// Builder returns a fluent builder for providers, so applications composing
+// many optional behaviors do not end up with long argument lists:
+//
+//	provider, err := slogprovider.Builder().
+//	    BufferSize(1000).
+//	    DropOldest().
+//	    WithMinLevel(slog.LevelInfo).
+//	    Build()
+//
+// The builder starts from a buffer of DefaultBufferSize records and
+// accumulates a Config, which Build validates like NewWithConfig. A builder
+// is reusable: Build may be called any number of times, concurrently too,
+// and later changes to the builder do not affect providers already built.
+// Options and hooks are shared by the providers built with them, so stateful
+// ones, such as a Sampler instance, should not be reused across providers.
+func Builder() *ProviderBuilder {
+	return &ProviderBuilder{cfg: Config{BufferSize: DefaultBufferSize}}
+}
+
+// update applies change to the configuration and returns b.
+func (b *ProviderBuilder) update(change func(cfg *Config)) *ProviderBuilder {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	change(&b.cfg)
+	return b
+}
+
+// BufferSize sets the number of records the buffer holds.
+func (b *ProviderBuilder) BufferSize(size int) *ProviderBuilder {
+	return b.update(func(cfg *Config) { cfg.BufferSize = size })
+}
+
+// DropNewest drops incoming records when the buffer is full. It is the
+// default.
+func (b *ProviderBuilder) DropNewest() *ProviderBuilder {
+	return b.update(func(cfg *Config) { cfg.DropPolicy = DropNewest })
+}
+
+// DropOldest evicts the oldest buffered record when the buffer is full, see
+// WithDropOldest.
+func (b *ProviderBuilder) DropOldest() *ProviderBuilder {
+	return b.update(func(cfg *Config) { cfg.DropPolicy = DropOldest })
+}
+
+// EvictWeighted evicts the lightest buffered record when the buffer is
+// full, see WithWeightedEviction.
+func (b *ProviderBuilder) EvictWeighted() *ProviderBuilder {
+	return b.update(func(cfg *Config) { cfg.DropPolicy = EvictWeighted })
+}
+
+// RetryGrace sets how long Handle retries buffering before dropping, see
+// WithRetryGrace.
+func (b *ProviderBuilder) RetryGrace(grace time.Duration) *ProviderBuilder {
+	return b.update(func(cfg *Config) { cfg.RetryGrace = grace })
+}
+
+// WithMinLevel sets the default minimum level, see WithMinLevel.
+func (b *ProviderBuilder) WithMinLevel(level slog.Level) *ProviderBuilder {
+	return b.update(func(cfg *Config) { cfg.MinLevel = &level })
+}
+
+// WithLevelOverride sets the minimum level of the logger name, see
+// WithLevelOverrides.
+func (b *ProviderBuilder) WithLevelOverride(name string, level slog.Level) *ProviderBuilder {
+	return b.update(func(cfg *Config) {
+		if cfg.LevelOverrides == nil {
+			cfg.LevelOverrides = make(map[string]slog.Level)
+		}
+		cfg.LevelOverrides[name] = level
+	})
+}
+
+// WithReadLevel sets the minimum level applied at Read, see WithReadLevel.
+func (b *ProviderBuilder) WithReadLevel(level slog.Level) *ProviderBuilder {
+	return b.update(func(cfg *Config) { cfg.ReadLevel = &level })
+}
+
+// WithRecordTTL sets the time to live of buffered records up to level, see
+// WithRecordTTL.
+func (b *ProviderBuilder) WithRecordTTL(level slog.Level, ttl time.Duration) *ProviderBuilder {
+	return b.update(func(cfg *Config) {
+		if cfg.RecordTTL == nil {
+			cfg.RecordTTL = make(map[slog.Level]time.Duration)
+		}
+		cfg.RecordTTL[level] = ttl
+	})
+}
+
+// WithSampling applies a TickSampler, see NewTickSampler. Every provider
+// built gets its own sampler.
+func (b *ProviderBuilder) WithSampling(tick time.Duration, first, thereafter int) *ProviderBuilder {
+	return b.update(func(cfg *Config) {
+		cfg.Sampling = &SamplingRule{Tick: tick, First: first, Thereafter: thereafter}
+	})
+}
+
+// WithSequence stamps a per-provider record index, see WithSequence.
+func (b *ProviderBuilder) WithSequence() *ProviderBuilder {
+	return b.update(func(cfg *Config) { cfg.Sequence = true })
+}
+
+// WithHooks registers hooks, see WithHooks.
+func (b *ProviderBuilder) WithHooks(hooks ...any) *ProviderBuilder {
+	return b.update(func(cfg *Config) { cfg.Hooks = append(cfg.Hooks, hooks...) })
+}
+
+// With adds options for behavior without a dedicated builder method. They
+// are applied after the builder settings, in order.
+func (b *ProviderBuilder) With(opts ...Option) *ProviderBuilder {
+	return b.update(func(cfg *Config) { cfg.Options = append(cfg.Options, opts...) })
+}
+
+// Config returns a copy of the accumulated configuration, e.g. to
+// serialize it.
+func (b *ProviderBuilder) Config() Config {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	cfg := b.cfg
+	cfg.LevelOverrides = maps.Clone(cfg.LevelOverrides)
+	cfg.RecordTTL = maps.Clone(cfg.RecordTTL)
+	if cfg.Sampling != nil {
+		sampling := *cfg.Sampling
+		cfg.Sampling = &sampling
+	}
+	if cfg.MinLevel != nil {
+		level := *cfg.MinLevel
+		cfg.MinLevel = &level
+	}
+	if cfg.ReadLevel != nil {
+		level := *cfg.ReadLevel
+		cfg.ReadLevel = &level
+	}
+	cfg.Hooks = append([]any(nil), cfg.Hooks...)
+	cfg.Options = append([]Option(nil), cfg.Options...)
+	return cfg
+}
+
+// Build creates a provider from the accumulated configuration, returning an
+// error describing every invalid setting, see NewWithConfig.
+func (b *ProviderBuilder) Build() (*Provider, error) {
+	return NewWithConfig(b.Config())
+}
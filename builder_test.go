@@ -0,0 +1,106 @@
+// builder_test.go: Tests for the fluent provider builder
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestBuilder_BuildsConfiguredProvider(t *testing.T) {
+	provider, err := Builder().
+		BufferSize(2).
+		DropOldest().
+		WithMinLevel(slog.LevelInfo).
+		WithLevelOverride("db", slog.LevelError).
+		With(WithSequence()).
+		Build()
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	logger := slog.New(provider)
+	if logger.Enabled(context.Background(), slog.LevelDebug) {
+		t.Error("Expected the minimum level to be applied")
+	}
+	if logger.WithGroup("db").Enabled(context.Background(), slog.LevelWarn) {
+		t.Error("Expected the level override to be applied")
+	}
+	for _, msg := range []string{"one", "two", "three"} {
+		logger.Info(msg)
+	}
+	if msgs := readMessages(t, provider, 2); msgs[0] != "two" || msgs[1] != "three" {
+		t.Errorf("Expected the oldest record to be evicted, got %v", msgs)
+	}
+}
+
+func TestBuilder_IsReusable(t *testing.T) {
+	b := Builder().BufferSize(5).WithLevelOverride("db", slog.LevelWarn)
+	first, err := b.Build()
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	defer func() { _ = first.Close() }() // Ignore error in test cleanup
+
+	b.BufferSize(10).WithLevelOverride("db", slog.LevelError)
+	second, err := b.Build()
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	defer func() { _ = second.Close() }() // Ignore error in test cleanup
+
+	if first.Cap() != 5 || second.Cap() != 10 {
+		t.Errorf("Cap() = %d and %d, want 5 and 10", first.Cap(), second.Cap())
+	}
+	if !slog.New(first).WithGroup("db").Enabled(context.Background(), slog.LevelWarn) {
+		t.Error("Expected later builder changes not to affect built providers")
+	}
+}
+
+func TestBuilder_ConcurrentBuilds(t *testing.T) {
+	b := Builder().BufferSize(10).WithSampling(time.Second, 1, 0)
+
+	var wg sync.WaitGroup
+	providers := make([]*Provider, 8)
+	for i := range providers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			providers[i], _ = b.Build()
+		}()
+	}
+	wg.Wait()
+
+	for _, provider := range providers {
+		if provider == nil {
+			t.Fatal("Expected every build to succeed")
+		}
+		slog.New(provider).Info("same")
+		slog.New(provider).Info("same")
+		if n := provider.Len(); n != 1 {
+			t.Errorf("Expected a sampler per provider, got %d buffered records", n)
+		}
+		_ = provider.Close()
+	}
+}
+
+func TestBuilder_ReportsInvalidSettings(t *testing.T) {
+	_, err := Builder().BufferSize(0).RetryGrace(-time.Second).Build()
+	if err == nil || !strings.Contains(err.Error(), "buffer size") || !strings.Contains(err.Error(), "retry grace") {
+		t.Errorf("Expected both invalid settings to be reported, got %v", err)
+	}
+
+	cfg := Builder().EvictWeighted().WithRecordTTL(slog.LevelDebug, time.Minute).Config()
+	if cfg.BufferSize != DefaultBufferSize || cfg.DropPolicy != EvictWeighted || cfg.RecordTTL[slog.LevelDebug] != time.Minute {
+		t.Errorf("Config() = %+v", cfg)
+	}
+}
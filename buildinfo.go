@@ -0,0 +1,62 @@
+// buildinfo.go: Static build identification fields
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"context"
+	"log/slog"
+	"runtime/debug"
+
+	"github.com/agilira/iris"
+)
+
+// Field keys used by WithBuildInfo.
+const (
+	BuildPathKey     = "build.path"     // Main module path
+	BuildVersionKey  = "build.version"  // Main module version, "(devel)" for local builds
+	BuildRevisionKey = "build.revision" // VCS revision the binary was built from
+	BuildDirtyKey    = "build.dirty"    // Whether the working tree had local modifications
+)
+
+// WithBuildInfo stamps every admitted record with the main module path and
+// version and, when the binary was built from a VCS checkout, the revision
+// and dirty flag, so every log line identifies the exact build.
+//
+// The information is read once with debug.ReadBuildInfo when the option is
+// created. Fields that are unavailable (for example VCS data in binaries
+// built with -buildvcs=false) are omitted; the option is a no-op when the
+// binary carries no build information.
+func WithBuildInfo() Option {
+	bi, ok := debug.ReadBuildInfo()
+	if !ok {
+		return nil
+	}
+	fields := buildInfoFields(bi)
+	return WithEnricher(func(context.Context, slog.Record) []iris.Field {
+		return fields
+	})
+}
+
+// buildInfoFields extracts the identifying fields from bi.
+func buildInfoFields(bi *debug.BuildInfo) []iris.Field {
+	var fields []iris.Field
+	if bi.Main.Path != "" {
+		fields = append(fields, iris.String(BuildPathKey, bi.Main.Path))
+	}
+	if bi.Main.Version != "" {
+		fields = append(fields, iris.String(BuildVersionKey, bi.Main.Version))
+	}
+	for _, s := range bi.Settings {
+		switch s.Key {
+		case "vcs.revision":
+			fields = append(fields, iris.String(BuildRevisionKey, s.Value))
+		case "vcs.modified":
+			fields = append(fields, iris.Bool(BuildDirtyKey, s.Value == "true"))
+		}
+	}
+	return fields
+}
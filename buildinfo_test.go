@@ -0,0 +1,62 @@
+// buildinfo_test.go: Tests for static build identification fields
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"log/slog"
+	"runtime/debug"
+	"testing"
+)
+
+func TestBuildInfoFields(t *testing.T) {
+	bi := &debug.BuildInfo{
+		Main: debug.Module{Path: "example.com/app", Version: "v1.2.3"},
+		Settings: []debug.BuildSetting{
+			{Key: "-trimpath", Value: "true"},
+			{Key: "vcs.revision", Value: "0123abcd"},
+			{Key: "vcs.modified", Value: "true"},
+		},
+	}
+	fields := buildInfoFields(bi)
+
+	want := map[string]string{
+		BuildPathKey:     "example.com/app",
+		BuildVersionKey:  "v1.2.3",
+		BuildRevisionKey: "0123abcd",
+	}
+	if len(fields) != 4 {
+		t.Fatalf("got %d fields, want 4", len(fields))
+	}
+	for _, f := range fields {
+		if f.Key() == BuildDirtyKey {
+			if !f.BoolValue() {
+				t.Error("build.dirty = false, want true")
+			}
+			continue
+		}
+		if f.StringValue() != want[f.Key()] {
+			t.Errorf("%s = %q, want %q", f.Key(), f.StringValue(), want[f.Key()])
+		}
+	}
+}
+
+func TestBuildInfoFields_OmitsMissingVCS(t *testing.T) {
+	fields := buildInfoFields(&debug.BuildInfo{Main: debug.Module{Path: "example.com/app"}})
+	if len(fields) != 1 || fields[0].Key() != BuildPathKey {
+		t.Errorf("fields = %v, want only %s", fields, BuildPathKey)
+	}
+}
+
+func TestWithBuildInfo_StampsRecords(t *testing.T) {
+	provider := New(10, WithBuildInfo())
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	record := readRecord(t, provider, func(l *slog.Logger) { l.Info("started") })
+	if _, ok := findField(record, BuildPathKey); !ok {
+		t.Errorf("missing %q field", BuildPathKey)
+	}
+}
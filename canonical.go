@@ -0,0 +1,210 @@
+// canonical.go: Stable rendering of values without a typed conversion
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"fmt"
+	"log/slog"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CanonicalFieldConverter is DefaultFieldConverter with canonical rendering
+// of the values it converts to strings, those without a typed conversion,
+// so identical events always produce byte-identical output for
+// deduplication and diffing. Unlike fmt formatting, the rendering:
+//
+//   - Sorts map keys by their rendering, whatever their type.
+//   - Formats floats like encoding/json: without exponent from 1e-6 to 1e21,
+//     in the shortest form that round-trips.
+//   - Follows pointers instead of printing addresses, up to a fixed depth.
+//   - Formats times as RFC 3339 with nanoseconds, without the monotonic
+//     clock reading time.Time.String includes.
+//
+// Values implementing error or fmt.Stringer are rendered by their method,
+// as they control their own form; structs render as {Field:value ...},
+// slices and arrays as [a b], maps as map[k:v ...] and groups as
+// [key=value ...]. Use it with WithCanonicalFallback, or delegate to it
+// from custom FieldConverters.
+var CanonicalFieldConverter FieldConverter = defaultFieldConverter{canonical: true}
+
+// WithCanonicalFallback converts attribute values with
+// CanonicalFieldConverter. It replaces any WithFieldConverter converter.
+func WithCanonicalFallback() Option {
+	return WithFieldConverter(CanonicalFieldConverter)
+}
+
+// maxCanonicalDepth bounds the nesting rendered by canonicalString, which
+// also stops reference cycles.
+const maxCanonicalDepth = 16
+
+// canonicalString renders v canonically, see CanonicalFieldConverter.
+func canonicalString(v slog.Value) string {
+	var b strings.Builder
+	writeCanonicalValue(&b, v, 0)
+	return b.String()
+}
+
+// writeCanonicalValue writes the canonical form of v to b.
+func writeCanonicalValue(b *strings.Builder, v slog.Value, depth int) {
+	v = v.Resolve()
+	switch v.Kind() {
+	case slog.KindFloat64:
+		b.WriteString(canonicalFloat(v.Float64(), 64))
+	case slog.KindTime:
+		b.WriteString(v.Time().Format(time.RFC3339Nano))
+	case slog.KindGroup:
+		b.WriteByte('[')
+		for i, a := range v.Group() {
+			if i > 0 {
+				b.WriteByte(' ')
+			}
+			b.WriteString(a.Key)
+			b.WriteByte('=')
+			writeCanonicalValue(b, a.Value, depth+1)
+		}
+		b.WriteByte(']')
+	case slog.KindAny:
+		writeCanonical(b, reflect.ValueOf(v.Any()), depth)
+	default:
+		b.WriteString(v.String())
+	}
+}
+
+// writeCanonical writes the canonical form of rv to b.
+func writeCanonical(b *strings.Builder, rv reflect.Value, depth int) {
+	if !rv.IsValid() {
+		b.WriteString("<nil>")
+		return
+	}
+	if depth > maxCanonicalDepth {
+		b.WriteString("...")
+		return
+	}
+	if rv.CanInterface() {
+		switch x := rv.Interface().(type) {
+		case time.Time:
+			b.WriteString(x.Format(time.RFC3339Nano))
+			return
+		case error:
+			if rv.Kind() != reflect.Pointer || !rv.IsNil() {
+				b.WriteString(x.Error())
+				return
+			}
+		case fmt.Stringer:
+			if rv.Kind() != reflect.Pointer || !rv.IsNil() {
+				b.WriteString(x.String())
+				return
+			}
+		}
+	}
+
+	switch rv.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if rv.IsNil() {
+			b.WriteString("<nil>")
+			return
+		}
+		writeCanonical(b, rv.Elem(), depth+1)
+	case reflect.Float32:
+		b.WriteString(canonicalFloat(rv.Float(), 32))
+	case reflect.Float64:
+		b.WriteString(canonicalFloat(rv.Float(), 64))
+	case reflect.Complex64, reflect.Complex128:
+		c := rv.Complex()
+		b.WriteByte('(')
+		b.WriteString(canonicalFloat(real(c), 64))
+		if imag(c) >= 0 {
+			b.WriteByte('+')
+		}
+		b.WriteString(canonicalFloat(imag(c), 64))
+		b.WriteString("i)")
+	case reflect.Struct:
+		b.WriteByte('{')
+		for i := 0; i < rv.NumField(); i++ {
+			if i > 0 {
+				b.WriteByte(' ')
+			}
+			b.WriteString(rv.Type().Field(i).Name)
+			b.WriteByte(':')
+			writeCanonical(b, rv.Field(i), depth+1)
+		}
+		b.WriteByte('}')
+	case reflect.Slice, reflect.Array:
+		if rv.Kind() == reflect.Slice && rv.Type().Elem().Kind() == reflect.Uint8 {
+			fmt.Fprintf(b, "%x", rv.Bytes())
+			return
+		}
+		b.WriteByte('[')
+		for i := 0; i < rv.Len(); i++ {
+			if i > 0 {
+				b.WriteByte(' ')
+			}
+			writeCanonical(b, rv.Index(i), depth+1)
+		}
+		b.WriteByte(']')
+	case reflect.Map:
+		if rv.IsNil() {
+			b.WriteString("map[]")
+			return
+		}
+		entries := make([][2]string, 0, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			var key, value strings.Builder
+			writeCanonical(&key, iter.Key(), depth+1)
+			writeCanonical(&value, iter.Value(), depth+1)
+			entries = append(entries, [2]string{key.String(), value.String()})
+		}
+		sort.Slice(entries, func(i, j int) bool {
+			if entries[i][0] != entries[j][0] {
+				return entries[i][0] < entries[j][0]
+			}
+			return entries[i][1] < entries[j][1] // Distinct keys, e.g. NaN, rendering alike
+		})
+		b.WriteString("map[")
+		for i, e := range entries {
+			if i > 0 {
+				b.WriteByte(' ')
+			}
+			b.WriteString(e[0])
+			b.WriteByte(':')
+			b.WriteString(e[1])
+		}
+		b.WriteByte(']')
+	case reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		// Addresses differ between runs, so only the type is stable.
+		b.WriteString(rv.Type().String())
+	default:
+		fmt.Fprint(b, rv)
+	}
+}
+
+// canonicalFloat formats f like encoding/json, with the special values
+// spelled as strconv does.
+func canonicalFloat(f float64, bits int) string {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return strconv.FormatFloat(f, 'g', -1, bits)
+	}
+	abs := math.Abs(f)
+	format := byte('f')
+	if abs != 0 && (bits == 64 && (abs < 1e-6 || abs >= 1e21) || bits == 32 && (float32(abs) < 1e-6 || float32(abs) >= 1e21)) {
+		format = 'e'
+	}
+	s := strconv.FormatFloat(f, format, -1, bits)
+	if format == 'e' {
+		// Clean up e-09 to e-9, as encoding/json does.
+		if n := len(s); n >= 4 && s[n-4] == 'e' && s[n-3] == '-' && s[n-2] == '0' {
+			s = s[:n-2] + s[n-1:]
+		}
+	}
+	return s
+}
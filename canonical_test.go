@@ -0,0 +1,86 @@
+// canonical_test.go: Tests for canonical fallback rendering
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"errors"
+	"log/slog"
+	"math"
+	"testing"
+	"time"
+)
+
+type canonicalPoint struct {
+	X, Y  float64
+	Label *string
+	Tags  map[string]int
+}
+
+func TestCanonicalString(t *testing.T) {
+	label := "origin"
+	at := time.Date(2025, 1, 2, 3, 4, 5, 600, time.UTC)
+	type cyclic struct{ Next *cyclic }
+	loop := &cyclic{}
+	loop.Next = loop
+
+	tests := []struct {
+		name  string
+		value slog.Value
+		want  string
+	}{
+		{"map keys sorted", slog.AnyValue(map[string]int{"b": 2, "a": 1, "c": 3}), "map[a:1 b:2 c:3]"},
+		{"int map keys sorted by rendering", slog.AnyValue(map[int]bool{10: true, 9: false}), "map[10:true 9:false]"},
+		{"floats without exponent", slog.AnyValue([]float64{1e20, 0.000001, 2.5}), "[100000000000000000000 0.000001 2.5]"},
+		{"floats with exponent", slog.AnyValue([]float64{1e21, 1e-7}), "[1e+21 1e-7]"},
+		{"special floats", slog.AnyValue([]float64{math.NaN(), math.Inf(-1)}), "[NaN -Inf]"},
+		{"pointers followed", slog.AnyValue(&canonicalPoint{X: 1, Label: &label, Tags: map[string]int{"z": 1, "y": 2}}), "{X:1 Y:0 Label:origin Tags:map[y:2 z:1]}"},
+		{"nil pointer", slog.AnyValue((*canonicalPoint)(nil)), "<nil>"},
+		{"time without monotonic reading", slog.AnyValue([]time.Time{at}), "[2025-01-02T03:04:05.0000006Z]"},
+		{"error", slog.AnyValue(errors.New("boom")), "boom"},
+		{"bytes", slog.AnyValue([]byte{0xca, 0xfe}), "cafe"},
+		{"group", slog.GroupValue(slog.Float64("ratio", 1e-7), slog.Any("m", map[string]int{"b": 1, "a": 2})), "[ratio=1e-7 m=map[a:2 b:1]]"},
+		{"float kind", slog.Float64Value(123456789), "123456789"},
+		{"func", slog.AnyValue(func() {}), "func()"},
+	}
+	for _, tt := range tests {
+		if got := canonicalString(tt.value); got != tt.want {
+			t.Errorf("%s: canonicalString() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+
+	if got := canonicalString(slog.AnyValue(loop)); len(got) > 200 {
+		t.Errorf("Expected cycles cut at the depth limit, got %d bytes", len(got))
+	}
+}
+
+func TestCanonicalString_Stable(t *testing.T) {
+	m := make(map[string]any)
+	for _, k := range []string{"k1", "k2", "k3", "k4", "k5", "k6", "k7", "k8"} {
+		m[k] = map[float64]string{1.5: "x", 0.25: "y", 3: "z"}
+	}
+	first := canonicalString(slog.AnyValue(m))
+	for i := 0; i < 50; i++ {
+		if got := canonicalString(slog.AnyValue(m)); got != first {
+			t.Fatalf("Rendering changed between calls:\n%s\n%s", first, got)
+		}
+	}
+}
+
+func TestWithCanonicalFallback(t *testing.T) {
+	provider := New(10, WithCanonicalFallback())
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	record := readRecord(t, provider, func(l *slog.Logger) {
+		l.Info("event", "point", canonicalPoint{X: 0.5, Y: 1e21}, "count", 3)
+	})
+	if f, _ := findField(record, "point"); f.StringValue() != "{X:0.5 Y:1e+21 Label:<nil> Tags:map[]}" {
+		t.Errorf("point = %q, want the canonical rendering", f.StringValue())
+	}
+	if f, _ := findField(record, "count"); !f.IsInt() {
+		t.Errorf("Expected typed conversions unchanged, got %+v", f)
+	}
+}
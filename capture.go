@@ -0,0 +1,213 @@
+// capture.go: Burst capture of dropped records for postmortem analysis
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"github.com/agilira/iris"
+)
+
+// captureQueue is the number of dropped records waiting to be written to the
+// capture file; records dropped while it is full are not captured.
+const captureQueue = 1024
+
+// BurstCaptureConfig configures WithBurstCapture.
+type BurstCaptureConfig struct {
+	// Path is the capture file. Rotated files are named Path.1, Path.2 and
+	// so on, Path.1 being the most recent. It is required.
+	Path string
+
+	// MaxBytes is the size from which the capture file is rotated before
+	// the next record; 10 MiB when zero.
+	MaxBytes int64
+
+	// MaxFiles is the number of files kept, including Path; 3 when zero.
+	MaxFiles int
+
+	// Duration is how long records are captured after drops begin; 1m when
+	// zero.
+	Duration time.Duration
+
+	// Codec is the format of the capture files; NDJSONCodec when nil. Each
+	// file is a complete stream that Import can read.
+	Codec Codec
+}
+
+// WithBurstCapture diverts records dropped because the buffer is full into
+// a size-capped, rotating local NDJSON file, so the data lost from the main
+// pipeline during a burst is still recoverable for analysis:
+//
+//	provider := slogprovider.New(1000, slogprovider.WithBurstCapture(slogprovider.BurstCaptureConfig{
+//	    Path: "/var/log/myapp/slog-burst.ndjson",
+//	}))
+//
+// Capture starts with the first drop after a drop-free period of at least
+// Duration and lasts Duration, so a sustained overload cannot keep the disk
+// busy. Records are written with cfg.Codec by a background goroutine, after
+// WithEncryption and conversion, and can be re-injected with Import. Records
+// evicted by WithDropOldest or WithWeightedEviction are not captured.
+//
+// Captured records are counted in Stats().Captured; write failures are
+// reported on Errors. Files are created with mode 0600, and a capture file
+// left by an earlier run is rotated rather than appended to. WithBurstCapture
+// panics if cfg.Path is empty.
+func WithBurstCapture(cfg BurstCaptureConfig) Option {
+	if cfg.Path == "" {
+		panic("slogprovider: WithBurstCapture requires a Path")
+	}
+	if cfg.MaxBytes <= 0 {
+		cfg.MaxBytes = 10 << 20
+	}
+	if cfg.MaxFiles <= 0 {
+		cfg.MaxFiles = 3
+	}
+	if cfg.Duration <= 0 {
+		cfg.Duration = time.Minute
+	}
+	if cfg.Codec == nil {
+		cfg.Codec = NDJSONCodec{}
+	}
+	return func(o *options) { o.capture = &cfg }
+}
+
+// burstCapture holds the capture window and the records waiting to be
+// written.
+type burstCapture struct {
+	cfg        BurstCaptureConfig
+	entries    chan entry
+	lastDrop   atomic.Int64 // Unix nanoseconds of the last drop, 0 for none
+	burstStart atomic.Int64 // Unix nanoseconds at which the current burst began
+
+	file *os.File      // Current capture file, nil until the first write
+	out  RecordEncoder // Encoder of the current capture file
+	size int64         // Size of the current capture file
+}
+
+// newBurstCapture creates the capture state for cfg, or nil if cfg is nil.
+func newBurstCapture(cfg *BurstCaptureConfig) *burstCapture {
+	if cfg == nil {
+		return nil
+	}
+	return &burstCapture{cfg: *cfg, entries: make(chan entry, captureQueue)}
+}
+
+// offer queues the dropped entry e for capture if a capture window is open,
+// opening one when drops begin.
+func (c *burstCapture) offer(e entry) {
+	now := time.Now().UnixNano()
+	window := int64(c.cfg.Duration)
+	if last := c.lastDrop.Swap(now); last == 0 || now-last > window {
+		c.burstStart.Store(now)
+	}
+	if now-c.burstStart.Load() > window {
+		return
+	}
+	select {
+	case c.entries <- e:
+	default: // Capture is falling behind; the record is lost as before
+	}
+}
+
+// run writes queued entries until the provider is closed, then writes the
+// remaining ones and closes the file.
+func (c *burstCapture) run(p *Provider) {
+	write := func(e entry) {
+		if err := c.write(e.record.Time, p.safeConvert(e)); err != nil {
+			p.reportError(fmt.Errorf("slog provider: burst capture: %w", err))
+			return
+		}
+		p.stats.captured.Add(1)
+	}
+	for {
+		select {
+		case e := <-c.entries:
+			write(e)
+		case <-p.closed:
+			for {
+				select {
+				case e := <-c.entries:
+					write(e)
+				default:
+					c.closeFile()
+					return
+				}
+			}
+		}
+	}
+}
+
+// write encodes record to the capture file, rotating it first once it
+// reached MaxBytes.
+func (c *burstCapture) write(ts time.Time, record *iris.Record) error {
+	if c.file != nil && c.size >= c.cfg.MaxBytes {
+		c.closeFile()
+	}
+	if c.file == nil {
+		// A new file starts a new stream, which codecs such as gob require,
+		// so an earlier capture is rotated rather than appended to.
+		if info, err := os.Stat(c.cfg.Path); err == nil && info.Size() > 0 {
+			if err := c.rotate(); err != nil {
+				return err
+			}
+		}
+		f, err := os.OpenFile(c.cfg.Path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+		if err != nil {
+			return err
+		}
+		c.file, c.size = f, 0
+		c.out = c.cfg.Codec.NewEncoder(c)
+	}
+	return c.out.Encode(ts, record)
+}
+
+// Write implements io.Writer for the encoder, counting the size of the
+// capture file.
+func (c *burstCapture) Write(data []byte) (int, error) {
+	n, err := c.file.Write(data)
+	c.size += int64(n)
+	return n, err
+}
+
+// rotate shifts Path to Path.1, Path.1 to Path.2 and so on, discarding the
+// oldest file beyond MaxFiles.
+func (c *burstCapture) rotate() error {
+	if c.cfg.MaxFiles == 1 {
+		return ignoreNotExist(os.Remove(c.cfg.Path))
+	}
+	for i := c.cfg.MaxFiles - 1; i >= 1; i-- {
+		from := c.cfg.Path
+		if i > 1 {
+			from = fmt.Sprintf("%s.%d", c.cfg.Path, i-1)
+		}
+		if err := ignoreNotExist(os.Rename(from, fmt.Sprintf("%s.%d", c.cfg.Path, i))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// closeFile closes the current capture file, if any.
+func (c *burstCapture) closeFile() {
+	if c.file != nil {
+		_ = c.file.Close() // Every line was written synchronously
+		c.file, c.out, c.size = nil, nil, 0
+	}
+}
+
+// ignoreNotExist returns nil for errors reporting a missing file.
+func ignoreNotExist(err error) error {
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,144 @@
+// capture_test.go: Tests for burst capture of dropped records
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// waitForCaptured polls until the provider captured n records.
+func waitForCaptured(t *testing.T, provider *Provider, n uint64) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for provider.Stats().Captured != n {
+		if time.Now().After(deadline) {
+			t.Fatalf("Captured did not reach %d, got %d", n, provider.Stats().Captured)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestWithBurstCapture_WritesDroppedRecords(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "burst.ndjson")
+	provider := New(1, WithBurstCapture(BurstCaptureConfig{Path: path}))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	logger := slog.New(provider)
+	logger.Info("kept")
+	logger.Info("dropped 1", "user", "alice")
+	logger.Warn("dropped 2")
+	waitForCaptured(t, provider, 2)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 || !strings.Contains(lines[0], `"msg":"dropped 1"`) || !strings.Contains(lines[0], `"user":"alice"`) {
+		t.Fatalf("Unexpected capture file:\n%s", data)
+	}
+
+	// The capture can be re-injected for analysis.
+	replay := New(10)
+	defer func() { _ = replay.Close() }() // Ignore error in test cleanup
+	if n, err := replay.ImportNDJSON(strings.NewReader(string(data))); err != nil || n != 2 {
+		t.Errorf("ImportNDJSON() = %d, %v", n, err)
+	}
+	if stats := provider.Stats(); stats.Dropped != 2 || stats.Buffered != 1 {
+		t.Errorf("Expected capture not to affect the buffer, got %+v", stats)
+	}
+}
+
+func TestWithBurstCapture_RotatesFiles(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "burst.ndjson")
+	provider := New(0, WithBurstCapture(BurstCaptureConfig{Path: path, MaxBytes: 1, MaxFiles: 2}))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	logger := slog.New(provider)
+	for _, msg := range []string{"first", "second", "third"} {
+		logger.Info(msg)
+	}
+	waitForCaptured(t, provider, 3)
+
+	for file, want := range map[string]string{path: "third", path + ".1": "second"} {
+		data, err := os.ReadFile(file)
+		if err != nil || !strings.Contains(string(data), want) || strings.Count(string(data), "\n") != 1 {
+			t.Errorf("%s = %q, %v; want the %s record", filepath.Base(file), data, err, want)
+		}
+	}
+	if _, err := os.Stat(path + ".2"); !os.IsNotExist(err) {
+		t.Errorf("Expected at most 2 files, found %s.2", filepath.Base(path))
+	}
+}
+
+func TestWithBurstCapture_LimitsCaptureDuration(t *testing.T) {
+	c := newBurstCapture(&BurstCaptureConfig{Path: "unused", Duration: 50 * time.Millisecond})
+
+	// Sustained drops are only captured at the start of the burst.
+	for i := 0; i < 12; i++ {
+		c.offer(entry{})
+		time.Sleep(10 * time.Millisecond)
+	}
+	captured := len(c.entries)
+	if captured == 0 || captured >= 12 {
+		t.Fatalf("Expected the start of the burst to be captured, got %d of 12", captured)
+	}
+
+	// A drop after a quiet period starts a new burst.
+	time.Sleep(100 * time.Millisecond)
+	c.offer(entry{})
+	if len(c.entries) != captured+1 {
+		t.Errorf("Expected a new burst to be captured")
+	}
+}
+
+func TestWithBurstCapture_ReportsWriteFailures(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing", "burst.ndjson")
+	provider := New(0, WithBurstCapture(BurstCaptureConfig{Path: path}))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	slog.New(provider).Info("dropped")
+	select {
+	case err := <-provider.Errors():
+		if !strings.Contains(err.Error(), "burst capture") {
+			t.Errorf("Unexpected error %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected the write failure to be reported")
+	}
+	if n := provider.Stats().Captured; n != 0 {
+		t.Errorf("Captured = %d, want 0", n)
+	}
+}
+
+func TestWithBurstCapture_GobFilesAreCompleteStreams(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "burst.gob")
+	provider := New(0, WithBurstCapture(BurstCaptureConfig{Path: path, MaxBytes: 1, Codec: GobCodec{}}))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	logger := slog.New(provider)
+	logger.Info("first", "n", 1)
+	logger.Info("second", "n", 2)
+	waitForCaptured(t, provider, 2)
+
+	for file, want := range map[string]string{path: "second", path + ".1": "first"} {
+		f, err := os.Open(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		record, err := GobCodec{}.NewDecoder(f).Decode()
+		_ = f.Close()
+		if err != nil || record.Message != want {
+			t.Errorf("%s = %q, %v; want %q", filepath.Base(file), record.Message, err, want)
+		}
+	}
+}
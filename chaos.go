@@ -0,0 +1,120 @@
+// chaos.go: Chaos mode randomly dropping and delaying records
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"math/rand/v2"
+	"os"
+	"sync"
+	"time"
+)
+
+// ChaosConfig configures WithChaos.
+type ChaosConfig struct {
+	// DropRate is the fraction of records, between 0 and 1, dropped in
+	// Handle as if the buffer were full.
+	DropRate float64
+
+	// DelayRate is the fraction of reads, between 0 and 1, delayed by Delay
+	// before the next record is returned.
+	DelayRate float64
+
+	// Delay is the delay applied to delayed reads. Defaults to 100ms.
+	Delay time.Duration
+
+	// Seed makes the random decisions reproducible when non-zero.
+	Seed uint64
+
+	// Output receives the warning written when the provider is created.
+	// Defaults to os.Stderr.
+	Output io.Writer
+}
+
+// WithChaos enables chaos mode: a percentage of records is dropped and a
+// percentage of reads is delayed at random, so teams can verify that their
+// alerting and dashboards tolerate bridge loss before it happens for real:
+//
+//	provider := slogprovider.New(1000, slogprovider.WithChaos(slogprovider.ChaosConfig{
+//	    DropRate:  0.05,
+//	    DelayRate: 0.01,
+//	}))
+//
+// Chaos mode is for development and staging only. It announces itself with a
+// warning on cfg.Output when the provider is created, and is reported as
+// "chaos" by DumpJSON. Dropped records are counted in Stats().Dropped like
+// any other drop. Chaos mode is built on fault injection and is combined
+// with a WithFaults policy, if any.
+func WithChaos(cfg ChaosConfig) Option {
+	return func(o *options) { o.chaos = &cfg }
+}
+
+// chaos draws the random decisions of chaos mode.
+type chaos struct {
+	cfg ChaosConfig
+	mu  sync.Mutex
+	rng *rand.Rand // nil for the global source
+}
+
+// chaosFaults returns the fault policy implementing cfg on top of base, and
+// writes the chaos mode warning.
+func chaosFaults(cfg ChaosConfig, base *FaultPolicy) *FaultPolicy {
+	cfg.DropRate = min(max(cfg.DropRate, 0), 1)
+	cfg.DelayRate = min(max(cfg.DelayRate, 0), 1)
+	if cfg.Delay <= 0 {
+		cfg.Delay = 100 * time.Millisecond
+	}
+	if cfg.Output == nil {
+		cfg.Output = os.Stderr
+	}
+	c := &chaos{cfg: cfg}
+	if cfg.Seed != 0 {
+		c.rng = rand.New(rand.NewPCG(cfg.Seed, cfg.Seed)) // #nosec G404 -- chaos decisions need no cryptographic randomness
+	}
+
+	_, _ = fmt.Fprintf(cfg.Output,
+		"slogprovider: CHAOS MODE ENABLED: dropping %.1f%% of records and delaying %.1f%% of reads by %v; do not use in production\n",
+		cfg.DropRate*100, cfg.DelayRate*100, cfg.Delay)
+
+	policy := FaultPolicy{}
+	if base != nil {
+		policy = *base
+	}
+	bufferFull, readDelay := policy.BufferFull, policy.ReadDelay
+	policy.BufferFull = func(record slog.Record) bool {
+		if bufferFull != nil && bufferFull(record) {
+			return true
+		}
+		return c.hit(cfg.DropRate)
+	}
+	policy.ReadDelay = func() time.Duration {
+		var delay time.Duration
+		if readDelay != nil {
+			delay = readDelay()
+		}
+		if c.hit(cfg.DelayRate) {
+			delay += cfg.Delay
+		}
+		return delay
+	}
+	return &policy
+}
+
+// hit reports whether an event with probability rate occurs.
+func (c *chaos) hit(rate float64) bool {
+	if rate <= 0 {
+		return false
+	}
+	if c.rng == nil {
+		return rand.Float64() < rate // #nosec G404 -- chaos decisions need no cryptographic randomness
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.rng.Float64() < rate
+}
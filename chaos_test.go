@@ -0,0 +1,90 @@
+// chaos_test.go: Tests for chaos mode
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWithChaos_DropsFractionOfRecords(t *testing.T) {
+	var out bytes.Buffer
+	provider := New(2000, WithChaos(ChaosConfig{DropRate: 0.25, Seed: 1, Output: &out}))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	if !strings.Contains(out.String(), "CHAOS MODE ENABLED") {
+		t.Errorf("Expected chaos mode warning, got %q", out.String())
+	}
+
+	logger := slog.New(provider)
+	for i := 0; i < 1000; i++ {
+		logger.Info("message", "i", i)
+	}
+	dropped := provider.Dropped()
+	if dropped < 180 || dropped > 320 {
+		t.Errorf("Expected about 250 of 1000 records dropped, got %d", dropped)
+	}
+	if err := provider.Verify(); err != nil {
+		t.Errorf("Verify failed in chaos mode: %v", err)
+	}
+}
+
+func TestWithChaos_SeedIsReproducible(t *testing.T) {
+	run := func() uint64 {
+		provider := New(100, WithChaos(ChaosConfig{DropRate: 0.5, Seed: 42, Output: &bytes.Buffer{}}))
+		defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+		logger := slog.New(provider)
+		for i := 0; i < 100; i++ {
+			logger.Info("message")
+		}
+		return provider.Dropped()
+	}
+	if a, b := run(), run(); a != b {
+		t.Errorf("Expected identical drops with the same seed, got %d and %d", a, b)
+	}
+}
+
+func TestWithChaos_DelaysReads(t *testing.T) {
+	provider := New(10, WithChaos(ChaosConfig{DelayRate: 1, Delay: 30 * time.Millisecond, Output: &bytes.Buffer{}}))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+	slog.New(provider).Info("message")
+
+	start := time.Now()
+	if _, err := provider.Read(context.Background()); err != nil {
+		t.Fatalf("Read failed: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 30*time.Millisecond {
+		t.Errorf("Expected read to be delayed, took %v", elapsed)
+	}
+}
+
+func TestWithChaos_CombinesWithFaults(t *testing.T) {
+	provider := New(10,
+		WithFaults(FaultPolicy{BufferFull: func(r slog.Record) bool { return r.Message == "faulty" }}),
+		WithChaos(ChaosConfig{Output: &bytes.Buffer{}}),
+	)
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	logger := slog.New(provider)
+	logger.Info("faulty")
+	logger.Info("fine")
+	if provider.Dropped() != 1 || provider.Len() != 1 {
+		t.Errorf("Expected the fault policy to stay active, dropped=%d len=%d", provider.Dropped(), provider.Len())
+	}
+
+	var buf bytes.Buffer
+	if err := provider.DumpJSON(&buf); err != nil {
+		t.Fatalf("DumpJSON failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), `"chaos": true`) {
+		t.Error("Expected DumpJSON to report chaos mode")
+	}
+}
@@ -0,0 +1,41 @@
+// clock.go: Injectable time source for time-based behavior
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import "time"
+
+// Clock is the time source of the provider's time-based decisions, so they
+// can be tested deterministically with a fake clock.
+type Clock interface {
+	Now() time.Time
+}
+
+// WithClock sets the clock used for record TTL expiry (WithRecordTTL),
+// throttling windows (WithThrottle) and the ticks of the TickSamplers
+// created from Config.Sampling, Rules or UpdateConfig. A nil clock restores
+// the system clock.
+//
+// Samplers created with NewTickSampler take a clock with
+// TickSampler.WithClock. Other timestamps, such as the time of records
+// synthesized by the provider, come from the system clock.
+func WithClock(c Clock) Option {
+	return func(o *options) { o.clock = c }
+}
+
+// now returns the current time of the configured clock.
+func (o *options) now() time.Time {
+	return clockNow(o.clock)
+}
+
+// clockNow returns the current time of c, or of the system clock if c is
+// nil.
+func clockNow(c Clock) time.Time {
+	if c == nil {
+		return time.Now()
+	}
+	return c.Now()
+}
@@ -0,0 +1,91 @@
+// clock_test.go: Tests for the injectable clock
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock is a Clock advanced manually.
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func TestWithClock_RecordTTL(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+	provider := New(10, WithClock(clock), WithRecordTTL(map[slog.Level]time.Duration{slog.LevelError: time.Minute}))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	for _, msg := range []string{"expires", "kept"} {
+		if err := provider.Handle(context.Background(), slog.NewRecord(clock.Now(), slog.LevelInfo, msg, 0)); err != nil {
+			t.Fatalf("Handle failed: %v", err)
+		}
+		clock.Advance(50 * time.Second)
+	}
+
+	if msgs := readMessages(t, provider, 1); msgs[0] != "kept" {
+		t.Errorf("Expected TTLs measured with the clock, got %v", msgs)
+	}
+}
+
+func TestWithClock_Throttle(t *testing.T) {
+	clock := &fakeClock{now: time.Now()}
+	provider := New(10, WithClock(clock), WithThrottle(ThrottleConfig{Limit: 1, Window: time.Hour}))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	logger := slog.New(provider)
+	logger.Info("retrying")
+	logger.Info("retrying")
+	clock.Advance(time.Hour)
+	logger.Info("retrying")
+
+	// The first record, then the summary of the first window and the record
+	// opening the second.
+	if msgs := readMessages(t, provider, 3); msgs[1] != "suppressed 1 similar records" || msgs[2] != "retrying" {
+		t.Errorf("Expected the window to end with the clock, got %v", msgs)
+	}
+}
+
+func TestWithClock_ConfigSampling(t *testing.T) {
+	clock := &fakeClock{now: time.Now()}
+	provider, err := NewWithConfig(Config{
+		BufferSize: 10,
+		Sampling:   &SamplingRule{Tick: time.Hour, First: 1},
+		Clock:      clock,
+	})
+	if err != nil {
+		t.Fatalf("NewWithConfig failed: %v", err)
+	}
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	logger := slog.New(provider)
+	logger.Info("tick")
+	logger.Info("tick")
+	clock.Advance(time.Hour)
+	logger.Info("tick")
+
+	if n := provider.Len(); n != 2 {
+		t.Errorf("Expected one record per tick of the clock, got %d buffered", n)
+	}
+}
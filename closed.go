@@ -0,0 +1,45 @@
+// closed.go: Post-Close error semantics
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import "errors"
+
+// ErrClosed is returned by Handle for records offered after Close, and by
+// Read and ReadBatch at end of stream when WithErrClosed is set.
+//
+// Like io.EOF, ErrClosed is a terminal condition rather than a failure: it
+// is only reported once every record buffered before Close has been read,
+// and every later call reports it again.
+var ErrClosed = errors.New("slog provider closed")
+
+// WithErrClosed makes Read and ReadBatch return ErrClosed instead of
+// (nil, nil) once the provider is closed and drained, so consumers can
+// distinguish shutdown from a nil record.
+//
+// Leave it unset when the provider feeds iris.NewReaderLogger: Iris stops a
+// reader on a nil record, while it logs and retries on errors until its own
+// context is cancelled, so ErrClosed would make it spin until the Iris
+// logger is closed. It is intended for custom consumers:
+//
+//	for {
+//	    record, err := provider.Read(ctx)
+//	    if errors.Is(err, slogprovider.ErrClosed) {
+//	        return nil // Clean shutdown, all records consumed
+//	    }
+//	    ...
+//	}
+func WithErrClosed() Option {
+	return func(o *options) { o.errClosed = true }
+}
+
+// endOfStream returns the Read error reported once the provider is drained.
+func (p *Provider) endOfStream() error {
+	if p.opts.errClosed {
+		return ErrClosed
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+// closed_test.go: Tests for post-Close error semantics
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func TestHandle_ReturnsErrClosedAfterClose(t *testing.T) {
+	provider := New(10)
+	_ = provider.Close() // Ignore error in test cleanup
+
+	err := provider.Handle(context.Background(), slog.NewRecord(time.Now(), slog.LevelInfo, "late", 0))
+	if !errors.Is(err, ErrClosed) {
+		t.Errorf("Handle() after Close = %v, want ErrClosed", err)
+	}
+}
+
+func TestRead_DrainsBeforeEndOfStream(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		opts []Option
+		want error
+	}{
+		{"iris contract", nil, nil},
+		{"WithErrClosed", []Option{WithErrClosed()}, ErrClosed},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := New(10, tt.opts...)
+			logger := slog.New(provider)
+			logger.Info("one")
+			logger.Info("two")
+			_ = provider.Close() // Ignore error in test cleanup
+
+			ctx := context.Background()
+			for _, want := range []string{"one", "two"} {
+				record, err := provider.Read(ctx)
+				if err != nil || record == nil || record.Msg != want {
+					t.Fatalf("Read() = %v, %v; want %q", record, err, want)
+				}
+			}
+			for i := 0; i < 2; i++ {
+				if record, err := provider.Read(ctx); record != nil || err != tt.want {
+					t.Errorf("Read() at end of stream = %v, %v; want nil, %v", record, err, tt.want)
+				}
+			}
+			if batch, err := provider.ReadBatch(ctx, 5); batch != nil || err != tt.want {
+				t.Errorf("ReadBatch() at end of stream = %v, %v; want nil, %v", batch, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestRead_WakesOnClose(t *testing.T) {
+	provider := New(10, WithErrClosed())
+	done := make(chan error, 1)
+	go func() {
+		_, err := provider.Read(context.Background())
+		done <- err
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	_ = provider.Close() // Ignore error in test cleanup
+	select {
+	case err := <-done:
+		if !errors.Is(err, ErrClosed) {
+			t.Errorf("Read() = %v, want ErrClosed", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Read() did not return after Close")
+	}
+}
@@ -0,0 +1,276 @@
+// generate.go: Typed logging function generation from event schemas
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"go/token"
+	"log/slog"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Events is the schema file format: a list of log events.
+//
+//	{
+//	  "package": "events",
+//	  "events": [
+//	    {
+//	      "name": "UserLogin",
+//	      "level": "INFO",
+//	      "message": "user logged in",
+//	      "fields": [
+//	        {"key": "user_id", "type": "int64"},
+//	        {"key": "duration", "type": "duration"}
+//	      ]
+//	    }
+//	  ]
+//	}
+type Events struct {
+	Package string  `json:"package"`
+	Events  []Event `json:"events"`
+}
+
+// Event declares one log event and the typed fields it carries.
+type Event struct {
+	Name    string  `json:"name"`    // Exported Go name of the generated function
+	Level   string  `json:"level"`   // slog level name, e.g. "INFO" or "DEBUG-4"
+	Message string  `json:"message"` // Constant log message
+	Fields  []Field `json:"fields"`
+}
+
+// Field declares a typed event field.
+type Field struct {
+	Key      string `json:"key"`      // Attribute key
+	Type     string `json:"type"`     // One of the fieldTypes keys
+	Required bool   `json:"required"` // Required by EventSchema, which applies to every record
+}
+
+// fieldType describes how a schema type is passed and logged.
+type fieldType struct {
+	goType string // Parameter type
+	attr   string // Attr constructor
+	kind   string // slog.Kind constant for the generated schema
+	pkg    string // Extra import required by goType or attr
+}
+
+// fieldTypes are the supported schema types. Each maps to an Attr
+// constructor that stores the value without boxing, so conversion to the
+// Iris field needs no reflection.
+var fieldTypes = map[string]fieldType{
+	"string":   {"string", "slog.String", "slog.KindString", ""},
+	"int":      {"int", "slog.Int", "slog.KindInt64", ""},
+	"int64":    {"int64", "slog.Int64", "slog.KindInt64", ""},
+	"uint64":   {"uint64", "slog.Uint64", "slog.KindUint64", ""},
+	"float64":  {"float64", "slog.Float64", "slog.KindFloat64", ""},
+	"bool":     {"bool", "slog.Bool", "slog.KindBool", ""},
+	"duration": {"time.Duration", "slog.Duration", "slog.KindDuration", "time"},
+	"time":     {"time.Time", "slog.Time", "slog.KindTime", "time"},
+	"bytes":    {"[]byte", "slogprovider.Bytes", "slog.KindAny", ""},
+	"any":      {"any", "slog.Any", "slog.KindAny", ""},
+}
+
+// standardLevels are the slog level constants used in generated code.
+var standardLevels = map[slog.Level]string{
+	slog.LevelDebug: "slog.LevelDebug",
+	slog.LevelInfo:  "slog.LevelInfo",
+	slog.LevelWarn:  "slog.LevelWarn",
+	slog.LevelError: "slog.LevelError",
+}
+
+// initialisms are rendered in upper case in parameter names.
+var initialisms = map[string]bool{
+	"id": true, "ip": true, "url": true, "uri": true, "http": true,
+	"json": true, "api": true, "uuid": true, "sql": true, "tls": true,
+}
+
+// parseEvents decodes and validates a schema file.
+func parseEvents(data []byte) (*Events, error) {
+	var events Events
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&events); err != nil {
+		return nil, fmt.Errorf("failed to parse schema: %w", err)
+	}
+
+	names := make(map[string]bool, len(events.Events))
+	kinds := make(map[string]string)
+	for _, event := range events.Events {
+		if !token.IsIdentifier(event.Name) || !token.IsExported(event.Name) {
+			return nil, fmt.Errorf("event %q: name must be an exported Go identifier", event.Name)
+		}
+		if names[event.Name] {
+			return nil, fmt.Errorf("event %q: declared twice", event.Name)
+		}
+		names[event.Name] = true
+
+		var level slog.Level
+		if err := level.UnmarshalText([]byte(event.Level)); err != nil {
+			return nil, fmt.Errorf("event %q: %w", event.Name, err)
+		}
+
+		params := map[string]bool{"ctx": true, "logger": true}
+		for _, field := range event.Fields {
+			typ, ok := fieldTypes[field.Type]
+			if !ok {
+				return nil, fmt.Errorf("event %q: field %q has unknown type %q", event.Name, field.Key, field.Type)
+			}
+			if field.Key == "" {
+				return nil, fmt.Errorf("event %q: field without key", event.Name)
+			}
+			param := paramName(field.Key)
+			if params[param] {
+				return nil, fmt.Errorf("event %q: field %q collides with parameter %s", event.Name, field.Key, param)
+			}
+			params[param] = true
+			if kind, ok := kinds[field.Key]; ok && kind != typ.kind {
+				return nil, fmt.Errorf("event %q: field %q is declared with conflicting types", event.Name, field.Key)
+			}
+			kinds[field.Key] = typ.kind
+		}
+	}
+	return &events, nil
+}
+
+// generate renders the Go source for events in package pkg.
+func generate(events *Events, pkg, source string) ([]byte, error) {
+	if !token.IsIdentifier(pkg) {
+		return nil, fmt.Errorf("invalid package name %q", pkg)
+	}
+
+	imports := map[string]bool{"context": true, "log/slog": true}
+	var body bytes.Buffer
+	for _, event := range events.Events {
+		writeEvent(&body, event, imports)
+	}
+	writeSchema(&body, events)
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "// Code generated by slogprovider-gen from %s. DO NOT EDIT.\n\n", source)
+	fmt.Fprintf(&out, "package %s\n\nimport (\n", pkg)
+	paths := make([]string, 0, len(imports))
+	for path := range imports {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		fmt.Fprintf(&out, "\t%q\n", path)
+	}
+	out.WriteString("\n\tslogprovider \"github.com/agilira/iris-provider-slog\"\n)\n")
+	out.Write(body.Bytes())
+
+	src, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to format generated code: %w", err)
+	}
+	return src, nil
+}
+
+// writeEvent renders the logging function of event.
+func writeEvent(w *bytes.Buffer, event Event, imports map[string]bool) {
+	var level slog.Level
+	_ = level.UnmarshalText([]byte(event.Level)) // Validated by parseEvents
+	levelExpr, ok := standardLevels[level]
+	if !ok {
+		levelExpr = fmt.Sprintf("slog.Level(%d)", int(level))
+	}
+
+	params := []string{"ctx context.Context", "logger *slog.Logger"}
+	attrs := make([]string, 0, len(event.Fields))
+	for _, field := range event.Fields {
+		typ := fieldTypes[field.Type]
+		if typ.pkg != "" {
+			imports[typ.pkg] = true
+		}
+		param := paramName(field.Key)
+		params = append(params, param+" "+typ.goType)
+		attrs = append(attrs, fmt.Sprintf("%s(%s, %s)", typ.attr, strconv.Quote(field.Key), param))
+	}
+
+	fmt.Fprintf(w, "\n// %s logs %s at %s.\n", event.Name, strconv.Quote(event.Message), level)
+	fmt.Fprintf(w, "func %s(%s) {\n", event.Name, strings.Join(params, ", "))
+	fmt.Fprintf(w, "if !logger.Enabled(ctx, %s) {\nreturn\n}\n", levelExpr)
+	fmt.Fprintf(w, "logger.LogAttrs(ctx, %s, %s", levelExpr, strconv.Quote(event.Message))
+	for _, attr := range attrs {
+		fmt.Fprintf(w, ",\n%s", attr)
+	}
+	if len(attrs) > 0 {
+		w.WriteString(",\n")
+	}
+	w.WriteString(")\n}\n")
+}
+
+// writeSchema renders EventSchema, the union of all event fields, for use
+// with slogprovider.WithSchema.
+func writeSchema(w *bytes.Buffer, events *Events) {
+	specs := make(map[string]Field)
+	for _, event := range events.Events {
+		for _, field := range event.Fields {
+			if prev, ok := specs[field.Key]; ok && prev.Required {
+				continue
+			}
+			specs[field.Key] = field
+		}
+	}
+	keys := make([]string, 0, len(specs))
+	for key := range specs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	w.WriteString("\n// EventSchema declares the fields of all generated events.\n")
+	w.WriteString("var EventSchema = slogprovider.Schema{Fields: map[string]slogprovider.FieldSpec{\n")
+	for _, key := range keys {
+		field := specs[key]
+		fmt.Fprintf(w, "%s: {Kind: %s", strconv.Quote(key), fieldTypes[field.Type].kind)
+		if field.Required {
+			w.WriteString(", Required: true")
+		}
+		w.WriteString("},\n")
+	}
+	w.WriteString("}}\n")
+}
+
+// paramName converts a field key such as "user.id" to a parameter name such
+// as userID.
+func paramName(key string) string {
+	words := strings.FieldsFunc(key, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	var b strings.Builder
+	for i, word := range words {
+		lower := strings.ToLower(word)
+		switch {
+		case i == 0:
+			b.WriteString(lower)
+		case initialisms[lower]:
+			b.WriteString(strings.ToUpper(lower))
+		default:
+			b.WriteString(upperFirst(lower))
+		}
+	}
+
+	name := b.String()
+	if !token.IsIdentifier(name) || token.IsKeyword(name) {
+		name = "f" + upperFirst(name)
+	}
+	return name
+}
+
+// upperFirst returns s with its first letter in upper case.
+func upperFirst(s string) string {
+	r := []rune(s)
+	if len(r) > 0 {
+		r[0] = unicode.ToUpper(r[0])
+	}
+	return string(r)
+}
@@ -0,0 +1,127 @@
+// generate_test.go: Tests for typed logging function generation
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package main
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testSchema = `{
+  "package": "events",
+  "events": [
+    {
+      "name": "UserLogin",
+      "level": "INFO",
+      "message": "user logged in",
+      "fields": [
+        {"key": "user.id", "type": "int64", "required": true},
+        {"key": "duration", "type": "duration"},
+        {"key": "token", "type": "bytes"}
+      ]
+    },
+    {
+      "name": "CacheMiss",
+      "level": "DEBUG-4",
+      "message": "cache miss",
+      "fields": [{"key": "type", "type": "string"}]
+    }
+  ]
+}`
+
+func TestGenerate(t *testing.T) {
+	events, err := parseEvents([]byte(testSchema))
+	if err != nil {
+		t.Fatalf("parseEvents failed: %v", err)
+	}
+	src, err := generate(events, events.Package, "events.json")
+	if err != nil {
+		t.Fatalf("generate failed: %v", err)
+	}
+	if _, err := parser.ParseFile(token.NewFileSet(), "events_gen.go", src, 0); err != nil {
+		t.Fatalf("Generated code does not parse: %v\n%s", err, src)
+	}
+
+	code := string(src)
+	for _, want := range []string{
+		"// Code generated by slogprovider-gen from events.json. DO NOT EDIT.",
+		"package events",
+		`"time"`,
+		"func UserLogin(ctx context.Context, logger *slog.Logger, userID int64, duration time.Duration, token []byte) {",
+		"if !logger.Enabled(ctx, slog.LevelInfo) {",
+		`slog.Int64("user.id", userID),`,
+		`slogprovider.Bytes("token", token),`,
+		"func CacheMiss(ctx context.Context, logger *slog.Logger, fType string) {",
+		"slog.Level(-8)",
+		`"user.id":  {Kind: slog.KindInt64, Required: true},`,
+	} {
+		if !strings.Contains(code, want) {
+			t.Errorf("Generated code lacks %q:\n%s", want, code)
+		}
+	}
+}
+
+func TestParseEvents_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"unexported name": `{"events": [{"name": "login", "level": "INFO"}]}`,
+		"bad level":       `{"events": [{"name": "Login", "level": "LOUD"}]}`,
+		"unknown type":    `{"events": [{"name": "Login", "level": "INFO", "fields": [{"key": "a", "type": "complex"}]}]}`,
+		"duplicate event": `{"events": [{"name": "Login", "level": "INFO"}, {"name": "Login", "level": "INFO"}]}`,
+		"param collision": `{"events": [{"name": "Login", "level": "INFO", "fields": [{"key": "user_id", "type": "int"}, {"key": "user.id", "type": "int"}]}]}`,
+		"reserved param":  `{"events": [{"name": "Login", "level": "INFO", "fields": [{"key": "ctx", "type": "int"}]}]}`,
+		"type conflict":   `{"events": [{"name": "A", "level": "INFO", "fields": [{"key": "n", "type": "int"}]}, {"name": "B", "level": "INFO", "fields": [{"key": "n", "type": "string"}]}]}`,
+		"unknown setting": `{"events": [], "pkg": "x"}`,
+	}
+	for name, schema := range tests {
+		if _, err := parseEvents([]byte(schema)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestParamName(t *testing.T) {
+	tests := map[string]string{
+		"user_id":     "userID",
+		"http.status": "httpStatus",
+		"Request-URL": "requestURL",
+		"2fa":         "f2fa",
+		"func":        "fFunc",
+	}
+	for key, want := range tests {
+		if got := paramName(key); got != want {
+			t.Errorf("paramName(%q) = %q, want %q", key, got, want)
+		}
+	}
+}
+
+func TestRun_WritesFile(t *testing.T) {
+	dir := t.TempDir()
+	schema := filepath.Join(dir, "events.json")
+	out := filepath.Join(dir, "events_gen.go")
+	if err := os.WriteFile(schema, []byte(testSchema), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := run([]string{"-schema", schema, "-out", out, "-package", "logs"}); err != nil {
+		t.Fatalf("run failed: %v", err)
+	}
+	src, err := os.ReadFile(out) // #nosec G304 -- test file in t.TempDir
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(src), "package logs") {
+		t.Errorf("Expected -package to override the schema, got:\n%s", src)
+	}
+
+	if err := run(nil); err == nil {
+		t.Error("Expected an error without -schema")
+	}
+}
@@ -0,0 +1,83 @@
+// main.go: slogprovider-gen command
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+// Command slogprovider-gen generates strongly-typed logging functions from
+// declared log-event schemas.
+//
+// Each event becomes a function taking its fields as typed parameters and
+// logging them with slog.Logger.LogAttrs, so the hottest events get
+// printf-level ergonomics without boxing or reflection on their way through
+// the provider to Iris fields:
+//
+//	//go:generate go run github.com/agilira/iris-provider-slog/cmd/slogprovider-gen -schema events.json -out events_gen.go
+//
+//	events.UserLogin(ctx, logger, userID, elapsed)
+//
+// The generated EventSchema variable declares all event fields for
+// slogprovider.WithSchema. See Events for the schema file format.
+//
+// Usage:
+//
+//	slogprovider-gen -schema events.json [-out events_gen.go] [-package events]
+//
+// The package name defaults to the schema's "package" setting, then to the
+// package running go generate. Without -out the code is written to stdout.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, "slogprovider-gen:", err)
+		os.Exit(1)
+	}
+}
+
+// run parses the command line and generates the code.
+func run(args []string) error {
+	fs := flag.NewFlagSet("slogprovider-gen", flag.ContinueOnError)
+	schema := fs.String("schema", "", "event schema file (JSON)")
+	out := fs.String("out", "", "output file, stdout when empty")
+	pkg := fs.String("package", "", "package name of the generated code")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *schema == "" {
+		return fmt.Errorf("missing -schema")
+	}
+
+	data, err := os.ReadFile(*schema) // #nosec G304 -- path is provided by the developer
+	if err != nil {
+		return fmt.Errorf("failed to read schema: %w", err)
+	}
+	events, err := parseEvents(data)
+	if err != nil {
+		return err
+	}
+
+	name := *pkg
+	if name == "" {
+		name = events.Package
+	}
+	if name == "" {
+		name = os.Getenv("GOPACKAGE")
+	}
+	src, err := generate(events, name, filepath.Base(*schema))
+	if err != nil {
+		return err
+	}
+
+	if *out == "" {
+		_, err = os.Stdout.Write(src)
+		return err
+	}
+	return os.WriteFile(*out, src, 0o644) // #nosec G306 -- generated source code is not sensitive
+}
@@ -0,0 +1,284 @@
+// codec.go: Pluggable on-disk record formats
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"time"
+
+	"github.com/agilira/iris"
+)
+
+// Codec is the persisted form of records written to disk by Export and
+// WithBurstCapture and read back by Import, so deployments can trade
+// inspectability for speed or compactness. NDJSONCodec is the default;
+// GobCodec is provided as a compact binary alternative, and other formats
+// such as protobuf can be plugged in by implementing Codec.
+type Codec interface {
+	// NewEncoder returns an encoder writing one stream of records to w.
+	NewEncoder(w io.Writer) RecordEncoder
+
+	// NewDecoder returns a decoder reading a stream written by an encoder
+	// of the same codec from r.
+	NewDecoder(r io.Reader) RecordDecoder
+}
+
+// RecordEncoder writes converted records to a stream.
+type RecordEncoder interface {
+	// Encode writes record with its time ts, or the current time when ts
+	// is zero.
+	Encode(ts time.Time, record *iris.Record) error
+}
+
+// RecordDecoder reads records from a stream.
+type RecordDecoder interface {
+	// Decode returns the next record, or io.EOF at the end of the stream.
+	Decode() (slog.Record, error)
+}
+
+// NDJSONCodec encodes records as newline-delimited JSON with the Iris JSON
+// encoder, see ExportNDJSON and ImportNDJSON for the format.
+type NDJSONCodec struct{}
+
+// NewEncoder implements Codec.
+func (NDJSONCodec) NewEncoder(w io.Writer) RecordEncoder {
+	return newNDJSONWriter(w)
+}
+
+// NewDecoder implements Codec.
+func (NDJSONCodec) NewDecoder(r io.Reader) RecordDecoder {
+	return &ndjsonDecoder{r: bufio.NewReader(r)}
+}
+
+// Encode implements RecordEncoder.
+func (n *ndjsonWriter) Encode(ts time.Time, record *iris.Record) error {
+	return n.write(ts, record)
+}
+
+// ndjsonDecoder reads records written by NDJSONCodec, skipping blank lines.
+type ndjsonDecoder struct {
+	r    *bufio.Reader
+	line int
+}
+
+// Decode implements RecordDecoder.
+func (d *ndjsonDecoder) Decode() (slog.Record, error) {
+	for {
+		data, err := d.r.ReadBytes('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return slog.Record{}, fmt.Errorf("failed to read records: %w", err)
+		}
+		d.line++
+		if len(bytes.TrimSpace(data)) > 0 {
+			record, parseErr := parseNDJSONRecord(data)
+			if parseErr != nil {
+				return slog.Record{}, fmt.Errorf("invalid record on line %d: %w", d.line, parseErr)
+			}
+			return record, nil
+		}
+		if err != nil {
+			return slog.Record{}, io.EOF
+		}
+	}
+}
+
+// GobCodec encodes records with encoding/gob. It is more compact and faster
+// to decode than NDJSONCodec, at the cost of human readability. Values
+// without a typed Iris field, such as errors and stringers, are stored in
+// their string form.
+type GobCodec struct{}
+
+// NewEncoder implements Codec.
+func (GobCodec) NewEncoder(w io.Writer) RecordEncoder {
+	return &gobEncoder{enc: gob.NewEncoder(w)}
+}
+
+// NewDecoder implements Codec.
+func (GobCodec) NewDecoder(r io.Reader) RecordDecoder {
+	return &gobDecoder{dec: gob.NewDecoder(r)}
+}
+
+// gobRecord is the gob form of a record.
+type gobRecord struct {
+	Time   time.Time
+	Level  slog.Level
+	Msg    string
+	Fields []gobField
+}
+
+// gobField is the gob form of a field; Kind selects the value.
+type gobField struct {
+	Key   string
+	Kind  slog.Kind
+	Str   string
+	Int   int64
+	Uint  uint64
+	Float float64
+	Bool  bool
+	Time  time.Time
+}
+
+// gobEncoder writes gobRecords.
+type gobEncoder struct {
+	enc *gob.Encoder
+}
+
+// Encode implements RecordEncoder.
+func (g *gobEncoder) Encode(ts time.Time, record *iris.Record) error {
+	if ts.IsZero() {
+		ts = time.Now()
+	}
+	out := gobRecord{Time: ts, Level: toSlogLevel(record.Level), Msg: record.Msg, Fields: make([]gobField, record.FieldCount())}
+	for i := range out.Fields {
+		f := record.GetField(i)
+		gf := gobField{Key: f.Key()}
+		switch {
+		case f.IsString():
+			gf.Kind, gf.Str = slog.KindString, f.StringValue()
+		case f.IsInt():
+			gf.Kind, gf.Int = slog.KindInt64, f.IntValue()
+		case f.IsUint():
+			gf.Kind, gf.Uint = slog.KindUint64, f.UintValue()
+		case f.IsFloat():
+			gf.Kind, gf.Float = slog.KindFloat64, f.FloatValue()
+		case f.IsBool():
+			gf.Kind, gf.Bool = slog.KindBool, f.BoolValue()
+		case f.IsDuration():
+			gf.Kind, gf.Int = slog.KindDuration, int64(f.DurationValue())
+		case f.IsTime():
+			gf.Kind, gf.Time = slog.KindTime, f.TimeValue()
+		case f.IsBytes():
+			gf.Kind, gf.Str = slog.KindString, string(f.BytesValue())
+		default:
+			gf.Kind, gf.Str = slog.KindString, fmt.Sprint(f.Obj)
+		}
+		out.Fields[i] = gf
+	}
+	if err := g.enc.Encode(&out); err != nil {
+		return fmt.Errorf("failed to export records: %w", err)
+	}
+	return nil
+}
+
+// gobDecoder reads gobRecords.
+type gobDecoder struct {
+	dec *gob.Decoder
+}
+
+// Decode implements RecordDecoder.
+func (g *gobDecoder) Decode() (slog.Record, error) {
+	var in gobRecord
+	if err := g.dec.Decode(&in); err != nil {
+		if errors.Is(err, io.EOF) {
+			return slog.Record{}, io.EOF
+		}
+		return slog.Record{}, fmt.Errorf("invalid record: %w", err)
+	}
+	record := slog.NewRecord(in.Time, in.Level, in.Msg, 0)
+	for _, f := range in.Fields {
+		var value slog.Value
+		switch f.Kind {
+		case slog.KindInt64:
+			value = slog.Int64Value(f.Int)
+		case slog.KindUint64:
+			value = slog.Uint64Value(f.Uint)
+		case slog.KindFloat64:
+			value = slog.Float64Value(f.Float)
+		case slog.KindBool:
+			value = slog.BoolValue(f.Bool)
+		case slog.KindDuration:
+			value = slog.DurationValue(time.Duration(f.Int))
+		case slog.KindTime:
+			value = slog.TimeValue(f.Time)
+		default:
+			value = slog.StringValue(f.Str)
+		}
+		record.AddAttrs(slog.Attr{Key: f.Key, Value: value})
+	}
+	return record, nil
+}
+
+// Export writes the buffered records to w with codec, oldest first, without
+// consuming them. See ExportNDJSON, which uses NDJSONCodec.
+func (p *Provider) Export(w io.Writer, codec Codec) error {
+	return p.export(w, codec, p.snapshot())
+}
+
+// snapshot returns a copy of the buffered entries, oldest first.
+func (p *Provider) snapshot() []entry {
+	var entries []entry
+	p.queue.each(func(e *entry) bool {
+		entries = append(entries, *e)
+		return true
+	})
+	return entries
+}
+
+// export writes entries to w with codec.
+func (p *Provider) export(w io.Writer, codec Codec, entries []entry) error {
+	out := codec.NewEncoder(w)
+	for _, e := range entries {
+		if err := out.Encode(e.record.Time, p.safeConvert(e)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Import decodes records written with codec from r and re-injects them
+// through Handle, returning the number of records handled. The slog.TimeKey
+// field written by Export is dropped, as the record time carries it. See
+// ImportNDJSON, which uses NDJSONCodec.
+func (p *Provider) Import(r io.Reader, codec Codec) (int, error) {
+	in := codec.NewDecoder(r)
+	imported := 0
+	for {
+		record, err := in.Decode()
+		if errors.Is(err, io.EOF) {
+			return imported, nil
+		}
+		if err != nil {
+			return imported, err
+		}
+		record = withoutAttr(record, slog.TimeKey)
+		if p.opts.sequence {
+			record = withoutAttr(record, SequenceKey)
+		}
+		if err := p.Handle(context.Background(), record); err != nil {
+			return imported, err
+		}
+		imported++
+	}
+}
+
+// withoutAttr returns record without its top-level attributes named key.
+// The record is copied only when it carries such an attribute.
+func withoutAttr(record slog.Record, key string) slog.Record {
+	found := false
+	record.Attrs(func(attr slog.Attr) bool {
+		found = attr.Key == key
+		return !found
+	})
+	if !found {
+		return record
+	}
+	out := slog.NewRecord(record.Time, record.Level, record.Message, record.PC)
+	record.Attrs(func(attr slog.Attr) bool {
+		if attr.Key != key {
+			out.AddAttrs(attr)
+		}
+		return true
+	})
+	return out
+}
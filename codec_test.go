@@ -0,0 +1,113 @@
+// codec_test.go: Tests for pluggable on-disk record formats
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGobCodec_RoundTripPreservesTypes(t *testing.T) {
+	source := New(10)
+	defer func() { _ = source.Close() }() // Ignore error in test cleanup
+
+	at := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	record := slog.NewRecord(at, slog.LevelWarn, "typed", 0)
+	record.AddAttrs(
+		slog.String("s", "text"),
+		slog.Int("i", -7),
+		slog.Uint64("u", 7),
+		slog.Float64("f", 1.5),
+		slog.Bool("b", true),
+		slog.Duration("d", time.Second),
+		slog.Time("t", at),
+		slog.Any("err", errors.New("boom")),
+	)
+	if err := source.Handle(context.Background(), record); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := source.Export(&buf, GobCodec{}); err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	target := New(10)
+	defer func() { _ = target.Close() }() // Ignore error in test cleanup
+	if n, err := target.Import(&buf, GobCodec{}); err != nil || n != 1 {
+		t.Fatalf("Import() = %d, %v", n, err)
+	}
+
+	got := readWithTimeout(t, target)
+	if got.Msg != "typed" {
+		t.Errorf("Msg = %q, want typed", got.Msg)
+	}
+	for key, want := range map[string]string{"s": "text", "err": "boom"} {
+		if f, ok := findField(got, key); !ok || f.StringValue() != want {
+			t.Errorf("%s = %v, want %q", key, f, want)
+		}
+	}
+	if f, _ := findField(got, "i"); f.IntValue() != -7 {
+		t.Errorf("i = %v, want -7", f)
+	}
+	if f, _ := findField(got, "u"); f.UintValue() != 7 {
+		t.Errorf("u = %v, want 7", f)
+	}
+	if f, _ := findField(got, "f"); f.FloatValue() != 1.5 {
+		t.Errorf("f = %v, want 1.5", f)
+	}
+	if f, _ := findField(got, "b"); !f.BoolValue() {
+		t.Errorf("b = %v, want true", f)
+	}
+	if f, _ := findField(got, "d"); f.DurationValue() != time.Second {
+		t.Errorf("d = %v, want 1s", f)
+	}
+	if f, _ := findField(got, "t"); !f.TimeValue().Equal(at) {
+		t.Errorf("t = %v, want %v", f, at)
+	}
+}
+
+func TestNDJSONCodec_ExportImport(t *testing.T) {
+	source := New(10)
+	defer func() { _ = source.Close() }() // Ignore error in test cleanup
+	slog.New(source).Info("first", "n", 1)
+	slog.New(source).Info("second")
+
+	var buf bytes.Buffer
+	if err := source.Export(&buf, NDJSONCodec{}); err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	var legacy bytes.Buffer
+	if err := source.ExportNDJSON(&legacy); err != nil || legacy.String() != buf.String() {
+		t.Errorf("Expected ExportNDJSON to match Export with NDJSONCodec")
+	}
+
+	dec := NDJSONCodec{}.NewDecoder(strings.NewReader("\n" + buf.String() + "\n"))
+	for _, want := range []string{"first", "second"} {
+		record, err := dec.Decode()
+		if err != nil || record.Message != want {
+			t.Fatalf("Decode() = %q, %v; want %q", record.Message, err, want)
+		}
+	}
+	if _, err := dec.Decode(); err != io.EOF {
+		t.Errorf("Expected io.EOF at the end of the stream, got %v", err)
+	}
+}
+
+func TestGobCodec_ReportsCorruptStreams(t *testing.T) {
+	provider := New(10)
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	if n, err := provider.Import(strings.NewReader("not gob"), GobCodec{}); err == nil || n != 0 {
+		t.Errorf("Import() = %d, %v; want an invalid record error", n, err)
+	}
+}
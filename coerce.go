@@ -0,0 +1,198 @@
+// coerce.go: Per-key type coercion of attribute values
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"log/slog"
+	"math"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+// CoercionCount reports the values of one key that did not have the kind
+// required by WithCoercion, see Provider.Coercions.
+type CoercionCount struct {
+	Coerced uint64 `json:"coerced"` // Values converted to the required kind
+	Failed  uint64 `json:"failed"`  // Values that could not be converted, kept as they were
+}
+
+// WithCoercion converts attribute values to the kind required for their key
+// before conversion, so downstream schemas can rely on a key always being,
+// say, a string even when call sites are sloppy:
+//
+//	provider := slogprovider.New(1000, slogprovider.WithCoercion(map[string]slog.Kind{
+//	    "user_id": slog.KindString,
+//	    "status":  slog.KindInt64,
+//	    "timeout": slog.KindDuration,
+//	}))
+//
+// Keys are matched like WithSchema fields: qualified by the group path, as in
+// "req.id" after WithGroup("req"), before WithJournald or WithNamespace
+// rewrite them.
+// Values convert as follows:
+//
+//   - slog.KindString: any value, formatted like slog.Value.String.
+//   - slog.KindInt64, slog.KindUint64: integers in range, integral floats and
+//     decimal strings.
+//   - slog.KindFloat64: integers and numeric strings.
+//   - slog.KindBool: strings accepted by strconv.ParseBool.
+//   - slog.KindDuration: strings accepted by time.ParseDuration.
+//   - slog.KindTime: RFC 3339 strings.
+//
+// Values that cannot be converted are kept unchanged. Mismatches are
+// counted in Stats().Coerced and Stats().CoercionFailed, and per key by
+// Provider.Coercions. Bound attributes are coerced and counted once, when
+// bound. It panics if a kind is not one of the above.
+func WithCoercion(kinds map[string]slog.Kind) Option {
+	rules := make(map[string]slog.Kind, len(kinds))
+	for key, kind := range kinds {
+		switch kind {
+		case slog.KindString, slog.KindInt64, slog.KindUint64, slog.KindFloat64,
+			slog.KindBool, slog.KindDuration, slog.KindTime:
+		default:
+			panic("slogprovider: WithCoercion cannot coerce " + key + " to " + kind.String())
+		}
+		rules[key] = kind
+	}
+	return func(o *options) { o.coercion = rules }
+}
+
+// coercer implements WithCoercion.
+type coercer struct {
+	kinds  map[string]slog.Kind
+	counts map[string]*coercionCounters // Fixed key set, read without locking
+}
+
+// coercionCounters are the live counters behind a CoercionCount.
+type coercionCounters struct {
+	coerced atomic.Uint64
+	failed  atomic.Uint64
+}
+
+// newCoercer creates the coercer for kinds, or nil if there are none.
+func newCoercer(kinds map[string]slog.Kind) *coercer {
+	if len(kinds) == 0 {
+		return nil
+	}
+	c := &coercer{kinds: kinds, counts: make(map[string]*coercionCounters, len(kinds))}
+	for key := range kinds {
+		c.counts[key] = new(coercionCounters)
+	}
+	return c
+}
+
+// coerce returns value converted to the kind required for key, counting
+// mismatches in stats.
+func (c *coercer) coerce(key string, value slog.Value, stats *counters) slog.Value {
+	kind, ok := c.kinds[key]
+	if !ok {
+		return value
+	}
+	value = value.Resolve()
+	if value.Kind() == kind {
+		return value
+	}
+	coerced, ok := coerceValue(value, kind)
+	if !ok {
+		c.counts[key].failed.Add(1)
+		stats.coercionFailed.Add(1)
+		return value
+	}
+	c.counts[key].coerced.Add(1)
+	stats.coerced.Add(1)
+	return coerced
+}
+
+// reset clears the per-key counters.
+func (c *coercer) reset() {
+	for _, count := range c.counts {
+		count.coerced.Store(0)
+		count.failed.Store(0)
+	}
+}
+
+// coerceValue converts v to kind, reporting false if it cannot.
+func coerceValue(v slog.Value, kind slog.Kind) (slog.Value, bool) {
+	switch kind {
+	case slog.KindString:
+		return slog.StringValue(v.String()), true
+	case slog.KindInt64:
+		switch v.Kind() {
+		case slog.KindUint64:
+			if u := v.Uint64(); u <= math.MaxInt64 {
+				return slog.Int64Value(int64(u)), true // #nosec G115 -- range checked
+			}
+		case slog.KindFloat64:
+			if f := v.Float64(); f == math.Trunc(f) && f >= math.MinInt64 && f < math.MaxInt64 {
+				return slog.Int64Value(int64(f)), true
+			}
+		case slog.KindString:
+			if i, err := strconv.ParseInt(v.String(), 10, 64); err == nil {
+				return slog.Int64Value(i), true
+			}
+		}
+	case slog.KindUint64:
+		switch v.Kind() {
+		case slog.KindInt64:
+			if i := v.Int64(); i >= 0 {
+				return slog.Uint64Value(uint64(i)), true // #nosec G115 -- range checked
+			}
+		case slog.KindFloat64:
+			if f := v.Float64(); f == math.Trunc(f) && f >= 0 && f < math.MaxUint64 {
+				return slog.Uint64Value(uint64(f)), true
+			}
+		case slog.KindString:
+			if u, err := strconv.ParseUint(v.String(), 10, 64); err == nil {
+				return slog.Uint64Value(u), true
+			}
+		}
+	case slog.KindFloat64:
+		switch v.Kind() {
+		case slog.KindInt64:
+			return slog.Float64Value(float64(v.Int64())), true
+		case slog.KindUint64:
+			return slog.Float64Value(float64(v.Uint64())), true
+		case slog.KindString:
+			if f, err := strconv.ParseFloat(v.String(), 64); err == nil {
+				return slog.Float64Value(f), true
+			}
+		}
+	case slog.KindBool:
+		if v.Kind() == slog.KindString {
+			if b, err := strconv.ParseBool(v.String()); err == nil {
+				return slog.BoolValue(b), true
+			}
+		}
+	case slog.KindDuration:
+		if v.Kind() == slog.KindString {
+			if d, err := time.ParseDuration(v.String()); err == nil {
+				return slog.DurationValue(d), true
+			}
+		}
+	case slog.KindTime:
+		if v.Kind() == slog.KindString {
+			if t, err := time.Parse(time.RFC3339Nano, v.String()); err == nil {
+				return slog.TimeValue(t), true
+			}
+		}
+	}
+	return v, false
+}
+
+// Coercions returns the per-key counts of values coerced by WithCoercion
+// since creation or the last ResetCounters. It is nil without that option.
+func (p *Provider) Coercions() map[string]CoercionCount {
+	if p.coercion == nil {
+		return nil
+	}
+	counts := make(map[string]CoercionCount, len(p.coercion.counts))
+	for key, c := range p.coercion.counts {
+		counts[key] = CoercionCount{Coerced: c.coerced.Load(), Failed: c.failed.Load()}
+	}
+	return counts
+}
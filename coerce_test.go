@@ -0,0 +1,109 @@
+// coerce_test.go: Tests for per-key type coercion
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func TestWithCoercion(t *testing.T) {
+	provider := New(10, WithCoercion(map[string]slog.Kind{
+		"user_id": slog.KindString,
+		"status":  slog.KindInt64,
+		"timeout": slog.KindDuration,
+		"ratio":   slog.KindFloat64,
+	}))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	record := readRecord(t, provider, func(l *slog.Logger) {
+		l.Info("request", "user_id", 42, "status", "200", "timeout", "1.5s", "ratio", uint64(3), "other", 7)
+	})
+
+	if f, ok := findField(record, "user_id"); !ok || !f.IsString() || f.StringValue() != "42" {
+		t.Errorf("user_id = %+v, want string 42", f)
+	}
+	if f, ok := findField(record, "status"); !ok || !f.IsInt() || f.IntValue() != 200 {
+		t.Errorf("status = %+v, want int 200", f)
+	}
+	if f, ok := findField(record, "timeout"); !ok || !f.IsDuration() || f.DurationValue() != 1500*time.Millisecond {
+		t.Errorf("timeout = %+v, want 1.5s", f)
+	}
+	if f, ok := findField(record, "ratio"); !ok || !f.IsFloat() || f.FloatValue() != 3 {
+		t.Errorf("ratio = %+v, want float 3", f)
+	}
+	if f, ok := findField(record, "other"); !ok || !f.IsInt() {
+		t.Errorf("other = %+v, want untouched int", f)
+	}
+	if s := provider.Stats(); s.Coerced != 4 || s.CoercionFailed != 0 {
+		t.Errorf("Coerced = %d, CoercionFailed = %d, want 4 and 0", s.Coerced, s.CoercionFailed)
+	}
+}
+
+func TestWithCoercion_Mismatches(t *testing.T) {
+	provider := New(10, WithCoercion(map[string]slog.Kind{"status": slog.KindInt64}))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	ok := readRecord(t, provider, func(l *slog.Logger) { l.Info("typed", "status", 200) })
+	if f, _ := findField(ok, "status"); !f.IsInt() {
+		t.Errorf("status = %+v, want int", f)
+	}
+	failed := readRecord(t, provider, func(l *slog.Logger) { l.Info("sloppy", "status", "OK") })
+	if f, _ := findField(failed, "status"); !f.IsString() || f.StringValue() != "OK" {
+		t.Errorf("status = %+v, want the unconvertible value kept", f)
+	}
+	bound := readRecord(t, provider, func(l *slog.Logger) { l.With("status", 201.0).Info("bound") })
+	if f, _ := findField(bound, "status"); !f.IsInt() || f.IntValue() != 201 {
+		t.Errorf("status = %+v, want the bound value coerced to int", f)
+	}
+
+	want := map[string]CoercionCount{"status": {Coerced: 1, Failed: 1}}
+	if got := provider.Coercions(); got["status"] != want["status"] || len(got) != 1 {
+		t.Errorf("Coercions() = %v, want %v", got, want)
+	}
+	if s := provider.Stats(); s.Coerced != 1 || s.CoercionFailed != 1 {
+		t.Errorf("Coerced = %d, CoercionFailed = %d, want 1 and 1", s.Coerced, s.CoercionFailed)
+	}
+
+	provider.ResetCounters()
+	if got := provider.Coercions()["status"]; got != (CoercionCount{}) {
+		t.Errorf("Expected ResetCounters to clear the per-key counts, got %+v", got)
+	}
+}
+
+func TestWithCoercion_InvalidKind(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected panic for a kind that cannot be coerced to")
+		}
+	}()
+	WithCoercion(map[string]slog.Kind{"payload": slog.KindGroup})
+}
+
+func TestCoerceValue(t *testing.T) {
+	tests := []struct {
+		value slog.Value
+		kind  slog.Kind
+		want  slog.Value
+		ok    bool
+	}{
+		{slog.IntValue(-1), slog.KindUint64, slog.IntValue(-1), false},
+		{slog.Float64Value(2.5), slog.KindInt64, slog.Float64Value(2.5), false},
+		{slog.Float64Value(2), slog.KindUint64, slog.Uint64Value(2), true},
+		{slog.StringValue("true"), slog.KindBool, slog.BoolValue(true), true},
+		{slog.StringValue("2025-01-02T03:04:05Z"), slog.KindTime, slog.TimeValue(time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)), true},
+		{slog.BoolValue(true), slog.KindString, slog.StringValue("true"), true},
+		{slog.IntValue(1), slog.KindBool, slog.IntValue(1), false},
+	}
+	for _, tt := range tests {
+		got, ok := coerceValue(tt.value, tt.kind)
+		if ok != tt.ok || !got.Equal(tt.want) {
+			t.Errorf("coerceValue(%v, %v) = %v, %v, want %v, %v", tt.value, tt.kind, got, ok, tt.want, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,423 @@
+// config.go: Declarative provider configuration
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/agilira/argus"
+)
+
+// OverflowPolicy selects what happens to records handled while the buffer
+// is full.
+type OverflowPolicy string
+
+const (
+	// DropNewest drops the incoming record. It is the default.
+	DropNewest OverflowPolicy = "drop_newest"
+
+	// DropOldest evicts the oldest buffered record, see WithDropOldest.
+	DropOldest OverflowPolicy = "drop_oldest"
+
+	// EvictWeighted evicts the buffered record with the lowest
+	// DefaultRecordWeight, see WithWeightedEviction.
+	EvictWeighted OverflowPolicy = "evict_weighted"
+)
+
+// Config is the declarative form of the provider configuration, mirroring
+// the iris.Config style. Unlike options it can be serialized, e.g. to keep
+// provider tuning in configuration files:
+//
+//	provider, err := slogprovider.NewWithConfig(slogprovider.Config{
+//	    BufferSize: 10000,
+//	    DropPolicy: slogprovider.DropOldest,
+//	    MinLevel:   &minLevel,
+//	})
+//
+// Behavior without a Config field is configured through Options.
+type Config struct {
+	// BufferSize is the number of records the buffer holds. It must be
+	// positive.
+	BufferSize int `json:"buffer_size"`
+
+	// DropPolicy selects the overflow behavior; DropNewest when empty.
+	DropPolicy OverflowPolicy `json:"drop_policy,omitempty"`
+
+	// RetryGrace is how long Handle retries buffering before applying the
+	// drop policy, see WithRetryGrace. It must not be negative.
+	RetryGrace time.Duration `json:"retry_grace,omitempty"`
+
+	// MinLevel, if set, is the default minimum level, see WithMinLevel.
+	MinLevel *slog.Level `json:"min_level,omitempty"`
+
+	// LevelOverrides are minimum levels keyed by logger name, see
+	// WithLevelOverrides.
+	LevelOverrides map[string]slog.Level `json:"level_overrides,omitempty"`
+
+	// ReadLevel, if set, is the minimum level applied at Read, see
+	// WithReadLevel.
+	ReadLevel *slog.Level `json:"read_level,omitempty"`
+
+	// RecordTTL are the times to live of buffered records by level, see
+	// WithRecordTTL. They must be positive.
+	RecordTTL map[slog.Level]time.Duration `json:"record_ttl,omitempty"`
+
+	// ErrClosed reports ErrClosed from Read at end of stream, see
+	// WithErrClosed.
+	ErrClosed bool `json:"err_closed,omitempty"`
+
+	// Sequence stamps a per-provider record index, see WithSequence.
+	Sequence bool `json:"sequence,omitempty"`
+
+	// SlogLevel attaches the numeric slog level, see WithSlogLevel.
+	SlogLevel bool `json:"slog_level,omitempty"`
+
+	// OmitTime leaves the slog record time out of converted records, see
+	// WithoutRecordTime.
+	OmitTime bool `json:"omit_time,omitempty"`
+
+	// Sampling, if set, applies a TickSampler, see NewTickSampler. Its tick
+	// must be positive.
+	Sampling *SamplingRule `json:"sampling,omitempty"`
+
+	// Clock, if set, is the time source of TTLs, throttling and sampling,
+	// see WithClock.
+	Clock Clock `json:"-"`
+
+	// Hooks are registered with WithHooks. Each must implement HandleHook,
+	// EmitHook or both.
+	Hooks []any `json:"-"`
+
+	// Options are applied after the settings above, for behavior without a
+	// Config field.
+	Options []Option `json:"-"`
+}
+
+// Validate reports every invalid setting of c, joined into one error. Each
+// problem is a *ValidationError, so errors.Is matches its sentinel, e.g.
+// ErrInvalidBufferSize.
+func (c Config) Validate() error {
+	var errs []error
+	if c.BufferSize <= 0 {
+		errs = append(errs, invalid(ErrInvalidBufferSize, "buffer size must be positive, got %d", c.BufferSize))
+	}
+	switch c.DropPolicy {
+	case "", DropNewest, DropOldest, EvictWeighted:
+	default:
+		errs = append(errs, invalid(ErrInvalidDropPolicy, "unknown drop policy %q (want %s, %s or %s)", c.DropPolicy, DropNewest, DropOldest, EvictWeighted))
+	}
+	if c.RetryGrace < 0 {
+		errs = append(errs, invalid(ErrInvalidRetryGrace, "retry grace must not be negative, got %s", c.RetryGrace))
+	}
+	for level, ttl := range c.RecordTTL {
+		if ttl <= 0 {
+			errs = append(errs, invalid(ErrInvalidTTL, "record TTL of level %s must be positive, got %s", level, ttl))
+		}
+	}
+	if c.Sampling != nil && c.Sampling.Tick <= 0 {
+		errs = append(errs, invalid(ErrInvalidSampling, "sampling tick must be positive, got %s", c.Sampling.Tick))
+	}
+	for i, hook := range c.Hooks {
+		_, isHandle := hook.(HandleHook)
+		_, isEmit := hook.(EmitHook)
+		if !isHandle && !isEmit {
+			errs = append(errs, invalid(ErrInvalidHook, "hook %d (%T) implements neither HandleHook nor EmitHook", i, hook))
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("slog provider: invalid config: %w", err)
+	}
+	return nil
+}
+
+// options returns the options equivalent to c, followed by c.Options.
+func (c Config) options() []Option {
+	var opts []Option
+	switch c.DropPolicy {
+	case DropOldest:
+		opts = append(opts, WithDropOldest())
+	case EvictWeighted:
+		opts = append(opts, WithWeightedEviction(nil))
+	}
+	if c.RetryGrace > 0 {
+		opts = append(opts, WithRetryGrace(c.RetryGrace))
+	}
+	if c.MinLevel != nil {
+		opts = append(opts, WithMinLevel(*c.MinLevel))
+	}
+	if len(c.LevelOverrides) > 0 {
+		overrides := make(map[string]slog.Leveler, len(c.LevelOverrides))
+		for name, level := range c.LevelOverrides {
+			overrides[name] = level
+		}
+		opts = append(opts, WithLevelOverrides(overrides))
+	}
+	if c.ReadLevel != nil {
+		opts = append(opts, WithReadLevel(*c.ReadLevel))
+	}
+	if len(c.RecordTTL) > 0 {
+		opts = append(opts, WithRecordTTL(c.RecordTTL))
+	}
+	if c.ErrClosed {
+		opts = append(opts, WithErrClosed())
+	}
+	if c.Sequence {
+		opts = append(opts, WithSequence())
+	}
+	if c.SlogLevel {
+		opts = append(opts, WithSlogLevel())
+	}
+	if c.OmitTime {
+		opts = append(opts, WithoutRecordTime())
+	}
+	if s := c.Sampling; s != nil {
+		sampler := NewTickSampler(s.Tick, s.First, s.Thereafter).WithClock(c.Clock)
+		rule := *s
+		opts = append(opts, WithSampler(sampler), func(o *options) {
+			o.configSampling = &configSampling{rule: rule, sampler: sampler}
+		})
+	}
+	if c.Clock != nil {
+		opts = append(opts, WithClock(c.Clock))
+	}
+	if len(c.Hooks) > 0 {
+		opts = append(opts, WithHooks(c.Hooks...))
+	}
+	return append(opts, c.Options...)
+}
+
+// NewWithConfig creates a provider configured by cfg. It returns an error
+// describing every invalid setting instead of creating a provider when cfg
+// does not validate, including cfg.Options that NewChecked would reject.
+func NewWithConfig(cfg Config) (*Provider, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	opts := cfg.options()
+	o := newOptions(opts)
+	if err := o.validate(cfg.BufferSize); err != nil {
+		return nil, fmt.Errorf("slog provider: invalid config: %w", err)
+	}
+	return New(cfg.BufferSize, opts...), nil
+}
+
+// UpdateConfig applies the runtime-tunable settings of cfg to a running
+// provider, so verbosity can change under load without a restart or losing
+// buffered records: MinLevel, LevelOverrides, ReadLevel and Sampling.
+//
+// The settings are swapped atomically as runtime rules (see SetRules) that
+// take precedence over the construction configuration, so a nil MinLevel or
+// ReadLevel keeps the level configured at construction. Sampling replaces
+// the sampling of the Config the provider was built with, if any: an
+// unchanged rule keeps its sampler and counters, a changed or nil one takes
+// its place. Otherwise Sampling applies in addition to the samplers
+// configured with options. Message rules
+// installed with SetRules or WatchRules are kept, while their level and
+// sampling rules are replaced.
+//
+// The other settings are fixed at construction: UpdateConfig returns an
+// error wrapping ErrNotUpdatable, and changes nothing, when they differ from
+// the provider's, so a whole Config can be reloaded from its source. Clock,
+// Hooks and Options are ignored.
+func (p *Provider) UpdateConfig(cfg Config) error {
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+	var errs []error
+	fixed := func(name string, changed bool) {
+		if changed {
+			errs = append(errs, invalid(ErrNotUpdatable, "%s cannot be changed at runtime", name))
+		}
+	}
+	ttls := make(map[slog.Level]time.Duration, len(p.opts.ttls))
+	for _, t := range p.opts.ttls {
+		ttls[t.level] = t.ttl
+	}
+	fixed("buffer size", cfg.BufferSize != p.queue.cap())
+	fixed("drop policy", cfg.dropPolicy() != p.opts.overflowPolicy())
+	fixed("retry grace", cfg.RetryGrace != p.opts.retryGrace)
+	fixed("record TTL", !maps.Equal(cfg.RecordTTL, ttls))
+	fixed("err_closed", cfg.ErrClosed != p.opts.errClosed)
+	fixed("sequence", cfg.Sequence != p.opts.sequence)
+	fixed("slog_level", cfg.SlogLevel != p.opts.slogLevel)
+	fixed("omit_time", cfg.OmitTime != p.opts.omitTime)
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("slog provider: invalid config update: %w", err)
+	}
+
+	rules := &Rules{
+		MinLevel:  cfg.MinLevel,
+		Levels:    cfg.LevelOverrides,
+		ReadLevel: cfg.ReadLevel,
+		Sampling:  cfg.Sampling,
+	}
+	replace := false
+	if cs := p.opts.configSampling; cs != nil && p.opts.sampler == Sampler(cs.sampler) {
+		if cfg.Sampling != nil && *cfg.Sampling == cs.rule {
+			rules.Sampling = nil // The construction sampler keeps sampling
+		} else {
+			replace = true
+		}
+	}
+	active, err := compileRules(rules, p.opts.clock)
+	if err != nil {
+		return fmt.Errorf("slog provider: invalid config update: %w", err)
+	}
+	active.replacesSampler = replace
+	if prev := p.rules.Load(); prev != nil {
+		active.filter = prev.filter
+	}
+	p.opts.warmRules(active)
+	p.rules.Store(active)
+	return nil
+}
+
+// configSampling is the sampler created at construction from
+// Config.Sampling, which UpdateConfig replaces rather than adds to.
+type configSampling struct {
+	rule    SamplingRule
+	sampler *TickSampler
+}
+
+// dropPolicy returns the drop policy of c, DropNewest when unset.
+func (c Config) dropPolicy() OverflowPolicy {
+	if c.DropPolicy == "" {
+		return DropNewest
+	}
+	return c.DropPolicy
+}
+
+// overflowPolicy returns the OverflowPolicy matching the options.
+func (o *options) overflowPolicy() OverflowPolicy {
+	switch {
+	case o.weight != nil:
+		return EvictWeighted
+	case o.dropOldest:
+		return DropOldest
+	default:
+		return DropNewest
+	}
+}
+
+// LoadConfig reads a Config from a configuration file, so provider tuning
+// can live alongside the rest of the logging configuration.
+//
+// The format is detected from the file extension (JSON, YAML, TOML, ...)
+// using argus. Keys are the JSON names of the Config fields; as in
+// LoadRules, settings may be nested or written as dotted keys, which keeps
+// the format usable with flat parsers:
+//
+//	buffer_size: 10000
+//	drop_policy: drop_oldest
+//	retry_grace: 2ms
+//	min_level: info
+//	level_overrides.db: warn
+//	record_ttl.DEBUG: 30s
+//	sampling.tick: 1s
+//	sampling.first: 100
+//	sampling.thereafter: 100
+//
+// Durations are Go duration strings or numbers of nanoseconds, so the JSON
+// encoding of a Config loads back unchanged. A missing buffer_size defaults
+// to DefaultBufferSize. Unknown keys and invalid settings are reported as
+// errors.
+func LoadConfig(path string) (Config, error) {
+	format := argus.DetectFormat(path)
+	if format == argus.FormatUnknown {
+		return Config{}, fmt.Errorf("unsupported config format for file: %s", path)
+	}
+	data, err := os.ReadFile(path) // #nosec G304 -- path is provided by the application
+	if err != nil {
+		return Config{}, fmt.Errorf("failed to read config file: %w", err)
+	}
+	settings, err := argus.ParseConfig(data, format)
+	if err != nil {
+		return Config{}, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+	return ParseConfig(settings)
+}
+
+// ParseConfig builds a validated Config from decoded configuration
+// settings, as produced by encoding/json or a configuration library. See
+// LoadConfig for the schema.
+func ParseConfig(settings map[string]any) (Config, error) {
+	flat := make(map[string]any)
+	flattenSettings("", settings, flat)
+
+	keys := make([]string, 0, len(flat))
+	for key := range flat {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	cfg := Config{BufferSize: DefaultBufferSize}
+	for _, key := range keys {
+		value := flat[key]
+		section, name, _ := strings.Cut(key, ".")
+
+		var err error
+		switch {
+		case key == "buffer_size":
+			cfg.BufferSize, err = settingInt(value)
+		case key == "drop_policy":
+			cfg.DropPolicy = OverflowPolicy(strings.ToLower(settingString(value)))
+		case key == "retry_grace":
+			cfg.RetryGrace, err = settingDuration(value)
+		case key == "min_level":
+			var level slog.Level
+			level, err = parseLevelSetting(value)
+			cfg.MinLevel = &level
+		case key == "read_level":
+			var level slog.Level
+			level, err = parseLevelSetting(value)
+			cfg.ReadLevel = &level
+		case section == "level_overrides" && name != "":
+			if cfg.LevelOverrides == nil {
+				cfg.LevelOverrides = make(map[string]slog.Level)
+			}
+			cfg.LevelOverrides[name], err = parseLevelSetting(value)
+		case section == "record_ttl" && name != "":
+			var level slog.Level
+			if level, err = parseLevelSetting(name); err == nil {
+				if cfg.RecordTTL == nil {
+					cfg.RecordTTL = make(map[slog.Level]time.Duration)
+				}
+				cfg.RecordTTL[level], err = settingDuration(value)
+			}
+		case key == "err_closed":
+			cfg.ErrClosed, err = settingBool(value)
+		case key == "sequence":
+			cfg.Sequence, err = settingBool(value)
+		case key == "slog_level":
+			cfg.SlogLevel, err = settingBool(value)
+		case key == "omit_time":
+			cfg.OmitTime, err = settingBool(value)
+		case section == "sampling":
+			if cfg.Sampling == nil {
+				cfg.Sampling = &SamplingRule{}
+			}
+			err = cfg.Sampling.set(name, value)
+		default:
+			err = fmt.Errorf("unknown setting")
+		}
+		if err != nil {
+			return Config{}, fmt.Errorf("config setting %q: %w", key, err)
+		}
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return Config{}, err
+	}
+	return cfg, nil
+}
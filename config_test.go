@@ -0,0 +1,326 @@
+// config_test.go: Tests for declarative provider configuration
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewWithConfig_AppliesSettings(t *testing.T) {
+	minLevel := slog.LevelWarn
+	provider, err := NewWithConfig(Config{
+		BufferSize:     2,
+		DropPolicy:     DropOldest,
+		MinLevel:       &minLevel,
+		LevelOverrides: map[string]slog.Level{"db": slog.LevelError},
+	})
+	if err != nil {
+		t.Fatalf("NewWithConfig failed: %v", err)
+	}
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	if provider.Cap() != 2 {
+		t.Errorf("Cap() = %d, want 2", provider.Cap())
+	}
+	logger := slog.New(provider)
+	if logger.Enabled(context.Background(), slog.LevelInfo) {
+		t.Error("Expected MinLevel to be applied")
+	}
+	if logger.WithGroup("db").Enabled(context.Background(), slog.LevelWarn) {
+		t.Error("Expected LevelOverrides to be applied")
+	}
+
+	for _, msg := range []string{"one", "two", "three"} {
+		logger.Warn(msg)
+	}
+	if msgs := readMessages(t, provider, 2); msgs[0] != "two" || msgs[1] != "three" {
+		t.Errorf("Expected the drop policy to evict the oldest record, got %v", msgs)
+	}
+}
+
+func TestNewWithConfig_AppliesOptionsAndHooks(t *testing.T) {
+	var handled int
+	hook := HandleHookFunc(func(context.Context, slog.Record) bool {
+		handled++
+		return true
+	})
+	provider, err := NewWithConfig(Config{
+		BufferSize: 10,
+		Hooks:      []any{hook},
+		Options:    []Option{WithSequence()},
+	})
+	if err != nil {
+		t.Fatalf("NewWithConfig failed: %v", err)
+	}
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	record := readRecord(t, provider, func(logger *slog.Logger) { logger.Info("msg") })
+	if handled != 1 {
+		t.Errorf("Expected the hook to run once, got %d", handled)
+	}
+	if _, ok := findField(record, "seq"); !ok {
+		t.Error("Expected Options to be applied")
+	}
+}
+
+func TestNewWithConfig_ReportsEveryInvalidSetting(t *testing.T) {
+	provider, err := NewWithConfig(Config{
+		DropPolicy: "drop_random",
+		RetryGrace: -time.Second,
+		RecordTTL:  map[slog.Level]time.Duration{slog.LevelDebug: 0},
+		Hooks:      []any{42},
+	})
+	if err == nil {
+		_ = provider.Close()
+		t.Fatal("Expected an invalid config error")
+	}
+	for _, want := range []string{
+		"buffer size must be positive, got 0",
+		`unknown drop policy "drop_random"`,
+		"retry grace must not be negative",
+		"record TTL of level DEBUG must be positive",
+		"hook 0 (int) implements neither HandleHook nor EmitHook",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected %q in %q", want, err)
+		}
+	}
+}
+
+func TestConfig_JSONRoundTrip(t *testing.T) {
+	minLevel := slog.LevelDebug
+	cfg := Config{
+		BufferSize:     500,
+		DropPolicy:     EvictWeighted,
+		RetryGrace:     time.Millisecond,
+		MinLevel:       &minLevel,
+		LevelOverrides: map[string]slog.Level{"db.pool": slog.LevelWarn},
+		RecordTTL:      map[slog.Level]time.Duration{slog.LevelInfo: time.Minute},
+		Sequence:       true,
+	}
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if !strings.Contains(string(data), `"record_ttl":{"INFO":60000000000}`) {
+		t.Errorf("Expected levels to be encoded by name, got %s", data)
+	}
+
+	var decoded Config
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if !reflect.DeepEqual(decoded, cfg) {
+		t.Errorf("Round trip = %+v, want %+v", decoded, cfg)
+	}
+}
+
+func TestLoadConfig_YAML(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "provider.yaml")
+	data := `buffer_size: 5000
+drop_policy: drop_oldest
+retry_grace: 2ms
+min_level: info
+level_overrides.db.pool: warn
+record_ttl.DEBUG: 30s
+sampling.tick: 1s
+sampling.first: 100
+sampling.thereafter: 10
+sequence: true
+`
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	info := slog.LevelInfo
+	want := Config{
+		BufferSize:     5000,
+		DropPolicy:     DropOldest,
+		RetryGrace:     2 * time.Millisecond,
+		MinLevel:       &info,
+		LevelOverrides: map[string]slog.Level{"db.pool": slog.LevelWarn},
+		RecordTTL:      map[slog.Level]time.Duration{slog.LevelDebug: 30 * time.Second},
+		Sampling:       &SamplingRule{Tick: time.Second, First: 100, Thereafter: 10},
+		Sequence:       true,
+	}
+	if !reflect.DeepEqual(cfg, want) {
+		t.Errorf("LoadConfig() = %+v, want %+v", cfg, want)
+	}
+}
+
+func TestLoadConfig_LoadsEncodedConfig(t *testing.T) {
+	minLevel := slog.LevelWarn
+	cfg := Config{
+		BufferSize: 200,
+		RetryGrace: time.Millisecond,
+		MinLevel:   &minLevel,
+		RecordTTL:  map[slog.Level]time.Duration{slog.LevelInfo: time.Minute},
+		Sampling:   &SamplingRule{Tick: time.Second, First: 5},
+	}
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "provider.json")
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if !reflect.DeepEqual(loaded, cfg) {
+		t.Errorf("LoadConfig() = %+v, want %+v", loaded, cfg)
+	}
+}
+
+func TestParseConfig_DefaultsAndValidation(t *testing.T) {
+	cfg, err := ParseConfig(map[string]any{"min_level": "debug"})
+	if err != nil || cfg.BufferSize != DefaultBufferSize {
+		t.Errorf("ParseConfig() = %+v, %v; want the default buffer size", cfg, err)
+	}
+
+	for settings, want := range map[string]map[string]any{
+		`config setting "bufer_size": unknown setting`: {"bufer_size": 10},
+		`config setting "retry_grace"`:                 {"retry_grace": "soon"},
+		"buffer size must be positive":                 {"buffer_size": 0},
+		"sampling tick must be positive":               {"sampling": map[string]any{"first": 1}},
+	} {
+		if _, err := ParseConfig(want); err == nil || !strings.Contains(err.Error(), settings) {
+			t.Errorf("ParseConfig(%v) error = %v, want %q", want, err, settings)
+		}
+	}
+}
+
+func TestProvider_UpdateConfig(t *testing.T) {
+	info := slog.LevelInfo
+	cfg := Config{BufferSize: 10, DropPolicy: DropOldest, MinLevel: &info}
+	provider, err := NewWithConfig(cfg)
+	if err != nil {
+		t.Fatalf("NewWithConfig failed: %v", err)
+	}
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	logger := slog.New(provider)
+	logger.Info("buffered before the update")
+
+	debug := slog.LevelDebug
+	cfg.MinLevel = &debug
+	cfg.LevelOverrides = map[string]slog.Level{"db": slog.LevelError}
+	if err := provider.UpdateConfig(cfg); err != nil {
+		t.Fatalf("UpdateConfig failed: %v", err)
+	}
+	if !logger.Enabled(context.Background(), slog.LevelDebug) {
+		t.Error("Expected the new minimum level to apply")
+	}
+	if logger.WithGroup("db").Enabled(context.Background(), slog.LevelWarn) {
+		t.Error("Expected the new level override to apply")
+	}
+	logger.Debug("buffered after the update")
+	if msgs := readMessages(t, provider, 2); msgs[0] != "buffered before the update" {
+		t.Errorf("Expected buffered records to be kept, got %v", msgs)
+	}
+}
+
+func TestProvider_UpdateConfig_KeepsMessageRules(t *testing.T) {
+	provider := New(10)
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	if err := provider.SetRules(&Rules{Messages: []MessageRule{{Action: DropMessage, Glob: "noise*"}}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := provider.UpdateConfig(Config{BufferSize: 10, Sampling: &SamplingRule{Tick: time.Minute, First: 1}}); err != nil {
+		t.Fatalf("UpdateConfig failed: %v", err)
+	}
+	logger := slog.New(provider)
+	logger.Info("noise 1")
+	logger.Info("signal")
+	logger.Info("signal")
+	if msgs := readMessages(t, provider, 1); msgs[0] != "signal" || provider.Len() != 0 {
+		t.Errorf("Expected message rules and sampling to apply, got %v and %d more", msgs, provider.Len())
+	}
+}
+
+func TestProvider_UpdateConfig_ReplacesConfigSampling(t *testing.T) {
+	cfg := Config{BufferSize: 20, Sampling: &SamplingRule{Tick: time.Hour, First: 1, Thereafter: 2}}
+	provider, err := NewWithConfig(cfg)
+	if err != nil {
+		t.Fatalf("NewWithConfig failed: %v", err)
+	}
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+	logger := slog.New(provider)
+	logBurst := func() {
+		for i := 0; i < 5; i++ {
+			logger.Info("hot")
+		}
+	}
+
+	logBurst() // Records 1, 3 and 5 are sampled
+	reloaded := cfg
+	reloaded.Sampling = &SamplingRule{Tick: time.Hour, First: 1, Thereafter: 2}
+	if err := provider.UpdateConfig(reloaded); err != nil {
+		t.Fatalf("UpdateConfig failed: %v", err)
+	}
+	logBurst() // Records 7 and 9, sampled once
+	if n := provider.Len(); n != 5 {
+		t.Errorf("Expected an unchanged reload to keep sampling once, got %d buffered, want 5", n)
+	}
+
+	reloaded.Sampling = &SamplingRule{Tick: time.Hour, First: 1}
+	if err := provider.UpdateConfig(reloaded); err != nil {
+		t.Fatalf("UpdateConfig failed: %v", err)
+	}
+	logBurst() // Only the first record of the new sampler
+	if n := provider.Len(); n != 6 {
+		t.Errorf("Expected the new rule to replace the construction sampler, got %d buffered, want 6", n)
+	}
+
+	reloaded.Sampling = nil
+	if err := provider.UpdateConfig(reloaded); err != nil {
+		t.Fatalf("UpdateConfig failed: %v", err)
+	}
+	logBurst()
+	if n := provider.Len(); n != 11 {
+		t.Errorf("Expected removing the rule to disable sampling, got %d buffered, want 11", n)
+	}
+}
+
+func TestProvider_UpdateConfig_RejectsFixedSettings(t *testing.T) {
+	provider := New(10, WithMinLevel(slog.LevelWarn))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	debug := slog.LevelDebug
+	err := provider.UpdateConfig(Config{BufferSize: 20, DropPolicy: DropOldest, Sequence: true, MinLevel: &debug})
+	if !errors.Is(err, ErrNotUpdatable) {
+		t.Fatalf("Expected %v, got %v", ErrNotUpdatable, err)
+	}
+	for _, want := range []string{"buffer size", "drop policy", "sequence"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected %q in %q", want, err)
+		}
+	}
+	if provider.Enabled(context.Background(), slog.LevelDebug) {
+		t.Error("Expected a rejected update to change nothing")
+	}
+	if err := provider.UpdateConfig(Config{}); !errors.Is(err, ErrInvalidBufferSize) {
+		t.Errorf("Expected the update to be validated, got %v", err)
+	}
+}
@@ -0,0 +1,101 @@
+// context.go: Request-scoped attributes carried in a context
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"context"
+	"log/slog"
+)
+
+// ctxAttrsKey is the context key of the attributes added with AppendCtx.
+type ctxAttrsKey struct{}
+
+// AppendCtx returns a copy of ctx carrying attrs in addition to the
+// attributes already added to ctx with AppendCtx. Records logged through a
+// ContextHandler with the returned context include them:
+//
+//	func middleware(next http.Handler) http.Handler {
+//	    return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+//	        ctx := slogprovider.AppendCtx(r.Context(), slog.String("request_id", newID()))
+//	        next.ServeHTTP(w, r.WithContext(ctx))
+//	    })
+//	}
+func AppendCtx(ctx context.Context, attrs ...slog.Attr) context.Context {
+	if len(attrs) == 0 {
+		return ctx
+	}
+	existing := CtxAttrs(ctx)
+	combined := make([]slog.Attr, 0, len(existing)+len(attrs))
+	combined = append(append(combined, existing...), attrs...)
+	return context.WithValue(ctx, ctxAttrsKey{}, combined)
+}
+
+// CtxAttrs returns the attributes added to ctx with AppendCtx, oldest first.
+// The result must not be modified.
+func CtxAttrs(ctx context.Context) []slog.Attr {
+	if ctx == nil {
+		return nil
+	}
+	attrs, _ := ctx.Value(ctxAttrsKey{}).([]slog.Attr)
+	return attrs
+}
+
+// ContextHandler is a slog.Handler adding the attributes carried by the
+// record's context, see AppendCtx, before passing records to the next
+// handler. Request-scoped attributes set once in middleware then appear on
+// every record without rebinding loggers:
+//
+//	provider := slogprovider.New(1000)
+//	logger := slog.New(slogprovider.NewContextHandler(provider))
+//	logger.InfoContext(ctx, "order placed") // includes request_id
+//
+// Context attributes follow the record's own attributes. ContextHandler can
+// wrap any handler, not only a Provider; a Provider tagging context
+// attributes with WithProvenance adds them itself, so ContextHandler leaves
+// its records unchanged.
+type ContextHandler struct {
+	next slog.Handler
+}
+
+// contextAttrsAdder is implemented by handlers that add the AppendCtx
+// attributes themselves.
+type contextAttrsAdder interface {
+	addsContextAttrs() bool
+}
+
+// NewContextHandler returns a ContextHandler wrapping next.
+func NewContextHandler(next slog.Handler) *ContextHandler {
+	return &ContextHandler{next: next}
+}
+
+// Enabled implements slog.Handler by delegating to the next handler.
+func (h *ContextHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+// Handle implements slog.Handler by adding the context attributes to a copy
+// of record and passing it to the next handler.
+func (h *ContextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if p, ok := h.next.(contextAttrsAdder); ok && p.addsContextAttrs() {
+		return h.next.Handle(ctx, record)
+	}
+	if attrs := CtxAttrs(ctx); len(attrs) > 0 {
+		record = record.Clone()
+		record.AddAttrs(attrs...)
+	}
+	return h.next.Handle(ctx, record)
+}
+
+// WithAttrs implements slog.Handler.
+func (h *ContextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &ContextHandler{next: h.next.WithAttrs(attrs)}
+}
+
+// WithGroup implements slog.Handler.
+func (h *ContextHandler) WithGroup(name string) slog.Handler {
+	return &ContextHandler{next: h.next.WithGroup(name)}
+}
@@ -0,0 +1,73 @@
+// context_test.go: Tests for context attributes and ContextHandler
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+)
+
+// Compile-time check that ContextHandler is a slog.Handler.
+var _ slog.Handler = (*ContextHandler)(nil)
+
+func TestAppendCtx_Accumulates(t *testing.T) {
+	base := AppendCtx(context.Background(), slog.String("a", "1"))
+	first := AppendCtx(base, slog.String("b", "2"))
+	second := AppendCtx(base, slog.String("c", "3"))
+
+	if got := CtxAttrs(first); len(got) != 2 || got[1].Key != "b" {
+		t.Errorf("Unexpected attrs in first: %v", got)
+	}
+	if got := CtxAttrs(second); len(got) != 2 || got[1].Key != "c" {
+		t.Errorf("Expected derived contexts not to interfere, got %v", got)
+	}
+	if got := CtxAttrs(base); len(got) != 1 {
+		t.Errorf("Expected base context unchanged, got %v", got)
+	}
+	if AppendCtx(base) != base {
+		t.Error("Expected AppendCtx without attrs to return ctx")
+	}
+}
+
+func TestContextHandler_AddsContextAttrs(t *testing.T) {
+	provider := New(10)
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	logger := slog.New(NewContextHandler(provider))
+	ctx := AppendCtx(context.Background(), slog.String("request_id", "r-1"))
+	logger.InfoContext(ctx, "order placed", "order", 7)
+	logger.Info("no context")
+
+	record := readWithTimeout(t, provider)
+	if f, ok := findField(record, "request_id"); !ok || f.StringValue() != "r-1" {
+		t.Errorf("Expected request_id from the context, got %v", f)
+	}
+	if _, ok := findField(record, "order"); !ok {
+		t.Error("Expected the record's own attributes to be kept")
+	}
+	if record = readWithTimeout(t, provider); len(fieldKeys(record)) != 0 {
+		t.Errorf("Expected no context attributes without AppendCtx, got %v", fieldKeys(record))
+	}
+}
+
+func TestContextHandler_DelegatesLevelAndGroups(t *testing.T) {
+	provider := New(10,
+		WithMinLevel(slog.LevelInfo),
+		WithLevelOverrides(map[string]slog.Leveler{"db": slog.LevelError}),
+	)
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	handler := NewContextHandler(provider)
+	ctx := context.Background()
+	if handler.Enabled(ctx, slog.LevelDebug) {
+		t.Error("Expected Enabled to follow the wrapped handler")
+	}
+	if handler.WithGroup("db").Enabled(ctx, slog.LevelWarn) {
+		t.Error("Expected WithGroup to reach the wrapped handler")
+	}
+}
@@ -0,0 +1,41 @@
+// convert.go: Exported slog to Iris conversion functions
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"log/slog"
+
+	"github.com/agilira/iris"
+)
+
+// ConvertRecord converts a slog.Record to an iris.Record using exactly the
+// rules applied by Provider.Read, so other bridges, tests and fuzzers can
+// reuse them.
+//
+// Only options that affect conversion itself, such as WithJournald,
+// WithFieldConverter, WithCoercion and WithNamespace, are honored; Handle-time
+// options (levels, filters, enrichers, ...) and Read-path options (schema,
+// middleware) are ignored.
+func ConvertRecord(record slog.Record, opts ...Option) *iris.Record {
+	p := &Provider{opts: newOptions(opts)}
+	p.coercion = newCoercer(p.opts.coercion)
+	return p.convertSlogRecord(record)
+}
+
+// ConvertAttr converts attr to the iris.Fields Provider.Read emits for it,
+// using DefaultFieldConverter: the value is resolved, a group yields one field
+// per member with dotted keys, e.g. "req.id", and an empty attribute or group
+// yields none.
+func ConvertAttr(attr slog.Attr) []iris.Field {
+	var p Provider
+	var fields []iris.Field
+	p.flattenAttr("", attr, func(f iris.Field) bool {
+		fields = append(fields, f)
+		return true
+	})
+	return fields
+}
@@ -0,0 +1,96 @@
+// convert_test.go: Tests for the exported conversion functions
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/agilira/iris"
+)
+
+func TestConvertAttr(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		attr  slog.Attr
+		check func(iris.Field) bool
+	}{
+		{slog.String("s", "v"), func(f iris.Field) bool { return f.IsString() && f.StringValue() == "v" }},
+		{slog.Int64("i", -3), func(f iris.Field) bool { return f.IsInt() && f.IntValue() == -3 }},
+		{slog.Uint64("u", 3), func(f iris.Field) bool { return f.IsUint() && f.UintValue() == 3 }},
+		{slog.Float64("f", 1.5), func(f iris.Field) bool { return f.IsFloat() && f.FloatValue() == 1.5 }},
+		{slog.Bool("b", true), func(f iris.Field) bool { return f.IsBool() && f.BoolValue() }},
+		{slog.Duration("d", time.Second), func(f iris.Field) bool { return f.IsDuration() && f.DurationValue() == time.Second }},
+		{slog.Time("t", now), func(f iris.Field) bool { return f.IsTime() && f.TimeValue().Equal(now) }},
+		{slog.Any("a", []int{1, 2}), func(f iris.Field) bool { return f.IsString() && f.StringValue() == "[1 2]" }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.attr.Key, func(t *testing.T) {
+			fields := ConvertAttr(tt.attr)
+			if len(fields) != 1 || fields[0].Key() != tt.attr.Key || !tt.check(fields[0]) {
+				t.Errorf("ConvertAttr(%v) = %+v", tt.attr, fields)
+			}
+		})
+	}
+}
+
+type convertValuer int
+
+func (v convertValuer) LogValue() slog.Value { return slog.IntValue(int(v)) }
+
+func TestConvertAttr_MatchesProvider(t *testing.T) {
+	provider := New(10, WithoutRecordTime())
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	attrs := []slog.Attr{
+		slog.Any("valuer", convertValuer(7)),
+		slog.Group("req", slog.Int("id", 7), slog.Group("user", slog.String("name", "ada"))),
+		slog.Group("empty"),
+		{},
+	}
+	want := readRecord(t, provider, func(l *slog.Logger) { l.LogAttrs(context.Background(), slog.LevelInfo, "m", attrs...) })
+
+	var got []iris.Field
+	for _, attr := range attrs {
+		got = append(got, ConvertAttr(attr)...)
+	}
+	if len(got) != want.FieldCount() {
+		t.Fatalf("ConvertAttr() = %d fields, want %d", len(got), want.FieldCount())
+	}
+	for i, f := range got {
+		if w := want.GetField(i); f.Key() != w.Key() || f.Type() != w.Type() || f.IntValue() != w.IntValue() || f.StringValue() != w.StringValue() {
+			t.Errorf("field %d = %+v, want %+v", i, f, w)
+		}
+	}
+	if f, _ := findField(want, "req.user.name"); f.StringValue() != "ada" {
+		t.Errorf("Expected req.user.name=ada, got %+v", f)
+	}
+}
+
+func TestConvertRecord_MatchesProvider(t *testing.T) {
+	opts := []Option{WithJournald(JournaldConfig{UppercaseFields: true, DeriveMessageIDs: true})}
+	provider := New(10, opts...)
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	record := slog.NewRecord(time.Now(), slog.LevelWarn, "disk low", 0)
+	record.AddAttrs(slog.Int("free.mb", 120))
+
+	want := readRecord(t, provider, func(l *slog.Logger) { l.Warn("disk low", "free.mb", 120) })
+	got := ConvertRecord(record, opts...)
+
+	if got.Level != want.Level || got.Msg != want.Msg || got.FieldCount() != want.FieldCount() {
+		t.Fatalf("ConvertRecord() = %v %q %d fields, want %v %q %d fields",
+			got.Level, got.Msg, got.FieldCount(), want.Level, want.Msg, want.FieldCount())
+	}
+	for i := 0; i < got.FieldCount(); i++ {
+		if g, w := got.GetField(i), want.GetField(i); g.Key() != w.Key() {
+			t.Errorf("field %d key = %q, want %q", i, g.Key(), w.Key())
+		}
+	}
+}
@@ -0,0 +1,161 @@
+// converter.go: Pluggable field conversion and custom converter registry
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"log/slog"
+	"reflect"
+	"sync"
+	"sync/atomic"
+
+	"github.com/agilira/iris"
+)
+
+// FieldConverter converts attribute values to Iris fields.
+//
+// Implementations can replace the conversion strategy wholesale, e.g. with
+// protobuf-aware conversion, or wrap DefaultFieldConverter to handle a few
+// values specially and delegate the rest. The key has already been adapted to
+// the provider's naming conventions (see WithJournald). ConvertField is called
+// on the reader goroutine and must be safe for concurrent use when the
+// converter is shared between providers.
+type FieldConverter interface {
+	ConvertField(key string, value slog.Value) iris.Field
+}
+
+// FieldConverterFunc adapts a function to the FieldConverter interface.
+type FieldConverterFunc func(key string, value slog.Value) iris.Field
+
+// ConvertField calls f(key, value).
+func (f FieldConverterFunc) ConvertField(key string, value slog.Value) iris.Field {
+	return f(key, value)
+}
+
+// DefaultFieldConverter is the conversion strategy used unless
+// WithFieldConverter is given. It maps slog kinds to typed Iris fields:
+//   - String → iris.String
+//   - Int64 → iris.Int64
+//   - Uint64 → iris.Uint64
+//   - Float64 → iris.Float64
+//   - Bool → iris.Bool
+//   - Duration → iris.Dur
+//   - Time → iris.Time
+//   - Attrs built with Field or Bytes → the carried field
+//   - Types registered with RegisterConverter → the registered conversion
+//   - Other types → iris.String (using String() method)
+var DefaultFieldConverter FieldConverter = defaultFieldConverter{}
+
+// defaultFieldConverter implements DefaultFieldConverter and
+// CanonicalFieldConverter.
+type defaultFieldConverter struct {
+	canonical bool // Render values without a typed conversion canonically
+}
+
+// ConvertField implements FieldConverter.
+func (c defaultFieldConverter) ConvertField(key string, value slog.Value) iris.Field {
+	switch value.Kind() {
+	case slog.KindString:
+		return iris.String(key, value.String())
+	case slog.KindInt64:
+		return iris.Int64(key, value.Int64())
+	case slog.KindUint64:
+		return iris.Uint64(key, value.Uint64())
+	case slog.KindFloat64:
+		return iris.Float64(key, value.Float64())
+	case slog.KindBool:
+		return iris.Bool(key, value.Bool())
+	case slog.KindDuration:
+		return iris.Dur(key, value.Duration())
+	case slog.KindTime:
+		return iris.Time(key, value.Time())
+	case slog.KindAny:
+		if f, ok := prebuiltField(key, value); ok {
+			return f
+		}
+		if convert, ok := lookupConverter(value.Any()); ok {
+			return convert(key, value.Any())
+		}
+		return iris.String(key, c.render(value))
+	default:
+		return iris.String(key, c.render(value))
+	}
+}
+
+// render returns the string form of a value without a typed conversion.
+func (c defaultFieldConverter) render(value slog.Value) string {
+	if c.canonical {
+		return canonicalString(value)
+	}
+	return value.String()
+}
+
+// WithFieldConverter replaces the attribute value conversion strategy. A nil
+// converter restores DefaultFieldConverter.
+//
+//	redactTokens := slogprovider.FieldConverterFunc(func(key string, v slog.Value) iris.Field {
+//	    if key == "token" {
+//	        return iris.String(key, "[REDACTED]")
+//	    }
+//	    return slogprovider.DefaultFieldConverter.ConvertField(key, v)
+//	})
+//	provider := slogprovider.New(1000, slogprovider.WithFieldConverter(redactTokens))
+func WithFieldConverter(c FieldConverter) Option {
+	return func(o *options) { o.converter = c }
+}
+
+// anyConverter converts a value of a registered type to a field.
+type anyConverter func(key string, v any) iris.Field
+
+var (
+	convertersMu sync.Mutex                                    // Serializes registrations
+	converters   atomic.Pointer[map[reflect.Type]anyConverter] // Copy-on-write registry read by conversion
+)
+
+// RegisterConverter teaches every provider how to convert attribute values of
+// type T, which would otherwise be converted to strings through their String
+// form. It is typically called from an init function:
+//
+//	func init() {
+//	    slogprovider.RegisterConverter(func(key string, m Money) iris.Field {
+//	        return iris.Int64(key, m.Cents)
+//	    })
+//	}
+//
+// Values match when their dynamic type is exactly T, so T must be a concrete
+// type; registering an interface type panics. Pointer and value types are
+// distinct. Registering T again replaces the previous converter. Registered
+// types count as convertible for WithStrictTyping.
+//
+// The registry is global and safe for concurrent use; lookups on the Read
+// path are lock-free.
+func RegisterConverter[T any](convert func(key string, v T) iris.Field) {
+	typ := reflect.TypeFor[T]()
+	if typ.Kind() == reflect.Interface {
+		panic("slogprovider: RegisterConverter requires a concrete type, got interface " + typ.String())
+	}
+
+	convertersMu.Lock()
+	defer convertersMu.Unlock()
+	next := make(map[reflect.Type]anyConverter)
+	if current := converters.Load(); current != nil {
+		for t, c := range *current {
+			next[t] = c
+		}
+	}
+	next[typ] = func(key string, v any) iris.Field { return convert(key, v.(T)) }
+	converters.Store(&next)
+}
+
+// lookupConverter returns the registered converter for the dynamic type of v.
+func lookupConverter(v any) (anyConverter, bool) {
+	registry := converters.Load()
+	if registry == nil || v == nil {
+		return nil, false
+	}
+	c, ok := (*registry)[reflect.TypeOf(v)]
+	return c, ok
+}
@@ -0,0 +1,104 @@
+// converter_test.go: Tests for the custom converter registry
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"fmt"
+	"log/slog"
+	"testing"
+
+	"github.com/agilira/iris"
+)
+
+type testMoney struct{ Cents int64 }
+
+func (m testMoney) String() string { return fmt.Sprintf("%d.%02d", m.Cents/100, m.Cents%100) }
+
+type testUserID uint64
+
+func init() {
+	RegisterConverter(func(key string, m testMoney) iris.Field { return iris.Int64(key, m.Cents) })
+	RegisterConverter(func(key string, id testUserID) iris.Field { return iris.Uint64(key, uint64(id)) })
+}
+
+func TestRegisterConverter_ConvertsDomainTypes(t *testing.T) {
+	provider := New(10)
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	record := readRecord(t, provider, func(l *slog.Logger) {
+		l.Info("charge", "amount", testMoney{1250}, "user", testUserID(42), "ptr", &testMoney{1})
+	})
+
+	if f, _ := findField(record, "amount"); !f.IsInt() || f.IntValue() != 1250 {
+		t.Errorf("amount = %+v, want int 1250", f)
+	}
+	if f, _ := findField(record, "user"); !f.IsUint() || f.UintValue() != 42 {
+		t.Errorf("user = %+v, want uint 42", f)
+	}
+	if f, _ := findField(record, "ptr"); !f.IsString() {
+		t.Errorf("ptr = %+v, want unregistered pointer type converted to string", f)
+	}
+}
+
+func TestRegisterConverter_ReplacesPrevious(t *testing.T) {
+	type version string
+	RegisterConverter(func(key string, v version) iris.Field { return iris.String(key, "old") })
+	RegisterConverter(func(key string, v version) iris.Field { return iris.String(key, "v"+string(v)) })
+
+	if f := ConvertAttr(slog.Any("version", version("2")))[0]; f.StringValue() != "v2" {
+		t.Errorf("version = %q, want v2", f.StringValue())
+	}
+}
+
+func TestRegisterConverter_RejectsInterfaces(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("RegisterConverter[fmt.Stringer] did not panic")
+		}
+	}()
+	RegisterConverter(func(key string, s fmt.Stringer) iris.Field { return iris.String(key, s.String()) })
+}
+
+func TestRegisterConverter_SatisfiesStrictTyping(t *testing.T) {
+	provider := New(10, WithStrictTyping(StrictTyping{}))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	slog.New(provider).Info("charge", "amount", testMoney{1})
+	if got := provider.Stats().Unconvertible; got != 0 {
+		t.Errorf("Stats().Unconvertible = %d, want registered type accepted", got)
+	}
+}
+
+func TestWithFieldConverter_WrapsDefault(t *testing.T) {
+	redact := FieldConverterFunc(func(key string, v slog.Value) iris.Field {
+		if key == "token" {
+			return iris.String(key, "[REDACTED]")
+		}
+		return DefaultFieldConverter.ConvertField(key, v)
+	})
+	provider := New(10, WithFieldConverter(redact))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	record := readRecord(t, provider, func(l *slog.Logger) { l.Info("login", "token", "s3cr3t", "attempt", 2) })
+	if f, _ := findField(record, "token"); f.StringValue() != "[REDACTED]" {
+		t.Errorf("token = %q, want redacted", f.StringValue())
+	}
+	if f, _ := findField(record, "attempt"); !f.IsInt() || f.IntValue() != 2 {
+		t.Errorf("attempt = %+v, want default int conversion", f)
+	}
+}
+
+func TestWithFieldConverter_NilRestoresDefault(t *testing.T) {
+	stringify := FieldConverterFunc(func(key string, v slog.Value) iris.Field { return iris.String(key, v.String()) })
+	provider := New(10, WithFieldConverter(stringify), WithFieldConverter(nil))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	record := readRecord(t, provider, func(l *slog.Logger) { l.Info("n", "count", 3) })
+	if f, _ := findField(record, "count"); !f.IsInt() {
+		t.Errorf("count = %+v, want default int conversion", f)
+	}
+}
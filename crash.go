@@ -0,0 +1,106 @@
+// crash.go: Crash dumps of recent and buffered records on panic
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"time"
+
+	"github.com/agilira/iris"
+)
+
+// Crash dump header fields.
+const (
+	CrashRecentKey   = "crash.recent"
+	CrashBufferedKey = "crash.buffered"
+)
+
+// WithCrashDump sets the file written by DumpOnPanic. The default is
+// slogprovider-crash-<pid>.ndjson in os.TempDir().
+func WithCrashDump(path string) Option {
+	return func(o *options) { o.crashPath = path }
+}
+
+// DumpOnPanic writes a crash dump when the calling goroutine panics, then
+// continues panicking. It must be deferred directly:
+//
+//	func main() {
+//	    provider := slogprovider.New(1000, slogprovider.WithRecentRecords(200))
+//	    defer provider.DumpOnPanic()
+//	    ...
+//	}
+//
+// Go has no process-wide panic hook, so only panics in goroutines that defer
+// DumpOnPanic are captured. The dump is written with WriteCrashDump to the
+// file set with WithCrashDump; failures to write it are reported on stderr.
+func (p *Provider) DumpOnPanic() {
+	r := recover()
+	if r == nil {
+		return
+	}
+	if err := p.writeCrashFile(r); err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "slogprovider: %v\n", err)
+	}
+	panic(r)
+}
+
+// CrashDumpPath returns the file written by DumpOnPanic.
+func (p *Provider) CrashDumpPath() string {
+	if p.opts.crashPath != "" {
+		return p.opts.crashPath
+	}
+	return filepath.Join(os.TempDir(), fmt.Sprintf("slogprovider-crash-%d.ndjson", os.Getpid()))
+}
+
+// WriteCrashDump writes the final log context to w as NDJSON, for use from
+// custom recover handlers. The first line is a fatal record describing reason
+// with the current goroutine's stack and the number of records that follow
+// (CrashRecentKey and CrashBufferedKey); then come the records kept by
+// WithRecentRecords and the unconsumed buffer, in the format of
+// ExportNDJSON. The output can be re-shipped with ImportNDJSON.
+func (p *Provider) WriteCrashDump(w io.Writer, reason any) error {
+	var recent []recentRecord
+	if p.recent != nil {
+		recent = p.recent.last(0)
+	}
+	buffered := p.snapshot() // Counted and written from one copy
+
+	header := iris.NewRecord(iris.Fatal, fmt.Sprintf("panic: %v", reason))
+	header.Stack = string(debug.Stack())
+	header.AddField(iris.Int(CrashRecentKey, len(recent)))
+	header.AddField(iris.Int(CrashBufferedKey, len(buffered)))
+
+	out := newNDJSONWriter(w)
+	if err := out.write(time.Now(), header); err != nil {
+		return err
+	}
+	for i := range recent {
+		if err := out.write(recent[i].time, &recent[i].record); err != nil {
+			return err
+		}
+	}
+	return p.export(w, NDJSONCodec{}, buffered)
+}
+
+// writeCrashFile writes the crash dump for reason to CrashDumpPath.
+func (p *Provider) writeCrashFile(reason any) error {
+	path := p.CrashDumpPath()
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600) // #nosec G304 -- path is provided by the application
+	if err != nil {
+		return fmt.Errorf("failed to create crash dump: %w", err)
+	}
+	err = p.WriteCrashDump(f, reason)
+	if closeErr := f.Close(); closeErr != nil {
+		err = errors.Join(err, fmt.Errorf("failed to close crash dump: %w", closeErr))
+	}
+	return err
+}
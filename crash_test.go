@@ -0,0 +1,125 @@
+// crash_test.go: Tests for crash dumps
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// crashLines decodes the lines of a crash dump.
+func crashLines(t *testing.T, data []byte) []map[string]any {
+	t.Helper()
+	var lines []map[string]any
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(nil, 1<<20)
+	for scanner.Scan() {
+		var line map[string]any
+		if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+			t.Fatalf("Invalid crash dump line %q: %v", scanner.Text(), err)
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func TestDumpOnPanic_WritesCrashFileAndRepanics(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "crash.ndjson")
+	provider := New(10, WithRecentRecords(5), WithCrashDump(path))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	logger := slog.New(provider)
+	logger.Info("consumed")
+	readWithTimeout(t, provider)
+	logger.Info("pending")
+
+	func() {
+		defer func() {
+			if r := recover(); r != "boom" {
+				t.Errorf("Expected the panic to continue, got %v", r)
+			}
+		}()
+		defer provider.DumpOnPanic()
+		panic("boom")
+	}()
+
+	data, err := os.ReadFile(path) // #nosec G304 -- test file
+	if err != nil {
+		t.Fatalf("Crash dump not written: %v", err)
+	}
+	lines := crashLines(t, data)
+	if len(lines) != 3 {
+		t.Fatalf("Expected header, recent and buffered lines, got %d", len(lines))
+	}
+	header := lines[0]
+	if header["msg"] != "panic: boom" || header["level"] != "fatal" || !strings.Contains(header["stack"].(string), "TestDumpOnPanic") {
+		t.Errorf("Unexpected header: %v", header)
+	}
+	if header[CrashRecentKey] != 1.0 || header[CrashBufferedKey] != 1.0 {
+		t.Errorf("Unexpected header counts: %v", header)
+	}
+	if lines[1]["msg"] != "consumed" || lines[2]["msg"] != "pending" {
+		t.Errorf("Unexpected records: %v %v", lines[1]["msg"], lines[2]["msg"])
+	}
+}
+
+func TestDumpOnPanic_NoPanic(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "crash.ndjson")
+	provider := New(10, WithCrashDump(path))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	func() {
+		defer provider.DumpOnPanic()
+	}()
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Error("Expected no crash dump without a panic")
+	}
+}
+
+func TestCrashDumpPath_Default(t *testing.T) {
+	provider := New(10)
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+	if path := provider.CrashDumpPath(); filepath.Dir(path) != filepath.Clean(os.TempDir()) {
+		t.Errorf("Expected default crash dump in the temp dir, got %s", path)
+	}
+}
+
+// loggingWriter logs a record through provider on its first write.
+type loggingWriter struct {
+	bytes.Buffer
+	provider *Provider
+	logged   bool
+}
+
+func (w *loggingWriter) Write(p []byte) (int, error) {
+	if !w.logged {
+		w.logged = true
+		slog.New(w.provider).Info("logged while dumping")
+	}
+	return w.Buffer.Write(p)
+}
+
+func TestWriteCrashDump_CountMatchesRecords(t *testing.T) {
+	provider := New(10)
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+	slog.New(provider).Info("pending")
+
+	w := &loggingWriter{provider: provider}
+	if err := provider.WriteCrashDump(w, "boom"); err != nil {
+		t.Fatalf("WriteCrashDump failed: %v", err)
+	}
+	lines := crashLines(t, w.Bytes())
+	if len(lines) != 2 || lines[0][CrashBufferedKey] != 1.0 {
+		t.Errorf("Expected the header count to match the 1 record written, got %d lines: %v", len(lines), lines[0])
+	}
+}
@@ -0,0 +1,70 @@
+// deadline.go: Context deadline awareness: admission control and remaining time
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/agilira/iris"
+)
+
+// DeadlineRemainingKey is the field key used for the time left until the
+// context deadline, in milliseconds.
+const DeadlineRemainingKey = "deadline_remaining_ms"
+
+// WithDeadlineMargin skips optional Handle-time work for records logged with
+// a context whose deadline is less than margin away (or already expired), so
+// requests that are about to time out do not spend time on logging extras.
+//
+// Under deadline pressure the record is still buffered, but enrichers
+// (WithEnricher and the options built on it) are not evaluated; skipped
+// enrichments are counted in Stats().EnrichmentsSkipped. Conversion is
+// always deferred to the Read path and never costs the caller. Contexts
+// without a deadline are unaffected.
+func WithDeadlineMargin(margin time.Duration) Option {
+	return func(o *options) { o.deadlineMargin = margin }
+}
+
+// underDeadline reports whether ctx expires within the configured margin.
+func (o *options) underDeadline(ctx context.Context) bool {
+	if o.deadlineMargin <= 0 || ctx == nil {
+		return false
+	}
+	deadline, ok := ctx.Deadline()
+	return ok && time.Until(deadline) < o.deadlineMargin
+}
+
+// WithDeadlineRemaining attaches the time left until the deadline of the
+// record's context, in whole milliseconds, under DeadlineRemainingKey. The
+// value is negative once the deadline has passed, which makes timeout
+// cascades visible in the logs. It is measured from the record time at
+// Handle time, and records logged without a context deadline get no field.
+//
+// The field is computed even under WithDeadlineMargin, since records close
+// to their deadline are the ones it is meant for.
+func WithDeadlineRemaining() Option {
+	return func(o *options) { o.deadlineRemaining = true }
+}
+
+// deadlineField returns the DeadlineRemainingKey field for a record logged
+// with ctx, if ctx has a deadline.
+func deadlineField(ctx context.Context, record slog.Record) (iris.Field, bool) {
+	if ctx == nil {
+		return iris.Field{}, false
+	}
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return iris.Field{}, false
+	}
+	now := record.Time
+	if now.IsZero() {
+		now = time.Now()
+	}
+	return iris.Int64(DeadlineRemainingKey, deadline.Sub(now).Milliseconds()), true
+}
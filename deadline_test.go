@@ -0,0 +1,77 @@
+// deadline_test.go: Tests for context deadline awareness
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/agilira/iris"
+)
+
+func TestWithDeadlineMargin_SkipsEnrichmentNearDeadline(t *testing.T) {
+	calls := 0
+	expensive := func(context.Context, slog.Record) []iris.Field {
+		calls++
+		return []iris.Field{iris.String("flags", "snapshot")}
+	}
+	provider := New(10, WithEnricher(expensive), WithDeadlineMargin(50*time.Millisecond))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	urgent, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	relaxed, cancel2 := context.WithTimeout(context.Background(), time.Hour)
+	defer cancel2()
+
+	record := readRecord(t, provider, func(l *slog.Logger) { l.InfoContext(urgent, "urgent") })
+	if _, ok := findField(record, "flags"); ok || calls != 0 {
+		t.Errorf("enriched a record under deadline pressure (calls = %d)", calls)
+	}
+
+	record = readRecord(t, provider, func(l *slog.Logger) { l.InfoContext(relaxed, "relaxed") })
+	if _, ok := findField(record, "flags"); !ok {
+		t.Error("record with distant deadline not enriched")
+	}
+	record = readRecord(t, provider, func(l *slog.Logger) { l.Info("no deadline") })
+	if _, ok := findField(record, "flags"); !ok {
+		t.Error("record without deadline not enriched")
+	}
+
+	if got := provider.Stats().EnrichmentsSkipped; got != 1 {
+		t.Errorf("Stats().EnrichmentsSkipped = %d, want 1", got)
+	}
+}
+
+func TestWithDeadlineRemaining(t *testing.T) {
+	provider := New(10, WithDeadlineRemaining(), WithDeadlineMargin(time.Hour))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	record := readRecord(t, provider, func(l *slog.Logger) { l.InfoContext(ctx, "request") })
+	field, ok := findField(record, DeadlineRemainingKey)
+	if !ok {
+		t.Fatal("Expected remaining deadline field under deadline pressure")
+	}
+	if ms := field.IntValue(); ms <= 55_000 || ms > 60_000 {
+		t.Errorf("Expected about 60000ms remaining, got %d", ms)
+	}
+
+	expired, cancel2 := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel2()
+	record = readRecord(t, provider, func(l *slog.Logger) { l.InfoContext(expired, "late") })
+	if field, ok := findField(record, DeadlineRemainingKey); !ok || field.IntValue() > -1000 {
+		t.Errorf("Expected a negative remaining time after the deadline, got %v", field)
+	}
+
+	record = readRecord(t, provider, func(l *slog.Logger) { l.Info("no deadline") })
+	if _, ok := findField(record, DeadlineRemainingKey); ok {
+		t.Error("Expected no field without a context deadline")
+	}
+}
@@ -0,0 +1,258 @@
+// degrade.go: Staged degradation under overload
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"fmt"
+	"log/slog"
+	"sync/atomic"
+	"time"
+)
+
+// DegradationStage is a step of the WithDegradation ladder.
+type DegradationStage int32
+
+// Degradation stages, from the least to the most degraded.
+const (
+	StageNormal   DegradationStage = iota // Every record is admitted and enriched
+	StageReduced                          // DegradationConfig.Reduced applies
+	StageSurvival                         // DegradationConfig.Survival applies
+)
+
+// String returns "normal", "reduced" or "survival".
+func (s DegradationStage) String() string {
+	switch s {
+	case StageNormal:
+		return "normal"
+	case StageReduced:
+		return "reduced"
+	case StageSurvival:
+		return "survival"
+	default:
+		return fmt.Sprintf("stage(%d)", int32(s))
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler, so stages encode by name in
+// Stats.
+func (s DegradationStage) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// DegradationPolicy is the admission policy of a degraded stage.
+type DegradationPolicy struct {
+	// MinLevel drops records below it.
+	MinLevel slog.Level
+
+	// SampleEvery admits one in SampleEvery of the remaining records below
+	// slog.LevelError; values below 2 disable sampling.
+	SampleEvery int
+
+	// SkipEnrichment buffers records without running the WithEnricher
+	// enrichers.
+	SkipEnrichment bool
+}
+
+// DegradationConfig configures WithDegradation. Zero fields take the
+// documented defaults.
+type DegradationConfig struct {
+	// Interval is how often the stage is re-evaluated. Defaults to 100ms.
+	Interval time.Duration
+
+	// ReducedAt is the buffer occupancy, between 0 and 1, from which the
+	// Reduced stage applies. Defaults to 0.5.
+	ReducedAt float64
+
+	// SurvivalAt is the buffer occupancy from which the Survival stage
+	// applies. Defaults to 0.9.
+	SurvivalAt float64
+
+	// Hysteresis is how far below the threshold of its stage the occupancy
+	// must fall before the ladder steps down. Defaults to 0.1.
+	Hysteresis float64
+
+	// Reduced is the policy of the Reduced stage. Defaults to skipping
+	// enrichment and sampling one in 2 records below Error.
+	Reduced *DegradationPolicy
+
+	// Survival is the policy of the Survival stage. Defaults to skipping
+	// enrichment and dropping records below Warn.
+	Survival *DegradationPolicy
+}
+
+// DegradationEvent reports a WithDegradation stage transition on Errors.
+type DegradationEvent struct {
+	From      DegradationStage // Stage left
+	To        DegradationStage // Stage entered
+	Occupancy float64          // Buffer occupancy that triggered the transition
+	Dropped   uint64           // Records dropped during the last interval
+}
+
+// Error implements error.
+func (e *DegradationEvent) Error() string {
+	return fmt.Sprintf("slog provider: degradation stage %s -> %s (buffer %.0f%% full, %d dropped)",
+		e.From, e.To, e.Occupancy*100, e.Dropped)
+}
+
+// WithDegradation ties load shedding together into an explicit ladder of
+// stages, Normal → Reduced → Survival, so behavior under overload is
+// predictable instead of the outcome of independent knobs:
+//
+//	provider := slogprovider.New(10000, slogprovider.WithDegradation(slogprovider.DegradationConfig{}))
+//
+// A supervised goroutine re-evaluates the stage every cfg.Interval from the
+// buffer occupancy: the ladder climbs to the stage whose threshold is
+// reached and, when records were dropped during the interval, at least one
+// stage above the current one. It steps down one stage per interval once
+// the occupancy is cfg.Hysteresis below the threshold of the current stage
+// and nothing was dropped.
+//
+// Each transition is sent as a *DegradationEvent on Errors. Records rejected
+// by a stage policy are counted in Stats().DegradationDropped, records
+// buffered without enrichment in Stats().EnrichmentsSkipped, and
+// Stats().Degradation reports the current stage.
+func WithDegradation(cfg DegradationConfig) Option {
+	if cfg.Interval <= 0 {
+		cfg.Interval = 100 * time.Millisecond
+	}
+	if cfg.ReducedAt <= 0 || cfg.ReducedAt > 1 {
+		cfg.ReducedAt = 0.5
+	}
+	if cfg.SurvivalAt <= cfg.ReducedAt || cfg.SurvivalAt > 1 {
+		cfg.SurvivalAt = max(cfg.ReducedAt, 0.9)
+	}
+	if cfg.Hysteresis <= 0 || cfg.Hysteresis >= cfg.ReducedAt {
+		cfg.Hysteresis = min(0.1, cfg.ReducedAt/2)
+	}
+	if cfg.Reduced == nil {
+		cfg.Reduced = &DegradationPolicy{MinLevel: slog.LevelDebug, SampleEvery: 2, SkipEnrichment: true}
+	}
+	if cfg.Survival == nil {
+		cfg.Survival = &DegradationPolicy{MinLevel: slog.LevelWarn, SkipEnrichment: true}
+	}
+	return func(o *options) { o.degradation = &cfg }
+}
+
+// degradation tracks the WithDegradation stage.
+type degradation struct {
+	cfg         DegradationConfig
+	stage       atomic.Int32 // Current DegradationStage
+	counter     atomic.Uint64
+	lastDropped uint64 // Dropped counter at the previous evaluation, owned by run
+}
+
+// newDegradation creates the state for cfg, or nil if cfg is nil.
+func newDegradation(cfg *DegradationConfig) *degradation {
+	if cfg == nil {
+		return nil
+	}
+	return &degradation{cfg: *cfg}
+}
+
+// run re-evaluates the stage until the provider is closed.
+func (d *degradation) run(p *Provider) {
+	ticker := time.NewTicker(d.cfg.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-p.closed:
+			return
+		case <-ticker.C:
+			d.evaluate(p)
+		}
+	}
+}
+
+// evaluate moves the ladder according to the current occupancy and the drops
+// since the previous evaluation.
+func (d *degradation) evaluate(p *Provider) {
+	occupancy := 1.0
+	if capacity := p.queue.cap(); capacity > 0 {
+		occupancy = float64(p.queue.len()) / float64(capacity)
+	}
+	total := p.stats.dropped.Load()
+	dropped := total - d.lastDropped
+	if total < d.lastDropped {
+		dropped = total // Counters were reset
+	}
+	d.lastDropped = total
+
+	from := d.current()
+	to := from
+	switch {
+	case occupancy >= d.cfg.SurvivalAt:
+		to = StageSurvival
+	case occupancy >= d.cfg.ReducedAt:
+		to = max(from, StageReduced)
+	}
+	if dropped > 0 {
+		to = max(to, min(from+1, StageSurvival))
+	}
+	if to == from && dropped == 0 && occupancy < d.threshold(from)-d.cfg.Hysteresis {
+		to = from - 1
+	}
+	if to != from {
+		d.stage.Store(int32(to))
+		p.reportError(&DegradationEvent{From: from, To: to, Occupancy: occupancy, Dropped: dropped})
+	}
+}
+
+// threshold returns the occupancy from which stage applies.
+func (d *degradation) threshold(stage DegradationStage) float64 {
+	switch stage {
+	case StageSurvival:
+		return d.cfg.SurvivalAt
+	case StageReduced:
+		return d.cfg.ReducedAt
+	default:
+		return 0
+	}
+}
+
+// current returns the current stage, StageNormal when d is nil.
+func (d *degradation) current() DegradationStage {
+	if d == nil {
+		return StageNormal
+	}
+	return DegradationStage(d.stage.Load())
+}
+
+// policy returns the policy of the current stage, nil in StageNormal.
+func (d *degradation) policy() *DegradationPolicy {
+	switch d.current() {
+	case StageSurvival:
+		return d.cfg.Survival
+	case StageReduced:
+		return d.cfg.Reduced
+	default:
+		return nil
+	}
+}
+
+// admit applies the current stage policy to a record at level.
+func (d *degradation) admit(level slog.Level) bool {
+	s := d.policy()
+	if s == nil {
+		return true
+	}
+	if level < s.MinLevel {
+		return false
+	}
+	if level >= slog.LevelError || s.SampleEvery < 2 {
+		return true
+	}
+	return d.counter.Add(1)%uint64(s.SampleEvery) == 1 // #nosec G115 -- SampleEvery is positive
+}
+
+// skipEnrichment reports whether the current stage skips enrichment.
+func (d *degradation) skipEnrichment() bool {
+	if d == nil {
+		return false
+	}
+	s := d.policy()
+	return s != nil && s.SkipEnrichment
+}
@@ -0,0 +1,153 @@
+// degrade_test.go: Tests for staged degradation under overload
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agilira/iris"
+)
+
+// nextDegradation returns the next DegradationEvent sent on Errors.
+func nextDegradation(t *testing.T, provider *Provider) *DegradationEvent {
+	t.Helper()
+	deadline := time.After(2 * time.Second)
+	for {
+		select {
+		case err := <-provider.Errors():
+			var event *DegradationEvent
+			if errors.As(err, &event) {
+				return event
+			}
+		case <-deadline:
+			t.Fatal("Expected a degradation stage transition")
+			return nil
+		}
+	}
+}
+
+func TestWithDegradation_ClimbsAndRecovers(t *testing.T) {
+	provider := New(10, WithDegradation(DegradationConfig{Interval: time.Millisecond}))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+	logger := slog.New(provider)
+
+	for i := 0; i < 6; i++ {
+		logger.Error("load")
+	}
+	if event := nextDegradation(t, provider); event.From != StageNormal || event.To != StageReduced {
+		t.Fatalf("Expected normal -> reduced, got %v", event)
+	}
+	for i := 0; i < 4; i++ {
+		logger.Error("load")
+	}
+	if event := nextDegradation(t, provider); event.To != StageSurvival || provider.Stats().Degradation != StageSurvival {
+		t.Fatalf("Expected reduced -> survival, got %v", event)
+	}
+
+	logger.Info("shed")
+	if stats := provider.Stats(); stats.DegradationDropped != 1 {
+		t.Errorf("Expected info records to be shed in survival, got %d", stats.DegradationDropped)
+	}
+
+	readMessages(t, provider, 10)
+	if event := nextDegradation(t, provider); event.To != StageReduced {
+		t.Fatalf("Expected survival -> reduced, got %v", event)
+	}
+	if event := nextDegradation(t, provider); event.To != StageNormal {
+		t.Fatalf("Expected reduced -> normal, got %v", event)
+	}
+}
+
+func TestDegradation_DropsEscalate(t *testing.T) {
+	provider := New(100, WithDegradation(DegradationConfig{Interval: time.Hour}))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	provider.stats.dropped.Add(3)
+	provider.degrade.evaluate(provider)
+	if got := provider.degrade.current(); got != StageReduced {
+		t.Fatalf("Expected drops to escalate one stage, got %s", got)
+	}
+
+	// Without further drops, the stage is kept while the occupancy is
+	// within the hysteresis band, then steps down.
+	provider.degrade.evaluate(provider)
+	if got := provider.degrade.current(); got != StageNormal {
+		t.Errorf("Expected recovery once drops stop, got %s", got)
+	}
+
+	provider.ResetCounters()
+	provider.stats.dropped.Add(1)
+	provider.degrade.evaluate(provider)
+	if got := provider.degrade.current(); got != StageReduced {
+		t.Errorf("Expected drops after a counter reset to be noticed, got %s", got)
+	}
+}
+
+func TestDegradation_Hysteresis(t *testing.T) {
+	provider := New(10, WithDegradation(DegradationConfig{Interval: time.Hour}))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+	logger := slog.New(provider)
+
+	for i := 0; i < 5; i++ {
+		logger.Error("load")
+	}
+	provider.degrade.evaluate(provider)
+	readMessages(t, provider, 1) // 40%: within the band below 50%
+	provider.degrade.evaluate(provider)
+	if got := provider.degrade.current(); got != StageReduced {
+		t.Fatalf("Expected the stage to be kept within the hysteresis band, got %s", got)
+	}
+	readMessages(t, provider, 1) // 30%
+	provider.degrade.evaluate(provider)
+	if got := provider.degrade.current(); got != StageNormal {
+		t.Errorf("Expected recovery below the hysteresis band, got %s", got)
+	}
+}
+
+func TestDegradation_StagePolicies(t *testing.T) {
+	enriched := 0
+	provider := New(100,
+		WithDegradation(DegradationConfig{Interval: time.Hour}),
+		WithEnricher(func(context.Context, slog.Record) []iris.Field {
+			enriched++
+			return nil
+		}))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+	logger := slog.New(provider)
+
+	provider.degrade.stage.Store(int32(StageReduced))
+	for i := 0; i < 4; i++ {
+		logger.Info("sampled")
+	}
+	logger.Error("kept")
+	if stats := provider.Stats(); stats.DegradationDropped != 2 || stats.Buffered != 3 || stats.EnrichmentsSkipped != 3 {
+		t.Errorf("Unexpected reduced stage accounting %+v", stats)
+	}
+
+	provider.degrade.stage.Store(int32(StageNormal))
+	logger.Info("normal")
+	if enriched != 1 {
+		t.Errorf("Expected enrichment only in the normal stage, got %d", enriched)
+	}
+}
+
+func TestDegradationStage_Encoding(t *testing.T) {
+	data, err := json.Marshal(Stats{Degradation: StageSurvival})
+	if err != nil || !strings.Contains(string(data), `"degradation":"survival"`) {
+		t.Errorf("Marshal = %s, %v", data, err)
+	}
+	event := &DegradationEvent{From: StageNormal, To: StageReduced, Occupancy: 0.5, Dropped: 2}
+	if !strings.Contains(event.Error(), "normal -> reduced") {
+		t.Errorf("Error() = %q", event.Error())
+	}
+}
@@ -51,6 +51,19 @@
 //	    slogger.Info("User login", "user_id", "12345")
 //	}
 //
+// Setup performs the same wiring in one call, writing JSON to any io.Writer
+// and returning a shutdown function that flushes buffered records:
+//
+//	logger, shutdown, err := slogprovider.Setup(os.Stdout)
+//	if err != nil {
+//	    panic(err)
+//	}
+//	defer shutdown()
+//
+// InstallDefault goes one step further and installs the provider with
+// slog.SetDefault, so code logging through slog.Info and the log package is
+// accelerated too.
+//
 // # Advanced Integration
 //
 // For advanced features like Loki integration, install the writer module:
@@ -101,6 +114,46 @@
 //   - Automatic cleanup and resource management
 //   - Graceful handling of buffer overflow conditions
 //
+// # Configuration
+//
+// New takes the buffer size and any number of functional options, so features
+// are enabled without changing the constructor signature:
+//
+//	provider := slogprovider.New(1000,
+//	    slogprovider.WithMinLevel(slog.LevelInfo),
+//	    slogprovider.WithWeightedEviction(nil),
+//	    slogprovider.WithEnricher(enricher),
+//	)
+//
+// Options fall into a few families:
+//   - Admission: WithMinLevel, WithLevelOverrides, WithFilter, WithMessageFilter,
+//     WithSampler, WithThrottle, WithBackpressure, WithMemoryPressure,
+//     WithDegradation
+//   - Overflow and retention: WithRetryGrace, WithDropOldest,
+//     WithWeightedEviction, WithDropPolicy, WithRecordTTL, WithBurstCapture,
+//     WithClock
+//   - Level mapping: WithLevelMapper, WithLevelHook, WithLevelNames, WithSlogLevel
+//   - Enrichment: WithEnricher, WithHostIdentity, WithBuildInfo, WithGoroutineID,
+//     WithSequence, WithNamespace, WithStartupBanner, WithLatencyTracking,
+//     WithProvenance
+//   - Conversion: WithFieldConverter, WithCanonicalFallback, WithJournald,
+//     WithKeyOrdering, WithSchema, WithEncryption, WithCoercion,
+//     WithMessageRewrites, WithoutRecordTime, WithAddSource, WithReplaceAttr
+//     (see NewWithHandlerOptions)
+//
+// Options are applied in order and nil options are ignored. New accepts any
+// settings, while NewChecked reports invalid or conflicting ones as errors
+// wrapping sentinels such as ErrInvalidBufferSize. NewWithConfig accepts the
+// same settings as a serializable Config and reports invalid settings the
+// same way, NewFromEnv reads them from IRIS_SLOG_* environment
+// variables, and Builder composes them fluently:
+//
+//	provider, err := slogprovider.Builder().
+//	    BufferSize(1000).
+//	    DropOldest().
+//	    WithMinLevel(slog.LevelInfo).
+//	    Build()
+//
 // # Thread Safety
 //
 // All provider operations are thread-safe:
@@ -111,9 +164,10 @@
 //
 // # Buffer Management
 //
-// The provider uses a buffered channel for record storage:
+// The provider uses a bounded FIFO buffer for record storage:
 //   - Buffer size is configurable during construction
 //   - Full buffers result in record dropping (non-blocking behavior)
+//   - Buffered records can be inspected without consuming them with Range
 //   - Buffer size should be tuned based on logging volume and processing speed
 //   - Recommended buffer sizes: 100-1000 for typical applications, 1000+ for high-volume
 //
@@ -123,7 +177,10 @@
 //   - Handle() drops records on buffer full rather than blocking
 //   - Read() respects context cancellation for graceful shutdown
 //   - Close() is idempotent and safe to call multiple times
-//   - Conversion errors are handled gracefully with fallback behavior
+//   - After Close(), Handle() returns ErrClosed and Read() drains the buffer
+//     before reporting end of stream (nil, nil, or ErrClosed with WithErrClosed)
+//   - Conversion errors are handled gracefully with fallback behavior; a
+//     panicking conversion yields a degraded record with a conversion_panic field
 //
 // # Level Mapping
 //
@@ -136,7 +193,11 @@
 //
 // # Field Conversion
 //
-// Slog attributes are converted to Iris fields with type preservation:
+// Iris records carry no timestamp, so the slog record time, unless zero, is
+// kept as an iris.Time field under slog.TimeKey ("time"); WithoutRecordTime
+// turns this off. Groups are flattened into dotted keys, e.g. "req.id", and
+// empty attributes and groups are ignored, as testing/slogtest requires. Slog
+// attributes are converted to Iris fields with type preservation:
 //   - String values → iris.String
 //   - Integer values → iris.Int64
 //   - Float values → iris.Float64
@@ -149,6 +210,7 @@
 //
 // This package requires:
 //   - github.com/agilira/iris (core logging library)
+//   - github.com/agilira/argus (rules file parsing and hot reload)
 //   - Go's standard log/slog package (Go 1.21+)
 //
 // No additional dependencies are required for basic functionality.
@@ -0,0 +1,104 @@
+// drop_policy.go: Custom eviction strategies on overflow
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"log/slog"
+	"time"
+)
+
+// DropPolicy decides what to drop when a record arrives at a full buffer,
+// for domain-specific eviction beyond WithDropOldest and
+// WithWeightedEviction.
+type DropPolicy interface {
+	// Evict returns the index in view, oldest first, of the buffered record
+	// to evict in favor of candidate, or -1 to drop candidate instead.
+	//
+	// It is called with the buffer locked, blocking Handle and Read, so it
+	// must be fast and must not call back into the provider. view is only
+	// valid during the call.
+	Evict(candidate slog.Record, view *QueueView) int
+}
+
+// DropPolicyFunc adapts a function to DropPolicy.
+type DropPolicyFunc func(candidate slog.Record, view *QueueView) int
+
+// Evict implements DropPolicy.
+func (f DropPolicyFunc) Evict(candidate slog.Record, view *QueueView) int {
+	return f(candidate, view)
+}
+
+// QueueView gives a DropPolicy read access to the full buffer.
+type QueueView struct {
+	q   *queue
+	now time.Time
+}
+
+// Len returns the number of buffered records.
+func (v *QueueView) Len() int {
+	return v.q.n
+}
+
+// Cap returns the usable buffer capacity, which WithMemoryPressure may
+// lower below the capacity given to New.
+func (v *QueueView) Cap() int {
+	return int(v.q.limit.Load())
+}
+
+// Bytes returns the estimated size of the buffered records with
+// WithSizeAccounting, 0 otherwise.
+func (v *QueueView) Bytes() int {
+	return v.q.bytes()
+}
+
+// Record returns the i-th buffered record, oldest first. Attributes bound
+// with WithAttrs and enriched fields are not part of the record.
+func (v *QueueView) Record(i int) slog.Record {
+	return v.q.at(i).record
+}
+
+// Meta returns a summary of the i-th buffered record, oldest first. Size is
+// only set with WithSizeAccounting.
+func (v *QueueView) Meta(i int) RecordMeta {
+	return entryMeta(v.q.at(i), v.now, false)
+}
+
+// WithDropPolicy replaces the drop-newest overflow behavior with policy,
+// which is consulted whenever a record arrives at a full buffer. For
+// example, to never evict audit records:
+//
+//	keepAudit := slogprovider.DropPolicyFunc(func(candidate slog.Record, view *slogprovider.QueueView) int {
+//	    for i := 0; i < view.Len(); i++ {
+//	        if !isAudit(view.Record(i)) {
+//	            return i
+//	        }
+//	    }
+//	    return -1
+//	})
+//	provider := slogprovider.New(1000, slogprovider.WithDropPolicy(keepAudit))
+//
+// Evicted records are counted in Stats().Dropped and Stats().Evicted, and
+// dropped candidates in Stats().Dropped. NewChecked reports combining it
+// with WithDropOldest or WithWeightedEviction as ErrConflictingOptions.
+func WithDropPolicy(policy DropPolicy) Option {
+	return func(o *options) { o.dropPolicy = policy }
+}
+
+// pushWithPolicy buffers e in a full queue if the drop policy evicts a
+// buffered entry for it.
+func (p *Provider) pushWithPolicy(e entry) pushResult {
+	view := QueueView{now: time.Now()}
+	result := p.queue.pushChoosing(e, func(q *queue) int {
+		view.q = q
+		return p.opts.dropPolicy.Evict(e.record, &view)
+	})
+	if result == pushed {
+		p.stats.dropped.Add(1)
+		p.stats.evicted.Add(1)
+	}
+	return result
+}
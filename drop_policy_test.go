@@ -0,0 +1,106 @@
+// drop_policy_test.go: Tests for custom eviction strategies
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"errors"
+	"log/slog"
+	"testing"
+)
+
+// isAudit reports whether record carries audit=true.
+func isAudit(record slog.Record) bool {
+	audit := false
+	record.Attrs(func(attr slog.Attr) bool {
+		audit = attr.Key == "audit" && attr.Value.Equal(slog.BoolValue(true))
+		return !audit
+	})
+	return audit
+}
+
+// keepAudit evicts the oldest non-audit record.
+var keepAudit = DropPolicyFunc(func(candidate slog.Record, view *QueueView) int {
+	for i := 0; i < view.Len(); i++ {
+		if !isAudit(view.Record(i)) {
+			return i
+		}
+	}
+	return -1
+})
+
+func TestWithDropPolicy_KeepsAuditRecords(t *testing.T) {
+	provider := New(3, WithDropPolicy(keepAudit))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	logger := slog.New(provider)
+	logger.Info("audit 1", "audit", true)
+	logger.Info("noise 1")
+	logger.Info("audit 2", "audit", true)
+	logger.Info("noise 2")
+	logger.Info("audit 3", "audit", true)
+	logger.Info("noise 3")
+
+	msgs := readMessages(t, provider, 3)
+	if msgs[0] != "audit 1" || msgs[1] != "audit 2" || msgs[2] != "audit 3" {
+		t.Errorf("Expected the audit records to be kept, got %v", msgs)
+	}
+	if stats := provider.Stats(); stats.Dropped != 3 || stats.Evicted != 2 {
+		t.Errorf("Expected 2 evictions and 1 dropped candidate, got %+v", stats)
+	}
+	if err := provider.Verify(); err != nil {
+		t.Errorf("Verify failed: %v", err)
+	}
+}
+
+func TestWithDropPolicy_QueueView(t *testing.T) {
+	var n, capacity int
+	var oldest RecordMeta
+	provider := New(2, WithSequence(), WithDropPolicy(DropPolicyFunc(func(candidate slog.Record, view *QueueView) int {
+		n, capacity, oldest = view.Len(), view.Cap(), view.Meta(0)
+		if candidate.Message == "kept" {
+			return 5 // Out of range: the candidate is dropped
+		}
+		return 1
+	})))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	logger := slog.New(provider)
+	logger.Info("first")
+	logger.Info("second")
+	logger.Info("kept")
+	logger.Info("third")
+
+	if n != 2 || capacity != 2 || oldest.Message != "first" || oldest.Seq != 1 {
+		t.Errorf("Unexpected view: len %d, cap %d, oldest %+v", n, capacity, oldest)
+	}
+	if msgs := readMessages(t, provider, 2); msgs[0] != "first" || msgs[1] != "third" {
+		t.Errorf("Expected the second record to be evicted, got %v", msgs)
+	}
+}
+
+func TestWithDropPolicy_RecoversLockOnPanic(t *testing.T) {
+	provider := New(1, WithDropPolicy(DropPolicyFunc(func(slog.Record, *QueueView) int {
+		panic("policy bug")
+	})))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	logger := slog.New(provider)
+	logger.Info("first")
+	func() {
+		defer func() { _ = recover() }()
+		logger.Info("second")
+	}()
+	if msgs := readMessages(t, provider, 1); msgs[0] != "first" {
+		t.Errorf("Expected the buffer to remain usable, got %v", msgs)
+	}
+}
+
+func TestWithDropPolicy_Conflicts(t *testing.T) {
+	if _, err := NewChecked(10, WithDropPolicy(keepAudit), WithDropOldest()); !errors.Is(err, ErrConflictingOptions) {
+		t.Errorf("Expected %v, got %v", ErrConflictingOptions, err)
+	}
+}
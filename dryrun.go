@@ -0,0 +1,87 @@
+// dryrun.go: Dry-run validation and shadow operation
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// WithDryRun makes the provider validate records instead of delivering
+// them: Handle performs every Handle-time step, then converts the record,
+// applies schema validation and RecordMiddleware (redaction included) and
+// discards the result. Nothing is buffered and Read only reports end of
+// stream once the provider is closed.
+//
+// Dry runs let teams run the bridge in shadow alongside their existing
+// handler, see NewShadowHandler, to verify its behavior (schema violation
+// reports, conversion errors and panics, strict typing) and measure its
+// overhead before cutting over. Dry-run records are counted in
+// Stats().DryRun and their conversion time in Stats().DryRunNanos. Emit
+// hooks do not run.
+func WithDryRun() Option {
+	return func(o *options) { o.dryRun = true }
+}
+
+// dryRun converts e on the calling goroutine and discards the result.
+func (p *Provider) dryRun(e entry) {
+	start := time.Now()
+	record := p.safeConvert(e)
+	if p.opts.schema != nil {
+		p.opts.schema.validate(record)
+	}
+	_ = p.opts.applyMiddleware(record)
+	p.stats.dryRun.Add(1)
+	p.stats.dryRunNanos.Add(uint64(time.Since(start))) // #nosec G115 -- elapsed time is never negative
+}
+
+// ShadowHandler is a slog.Handler passing every record to a primary handler
+// and, independently, to a shadow handler whose errors are ignored.
+type ShadowHandler struct {
+	primary slog.Handler
+	shadow  slog.Handler
+}
+
+// NewShadowHandler returns a handler that logs through primary and mirrors
+// records to shadow, typically a provider configured with WithDryRun:
+//
+//	shadow := slogprovider.New(1000, slogprovider.WithDryRun(), slogprovider.WithSchema(schema))
+//	logger := slog.New(slogprovider.NewShadowHandler(existingHandler, shadow))
+//
+// The primary handler decides the outcome of Handle; shadow errors are
+// discarded so the shadow can never affect production logging.
+func NewShadowHandler(primary, shadow slog.Handler) *ShadowHandler {
+	return &ShadowHandler{primary: primary, shadow: shadow}
+}
+
+// Enabled implements slog.Handler, reporting whether either handler is
+// enabled for level.
+func (h *ShadowHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.primary.Enabled(ctx, level) || h.shadow.Enabled(ctx, level)
+}
+
+// Handle implements slog.Handler.
+func (h *ShadowHandler) Handle(ctx context.Context, record slog.Record) error {
+	if h.shadow.Enabled(ctx, record.Level) {
+		_ = h.shadow.Handle(ctx, record.Clone()) // Shadow errors never reach the application
+	}
+	if !h.primary.Enabled(ctx, record.Level) {
+		return nil
+	}
+	return h.primary.Handle(ctx, record)
+}
+
+// WithAttrs implements slog.Handler by deriving both handlers.
+func (h *ShadowHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &ShadowHandler{primary: h.primary.WithAttrs(attrs), shadow: h.shadow.WithAttrs(attrs)}
+}
+
+// WithGroup implements slog.Handler by deriving both handlers.
+func (h *ShadowHandler) WithGroup(name string) slog.Handler {
+	return &ShadowHandler{primary: h.primary.WithGroup(name), shadow: h.shadow.WithGroup(name)}
+}
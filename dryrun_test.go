@@ -0,0 +1,88 @@
+// dryrun_test.go: Tests for dry-run mode and the shadow handler
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agilira/iris"
+)
+
+func TestWithDryRun_ValidatesAndDiscards(t *testing.T) {
+	var violations []SchemaViolation
+	redacted := 0
+	provider := New(100, WithDryRun(),
+		WithSchema(Schema{
+			Fields:      map[string]FieldSpec{"user_id": {Kind: slog.KindInt64, Required: true}},
+			OnViolation: func(_ *iris.Record, v []SchemaViolation) { violations = append(violations, v...) },
+		}),
+		WithRecordMiddleware(func(record *iris.Record) *iris.Record {
+			redacted++
+			return record
+		}),
+	)
+	logger := slog.New(provider)
+
+	logger.Info("ok", "user_id", 1)
+	logger.Info("bad", "user_id", "x")
+
+	if len(violations) != 1 || violations[0].Key != "user_id" {
+		t.Errorf("Expected one schema violation, got %v", violations)
+	}
+	if redacted != 2 {
+		t.Errorf("Expected middleware to run for both records, ran %d times", redacted)
+	}
+	stats := provider.Stats()
+	if stats.DryRun != 2 || stats.DryRunNanos == 0 || stats.Handled != 0 || stats.Buffered != 0 {
+		t.Errorf("Unexpected stats %+v", stats)
+	}
+
+	_ = provider.Close()
+	if record, err := provider.Read(context.Background()); record != nil || err != nil {
+		t.Errorf("Expected end of stream, got %v (err=%v)", record, err)
+	}
+}
+
+// failingHandler is a handler whose Handle always fails.
+type failingHandler struct{ slog.Handler }
+
+func (failingHandler) Handle(context.Context, slog.Record) error { return errors.New("shadow failed") }
+
+func TestShadowHandler_MirrorsRecords(t *testing.T) {
+	var primary bytes.Buffer
+	shadow := New(100)
+	defer func() { _ = shadow.Close() }() // Ignore error in test cleanup
+
+	logger := slog.New(NewShadowHandler(slog.NewTextHandler(&primary, nil), shadow)).With("svc", "api").WithGroup("req")
+	logger.Info("served", "status", 200)
+
+	if out := primary.String(); !strings.Contains(out, "svc=api") || !strings.Contains(out, "req.status=200") {
+		t.Errorf("Unexpected primary output %q", out)
+	}
+	if got := shadow.Len(); got != 1 {
+		t.Errorf("Expected the record mirrored to the shadow, got %d", got)
+	}
+}
+
+func TestShadowHandler_IgnoresShadowErrors(t *testing.T) {
+	var primary bytes.Buffer
+	h := NewShadowHandler(slog.NewTextHandler(&primary, nil), failingHandler{slog.NewTextHandler(&bytes.Buffer{}, nil)})
+
+	record := slog.NewRecord(time.Now(), slog.LevelInfo, "msg", 0)
+	if err := h.Handle(context.Background(), record); err != nil {
+		t.Errorf("Expected shadow errors ignored, got %v", err)
+	}
+	if primary.Len() == 0 {
+		t.Error("Expected the primary handler to log")
+	}
+}
@@ -0,0 +1,265 @@
+// dump.go: JSON snapshot of metrics and configuration for support bundles
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+)
+
+// dumpDocument is the top-level DumpJSON document.
+type dumpDocument struct {
+	Stats  Stats          `json:"stats"`
+	Config configSnapshot `json:"config"`
+}
+
+// configSnapshot describes the provider configuration in DumpJSON.
+type configSnapshot struct {
+	BufferCapacity  int               `json:"buffer_capacity"`
+	MinLevel        *string           `json:"min_level"`
+	LevelOverrides  map[string]string `json:"level_overrides"`
+	RuntimeRules    bool              `json:"runtime_rules"`
+	Journald        bool              `json:"journald"`
+	Filters         int               `json:"filters"`
+	Sampler         string            `json:"sampler"`
+	Throttle        *throttleSnapshot `json:"throttle"`
+	StrictTyping    bool              `json:"strict_typing"`
+	FaultInjection  bool              `json:"fault_injection"`
+	Chaos           bool              `json:"chaos"`
+	FieldConverter  string            `json:"field_converter"`
+	Middleware      int               `json:"middleware"`
+	Enrichers       int               `json:"enrichers"`
+	Sequence        bool              `json:"sequence"`
+	RecordTime      bool              `json:"record_time"`
+	SchemaFields    int               `json:"schema_fields"`
+	HandleHooks     int               `json:"handle_hooks"`
+	EmitHooks       int               `json:"emit_hooks"`
+	ErrClosed       bool              `json:"err_closed"`
+	DeadlineMargin  string            `json:"deadline_margin"`
+	RetryGrace      string            `json:"retry_grace"`
+	WeightedEvict   bool              `json:"weighted_eviction"`
+	DropOldest      bool              `json:"drop_oldest"`
+	DropPolicy      string            `json:"drop_policy"`
+	BurstCapture    *string           `json:"burst_capture"`
+	Timeline        *string           `json:"timeline_resolution"`
+	Latency         *string           `json:"latency_threshold"`
+	Watchdog        *string           `json:"watchdog_timeout"`
+	TraceGrouping   *string           `json:"trace_grouping_window"`
+	KeyOrdering     *string           `json:"key_ordering"`
+	Resequencing    *string           `json:"resequence_window"`
+	Acknowledgement bool              `json:"acknowledgement"`
+	MetricsOnly     bool              `json:"metrics_only"`
+	DryRun          bool              `json:"dry_run"`
+	RecordTTL       map[string]string `json:"record_ttl,omitempty"`
+	ReadLevel       *string           `json:"read_level"`
+	ErrorBoost      *string           `json:"error_boost"`
+	Namespace       *string           `json:"namespace"`
+	EncryptedKeys   []string          `json:"encrypted_keys"`
+	MemoryLimit     *uint64           `json:"memory_limit"`
+	Backpressure    []string          `json:"backpressure_stages"`
+	Degradation     []string          `json:"degradation_stages"`
+	RecentRecords   int               `json:"recent_records"`
+	LevelMapper     map[string]string `json:"level_mapper,omitempty"`
+	LevelHook       bool              `json:"level_hook"`
+	WarmUp          bool              `json:"warm_up"`
+	AddSource       bool              `json:"add_source"`
+	ReplaceAttr     bool              `json:"replace_attr"`
+	Coercion        map[string]string `json:"coercion,omitempty"`
+	MessageRewrites int               `json:"message_rewrites"`
+	Provenance      map[string]string `json:"provenance,omitempty"`
+	StartupBanner   bool              `json:"startup_banner"`
+	RegionAlloc     bool              `json:"region_allocation"`
+}
+
+// throttleSnapshot describes a ThrottleConfig in DumpJSON.
+type throttleSnapshot struct {
+	Limit   int    `json:"limit"`
+	Window  string `json:"window"`
+	KeyAttr string `json:"key_attr"`
+}
+
+// DumpJSON writes a JSON document with the provider's counters (see Stats)
+// and a description of its configuration, suitable for support bundles and
+// incident tickets:
+//
+//	{"stats": {"handled": 1200, "dropped": 3, ...},
+//	 "config": {"buffer_capacity": 1000, "min_level": "INFO", ...}}
+//
+// The document layout is stable: keys are always present, using null, empty
+// or zero values for unconfigured features. Custom types (samplers, field
+// converters) are identified by their Go type name.
+func (p *Provider) DumpJSON(w io.Writer) error {
+	doc := dumpDocument{Stats: p.Stats(), Config: p.configSnapshot()}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return fmt.Errorf("failed to encode provider dump: %w", err)
+	}
+	return nil
+}
+
+// configSnapshot describes the current configuration.
+func (p *Provider) configSnapshot() configSnapshot {
+	o := &p.opts
+	c := configSnapshot{
+		BufferCapacity:  p.queue.cap(),
+		LevelOverrides:  make(map[string]string, len(o.levelOverrides)),
+		RuntimeRules:    p.rules.Load() != nil,
+		Journald:        o.journald != nil,
+		Filters:         len(o.filters),
+		StrictTyping:    o.strict != nil,
+		FaultInjection:  o.faults != nil,
+		Chaos:           o.chaos != nil,
+		RecentRecords:   o.recent,
+		LevelHook:       o.levelHook != nil,
+		WarmUp:          o.warmUp,
+		AddSource:       o.addSource,
+		ReplaceAttr:     o.replaceAttr != nil,
+		StartupBanner:   o.banner,
+		Acknowledgement: o.ack != nil,
+		MetricsOnly:     o.metricsOnly != nil,
+		DryRun:          o.dryRun,
+		RegionAlloc:     regionAllocation,
+		FieldConverter:  "default",
+		Middleware:      len(o.middleware),
+		Enrichers:       len(o.enrichers),
+		Sequence:        o.sequence,
+		RecordTime:      !o.omitTime,
+		HandleHooks:     len(o.handleHooks),
+		EmitHooks:       len(o.emitHooks),
+		ErrClosed:       o.errClosed,
+		DeadlineMargin:  o.deadlineMargin.String(),
+		RetryGrace:      o.retryGrace.String(),
+		WeightedEvict:   o.weight != nil,
+		DropOldest:      o.dropOldest,
+	}
+	if o.minLevel != nil {
+		level := o.levelName(o.minLevel.Level())
+		c.MinLevel = &level
+	}
+	if o.encrypt != nil {
+		c.EncryptedKeys = o.encrypt.cfg.Keys
+	}
+	if o.capture != nil {
+		path := o.capture.Path
+		c.BurstCapture = &path
+	}
+	if o.namespace != nil {
+		prefix := o.namespace.Prefix
+		c.Namespace = &prefix
+	}
+	if o.boost != nil {
+		duration := o.boost.Duration.String()
+		c.ErrorBoost = &duration
+	}
+	if o.readLevel != nil {
+		level := o.levelName(o.readLevel.Level())
+		c.ReadLevel = &level
+	}
+	for name, level := range o.levelOverrides {
+		c.LevelOverrides[name] = o.levelString(level)
+	}
+	if len(o.ttls) > 0 {
+		c.RecordTTL = make(map[string]string, len(o.ttls))
+		for _, t := range o.ttls {
+			c.RecordTTL[o.levelName(t.level)] = t.ttl.String()
+		}
+	}
+	if len(o.levelMapper) > 0 {
+		c.LevelMapper = make(map[string]string, len(o.levelMapper))
+		for level, target := range o.levelMapper {
+			c.LevelMapper[o.levelName(level)] = target.String()
+		}
+	}
+	if o.dropPolicy != nil {
+		c.DropPolicy = fmt.Sprintf("%T", o.dropPolicy)
+	}
+	if o.sampler != nil {
+		c.Sampler = fmt.Sprintf("%T", o.sampler)
+	}
+	if t := o.throttle; t != nil {
+		c.Throttle = &throttleSnapshot{Limit: t.Limit, Window: t.Window.String(), KeyAttr: t.KeyAttr}
+	}
+	if o.watchdog != nil {
+		timeout := o.watchdog.Timeout.String()
+		c.Watchdog = &timeout
+	}
+	if o.traceGrouping != nil {
+		window := o.traceGrouping.Window.String()
+		c.TraceGrouping = &window
+	}
+	if o.keyOrder != nil {
+		key := o.keyOrder.Key
+		c.KeyOrdering = &key
+	}
+	if o.resequence != nil {
+		window := o.resequence.Window.String()
+		c.Resequencing = &window
+	}
+	if b := o.backpressure; b != nil {
+		c.Backpressure = make([]string, len(b.Stages))
+		for i, s := range b.Stages {
+			c.Backpressure[i] = fmt.Sprintf(">=%g: min %s, 1/%d", s.Pressure, o.levelName(s.MinLevel), max(s.SampleEvery, 1))
+		}
+	}
+	if d := o.degradation; d != nil {
+		c.Degradation = []string{
+			fmt.Sprintf(">=%g: %s", d.ReducedAt, o.degradationPolicy(d.Reduced)),
+			fmt.Sprintf(">=%g: %s", d.SurvivalAt, o.degradationPolicy(d.Survival)),
+		}
+	}
+	if prov := o.provenance; prov != nil {
+		c.Provenance = map[string]string{"bound": prov.Bound, "context": prov.Context, "enriched": prov.Enriched}
+	}
+	if o.rewriter != nil {
+		c.MessageRewrites = len(o.rewriter.rewrites)
+	}
+	if len(o.coercion) > 0 {
+		c.Coercion = make(map[string]string, len(o.coercion))
+		for key, kind := range o.coercion {
+			c.Coercion[key] = kind.String()
+		}
+	}
+	if o.latency != nil {
+		threshold := o.latency.Threshold.String()
+		c.Latency = &threshold
+	}
+	if o.timeline != nil {
+		resolution := o.timeline.Resolution.String()
+		c.Timeline = &resolution
+	}
+	if p.memory != nil {
+		limit := p.memory.cfg.Limit
+		c.MemoryLimit = &limit
+	}
+	if o.converter != nil {
+		c.FieldConverter = fmt.Sprintf("%T", o.converter)
+	}
+	if o.schema != nil {
+		c.SchemaFields = len(o.schema.Fields)
+	}
+	return c
+}
+
+// levelString renders a possibly nil leveler.
+func (o *options) levelString(l slog.Leveler) string {
+	if l == nil {
+		return ""
+	}
+	return o.levelName(l.Level())
+}
+
+// degradationPolicy renders a DegradationPolicy.
+func (o *options) degradationPolicy(s *DegradationPolicy) string {
+	policy := fmt.Sprintf("min %s, 1/%d", o.levelName(s.MinLevel), max(s.SampleEvery, 1))
+	if s.SkipEnrichment {
+		policy += ", no enrichment"
+	}
+	return policy
+}
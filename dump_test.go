@@ -0,0 +1,86 @@
+// dump_test.go: Tests for the JSON metrics and configuration snapshot
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func TestDumpJSON_StableDocument(t *testing.T) {
+	provider := New(4,
+		WithMinLevel(slog.LevelInfo),
+		WithLevelOverrides(map[string]slog.Leveler{"db": slog.LevelWarn}),
+		WithSampler(NewTickSampler(time.Second, 10, 10)),
+		WithThrottle(ThrottleConfig{Limit: 5, Window: time.Minute}),
+		WithSequence(),
+	)
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	logger := slog.New(provider)
+	for i := 0; i < 6; i++ {
+		logger.Info("burst", "i", i)
+	}
+
+	var buf bytes.Buffer
+	if err := provider.DumpJSON(&buf); err != nil {
+		t.Fatalf("DumpJSON() error = %v", err)
+	}
+	var doc struct {
+		Stats  map[string]any `json:"stats"`
+		Config map[string]any `json:"config"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, buf.String())
+	}
+
+	if doc.Stats["handled"] != 5.0 || doc.Stats["buffered"] != 4.0 || doc.Stats["dropped"] != 1.0 {
+		t.Errorf("stats = %v", doc.Stats)
+	}
+	for key, want := range map[string]any{
+		"buffer_capacity": 4.0,
+		"min_level":       "INFO",
+		"sampler":         "*slogprovider.TickSampler",
+		"sequence":        true,
+		"field_converter": "default",
+		"journald":        false,
+	} {
+		if got := doc.Config[key]; got != want {
+			t.Errorf("config[%q] = %v, want %v", key, got, want)
+		}
+	}
+	if overrides, _ := doc.Config["level_overrides"].(map[string]any); overrides["db"] != "WARN" {
+		t.Errorf("level_overrides = %v", doc.Config["level_overrides"])
+	}
+	if throttle, _ := doc.Config["throttle"].(map[string]any); throttle["window"] != "1m0s" {
+		t.Errorf("throttle = %v", doc.Config["throttle"])
+	}
+}
+
+func TestDumpJSON_UnconfiguredKeysPresent(t *testing.T) {
+	provider := New(1)
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	var buf bytes.Buffer
+	if err := provider.DumpJSON(&buf); err != nil {
+		t.Fatalf("DumpJSON() error = %v", err)
+	}
+	var doc struct {
+		Config map[string]any `json:"config"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []string{"min_level", "throttle", "sampler", "schema_fields"} {
+		if _, ok := doc.Config[key]; !ok {
+			t.Errorf("config key %q missing", key)
+		}
+	}
+}
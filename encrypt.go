@@ -0,0 +1,309 @@
+// encrypt.go: Envelope encryption of selected attribute values
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// KMS wraps and unwraps data keys with a master key held by a key management
+// service, e.g. AWS KMS, Google Cloud KMS or HashiCorp Vault transit.
+type KMS interface {
+	// WrapKey encrypts a data key with the master key.
+	WrapKey(ctx context.Context, dataKey []byte) ([]byte, error)
+
+	// UnwrapKey decrypts a data key wrapped by WrapKey.
+	UnwrapKey(ctx context.Context, wrapped []byte) ([]byte, error)
+}
+
+// EncryptionConfig configures the attribute encryption enabled by
+// WithEncryption.
+type EncryptionConfig struct {
+	// Keys are the attributes to encrypt. Attributes nested in groups are
+	// named by their dotted path, e.g. "user.ssn".
+	Keys []string
+
+	// KMS wraps the data keys. It is required.
+	KMS KMS
+
+	// RotateAfter is how long a data key is used before a new one is
+	// generated and wrapped; 1h when zero.
+	RotateAfter time.Duration
+}
+
+// EncryptionError reports an attribute that could not be encrypted. The
+// record carrying it is dropped rather than buffered in plaintext.
+type EncryptionError struct {
+	Key string // Attribute key
+	Err error  // Underlying failure
+}
+
+// Error implements error.
+func (e *EncryptionError) Error() string {
+	return fmt.Sprintf("slog provider: failed to encrypt attribute %q: %v", e.Key, e.Err)
+}
+
+// Unwrap returns the underlying failure.
+func (e *EncryptionError) Unwrap() error {
+	return e.Err
+}
+
+// WithEncryption replaces the values of the selected attributes with
+// envelope-encrypted ciphertext, so that fields such as personal data stay
+// recoverable by an authorized party instead of being redacted:
+//
+//	provider := slogprovider.New(1000, slogprovider.WithEncryption(slogprovider.EncryptionConfig{
+//	    Keys: []string{"email", "user.ssn"},
+//	    KMS:  vault,
+//	}))
+//
+// Values are encrypted in Handle, before the record is buffered, with
+// AES-256-GCM under a random data key that is wrapped by the KMS and rotated
+// every RotateAfter; while a new key is wrapped, other records keep using the
+// expired one, so a slow KMS only delays the record that triggered the
+// rotation. The attribute becomes a base64 string carrying the wrapped key,
+// which DecryptValue turns back into the value's String form. Encryption
+// binds the ciphertext to the attribute key, so values cannot be moved
+// between keys unnoticed. LogValuer values are resolved first, so the
+// members of a group they return can be selected by their dotted path.
+//
+// When encryption fails the record is dropped, Handle returns an
+// *EncryptionError, which is also reported on Errors, and the failure is
+// counted in Stats().EncryptionErrors. The keys are copied; WithEncryption
+// panics if cfg.KMS is nil.
+func WithEncryption(cfg EncryptionConfig) Option {
+	if cfg.KMS == nil {
+		panic("slogprovider: WithEncryption requires a KMS")
+	}
+	if cfg.RotateAfter <= 0 {
+		cfg.RotateAfter = time.Hour
+	}
+	cfg.Keys = append([]string(nil), cfg.Keys...)
+	enc := &encrypter{cfg: cfg, keys: make(map[string]bool, len(cfg.Keys))}
+	for _, key := range cfg.Keys {
+		enc.keys[key] = true
+	}
+	return func(o *options) { o.encrypt = enc }
+}
+
+// envelopeVersion identifies the layout of encrypted values:
+// version, wrapped key length (uint16), wrapped key, nonce, ciphertext.
+const envelopeVersion = 1
+
+// encrypter encrypts the configured attributes with rotating data keys.
+type encrypter struct {
+	cfg  EncryptionConfig
+	keys map[string]bool
+
+	mu       sync.Mutex
+	key      *dataKey // Current data key, nil until first use
+	rotating bool     // A replacement for an expired key is being wrapped
+}
+
+// dataKey is a data key with its wrapped form.
+type dataKey struct {
+	aead    cipher.AEAD
+	wrapped []byte
+	expires time.Time
+}
+
+// current returns the data key to use, generating and wrapping a new one
+// when the current key is missing or expired. The KMS is called without
+// holding the lock: while one goroutine rotates an expired key, the others
+// keep using it.
+func (c *encrypter) current(ctx context.Context) (*dataKey, error) {
+	now := time.Now()
+	c.mu.Lock()
+	if c.key != nil && (now.Before(c.key.expires) || c.rotating) {
+		defer c.mu.Unlock()
+		return c.key, nil
+	}
+	c.rotating = true
+	c.mu.Unlock()
+
+	key, err := c.newDataKey(ctx, now)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.rotating = false
+	if err != nil {
+		return nil, err
+	}
+	c.key = key
+	return key, nil
+}
+
+// newDataKey generates a data key valid from now and wraps it with the KMS.
+func (c *encrypter) newDataKey(ctx context.Context, now time.Time) (*dataKey, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return nil, fmt.Errorf("failed to generate data key: %w", err)
+	}
+	wrapped, err := c.cfg.KMS.WrapKey(ctx, raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to wrap data key: %w", err)
+	}
+	if len(wrapped) > 0xFFFF {
+		return nil, fmt.Errorf("wrapped data key too long: %d bytes", len(wrapped))
+	}
+	aead, err := newAEAD(raw)
+	if err != nil {
+		return nil, err
+	}
+	return &dataKey{aead: aead, wrapped: wrapped, expires: now.Add(c.cfg.RotateAfter)}, nil
+}
+
+// seal encrypts plaintext for the attribute key into a base64 envelope.
+func (c *encrypter) seal(ctx context.Context, key, plaintext string) (string, error) {
+	dk, err := c.current(ctx)
+	if err != nil {
+		return "", err
+	}
+	nonceSize := dk.aead.NonceSize()
+	buf := make([]byte, 3, 3+len(dk.wrapped)+nonceSize+len(plaintext)+dk.aead.Overhead())
+	buf[0] = envelopeVersion
+	binary.BigEndian.PutUint16(buf[1:3], uint16(len(dk.wrapped))) // #nosec G115 -- length checked in current
+	buf = append(buf, dk.wrapped...)
+	nonce := buf[len(buf) : len(buf)+nonceSize]
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	buf = buf[:len(buf)+nonceSize]
+	buf = dk.aead.Seal(buf, nonce, []byte(plaintext), []byte(key))
+	return base64.StdEncoding.EncodeToString(buf), nil
+}
+
+// DecryptValue decrypts a value encrypted by WithEncryption for the
+// attribute key, unwrapping its data key with kms.
+func DecryptValue(ctx context.Context, kms KMS, key, value string) (string, error) {
+	buf, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return "", fmt.Errorf("invalid encrypted value: %w", err)
+	}
+	if len(buf) < 3 || buf[0] != envelopeVersion {
+		return "", errors.New("invalid encrypted value: unknown envelope version")
+	}
+	n := int(binary.BigEndian.Uint16(buf[1:3]))
+	if len(buf) < 3+n {
+		return "", errors.New("invalid encrypted value: truncated envelope")
+	}
+	raw, err := kms.UnwrapKey(ctx, buf[3:3+n])
+	if err != nil {
+		return "", fmt.Errorf("failed to unwrap data key: %w", err)
+	}
+	aead, err := newAEAD(raw)
+	if err != nil {
+		return "", err
+	}
+	rest := buf[3+n:]
+	if len(rest) < aead.NonceSize() {
+		return "", errors.New("invalid encrypted value: truncated envelope")
+	}
+	plaintext, err := aead.Open(nil, rest[:aead.NonceSize()], rest[aead.NonceSize():], []byte(key))
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt value: %w", err)
+	}
+	return string(plaintext), nil
+}
+
+// newAEAD returns the AES-GCM cipher for a raw data key.
+func newAEAD(raw []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid data key: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+// encryptRecord returns record, handled for the logger name, with the
+// configured attributes encrypted. The record is copied only when it carries
+// such an attribute.
+func (p *Provider) encryptRecord(ctx context.Context, record slog.Record, name string) (slog.Record, error) {
+	c := p.opts.encrypt
+	matched := false
+	record.Attrs(func(attr slog.Attr) bool {
+		matched = c.matches(name, attr)
+		return !matched
+	})
+	if !matched {
+		return record, nil
+	}
+
+	out := slog.NewRecord(record.Time, record.Level, record.Message, record.PC)
+	var err error
+	record.Attrs(func(attr slog.Attr) bool {
+		attr, err = c.encryptAttr(ctx, name, attr)
+		out.AddAttrs(attr)
+		return err == nil
+	})
+	if err != nil {
+		p.stats.encryptionErrors.Add(1)
+		p.reportError(err)
+		return record, err
+	}
+	return out, nil
+}
+
+// matches reports whether attr, under the group path prefix, is or contains
+// a configured attribute.
+func (c *encrypter) matches(prefix string, attr slog.Attr) bool {
+	path := joinPath(prefix, attr.Key)
+	if c.keys[path] {
+		return true
+	}
+	if value := attr.Value.Resolve(); value.Kind() == slog.KindGroup {
+		for _, nested := range value.Group() {
+			if c.matches(path, nested) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// encryptAttr encrypts attr, or the configured attributes nested in it.
+func (c *encrypter) encryptAttr(ctx context.Context, prefix string, attr slog.Attr) (slog.Attr, error) {
+	path := joinPath(prefix, attr.Key)
+	attr.Value = attr.Value.Resolve() // A LogValuer may return a group
+	if c.keys[path] {
+		sealed, err := c.seal(ctx, path, attr.Value.String())
+		if err != nil {
+			return attr, &EncryptionError{Key: path, Err: err}
+		}
+		return slog.String(attr.Key, sealed), nil
+	}
+	if attr.Value.Kind() != slog.KindGroup || !c.matches(prefix, attr) {
+		return attr, nil
+	}
+	group := attr.Value.Group()
+	nested := make([]slog.Attr, len(group))
+	for i, a := range group {
+		var err error
+		if nested[i], err = c.encryptAttr(ctx, path, a); err != nil {
+			return attr, err
+		}
+	}
+	return slog.Attr{Key: attr.Key, Value: slog.GroupValue(nested...)}, nil
+}
+
+// joinPath appends key to the dotted group path prefix.
+func joinPath(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
@@ -0,0 +1,217 @@
+// encrypt_test.go: Tests for attribute envelope encryption
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// xorKMS is a test KMS wrapping keys with a fixed XOR mask.
+type xorKMS struct {
+	wraps atomic.Int32
+	fail  error
+}
+
+func (k *xorKMS) WrapKey(_ context.Context, dataKey []byte) ([]byte, error) {
+	if k.fail != nil {
+		return nil, k.fail
+	}
+	k.wraps.Add(1)
+	return k.xor(dataKey), nil
+}
+
+func (k *xorKMS) UnwrapKey(_ context.Context, wrapped []byte) ([]byte, error) {
+	return k.xor(wrapped), nil
+}
+
+func (k *xorKMS) xor(b []byte) []byte {
+	out := make([]byte, len(b))
+	for i := range b {
+		out[i] = b[i] ^ 0x5a
+	}
+	return out
+}
+
+func TestWithEncryption_EncryptsSelectedKeys(t *testing.T) {
+	kms := &xorKMS{}
+	provider := New(100, WithEncryption(EncryptionConfig{Keys: []string{"email", "user.ssn"}, KMS: kms}))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	record := readRecord(t, provider, func(logger *slog.Logger) {
+		logger.Info("signup",
+			"email", "alice@example.com",
+			"plan", "pro",
+			slog.Group("user", "ssn", "123-45-6789", "id", 42),
+		)
+	})
+
+	email, ok := findField(record, "email")
+	if !ok || strings.Contains(email.StringValue(), "alice") {
+		t.Fatalf("Expected an encrypted email field, got %v", email)
+	}
+	got, err := DecryptValue(context.Background(), kms, "email", email.StringValue())
+	if err != nil || got != "alice@example.com" {
+		t.Errorf("DecryptValue() = %q, %v", got, err)
+	}
+	if _, err := DecryptValue(context.Background(), kms, "plan", email.StringValue()); err == nil {
+		t.Error("Expected decryption under another key to fail")
+	}
+	if plan, _ := findField(record, "plan"); plan.StringValue() != "pro" {
+		t.Errorf("Unselected attribute changed: %v", plan)
+	}
+
+	for i := 0; i < record.FieldCount(); i++ {
+		if f := record.GetField(i); strings.Contains(f.StringValue(), "123-45") {
+			t.Errorf("Nested attribute was not encrypted: %v", f)
+		}
+	}
+	if n := kms.wraps.Load(); n != 1 {
+		t.Errorf("Expected the data key to be wrapped once, got %d", n)
+	}
+}
+
+func TestWithEncryption_RotatesDataKeys(t *testing.T) {
+	kms := &xorKMS{}
+	provider := New(100, WithEncryption(EncryptionConfig{Keys: []string{"token"}, KMS: kms, RotateAfter: time.Nanosecond}))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	logger := slog.New(provider)
+	logger.Info("first", "token", "a")
+	time.Sleep(time.Millisecond)
+	logger.Info("second", "token", "b")
+
+	if n := kms.wraps.Load(); n != 2 {
+		t.Errorf("Expected 2 data keys, got %d", n)
+	}
+}
+
+// blockingKMS is an xorKMS whose second WrapKey call blocks until release
+// is closed.
+type blockingKMS struct {
+	xorKMS
+	calls   atomic.Int32
+	release chan struct{}
+}
+
+func (k *blockingKMS) WrapKey(ctx context.Context, dataKey []byte) ([]byte, error) {
+	if k.calls.Add(1) == 2 {
+		<-k.release
+	}
+	return k.xorKMS.WrapKey(ctx, dataKey)
+}
+
+func TestWithEncryption_RotationDoesNotBlockHandle(t *testing.T) {
+	kms := &blockingKMS{release: make(chan struct{})}
+	provider := New(100, WithEncryption(EncryptionConfig{Keys: []string{"token"}, KMS: kms, RotateAfter: time.Nanosecond}))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+	logger := slog.New(provider)
+
+	logger.Info("first", "token", "a")
+	time.Sleep(time.Millisecond)
+	rotated := make(chan struct{})
+	go func() {
+		defer close(rotated)
+		logger.Info("rotating", "token", "b")
+	}()
+	for kms.calls.Load() < 2 {
+		time.Sleep(time.Millisecond)
+	}
+
+	handled := make(chan struct{})
+	go func() {
+		defer close(handled)
+		logger.Info("during rotation", "token", "c")
+	}()
+	select {
+	case <-handled:
+	case <-time.After(time.Second):
+		t.Error("Handle blocked while the KMS wrapped a new data key")
+	}
+	close(kms.release)
+	<-rotated
+	<-handled
+
+	if n := provider.Len(); n != 3 {
+		t.Errorf("Expected 3 buffered records, got %d", n)
+	}
+}
+
+// ssnHolder logs as a group holding a social security number.
+type ssnHolder struct{ ssn string }
+
+func (h ssnHolder) LogValue() slog.Value {
+	return slog.GroupValue(slog.String("ssn", h.ssn))
+}
+
+func TestWithEncryption_ResolvesLogValuers(t *testing.T) {
+	kms := &xorKMS{}
+	provider := New(100, WithEncryption(EncryptionConfig{Keys: []string{"user.ssn"}, KMS: kms}))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	record := readRecord(t, provider, func(logger *slog.Logger) {
+		logger.Info("m", "user", ssnHolder{"123-45-6789"})
+	})
+
+	ssn, ok := findField(record, "user.ssn")
+	if !ok || strings.Contains(ssn.StringValue(), "123-45") {
+		t.Fatalf("Expected an encrypted user.ssn field, got %v", ssn)
+	}
+	got, err := DecryptValue(context.Background(), kms, "user.ssn", ssn.StringValue())
+	if err != nil || got != "123-45-6789" {
+		t.Errorf("DecryptValue() = %q, %v", got, err)
+	}
+}
+
+func TestWithEncryption_FailureDropsRecord(t *testing.T) {
+	kms := &xorKMS{fail: errors.New("kms unavailable")}
+	provider := New(100, WithEncryption(EncryptionConfig{Keys: []string{"token"}, KMS: kms}))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	record := slog.NewRecord(time.Now(), slog.LevelInfo, "login", 0)
+	record.AddAttrs(slog.String("token", "secret"))
+	err := provider.Handle(context.Background(), record)
+
+	var encErr *EncryptionError
+	if !errors.As(err, &encErr) || encErr.Key != "token" {
+		t.Fatalf("Expected an *EncryptionError for token, got %v", err)
+	}
+	if n := provider.Len(); n != 0 {
+		t.Errorf("Expected the record to be dropped, got %d buffered", n)
+	}
+	if stats := provider.Stats(); stats.EncryptionErrors != 1 {
+		t.Errorf("Expected 1 encryption error, got %d", stats.EncryptionErrors)
+	}
+	select {
+	case reported := <-provider.Errors():
+		if !errors.As(reported, &encErr) {
+			t.Errorf("Unexpected reported error %v", reported)
+		}
+	default:
+		t.Error("Expected the failure to be reported on Errors")
+	}
+
+	// Records without selected attributes do not need the KMS.
+	slog.New(provider).Info("plain", "user", "alice")
+	if n := provider.Len(); n != 1 {
+		t.Errorf("Expected the plain record to be buffered, got %d", n)
+	}
+}
+
+func TestWithEncryption_RequiresKMS(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected WithEncryption to panic without a KMS")
+		}
+	}()
+	WithEncryption(EncryptionConfig{Keys: []string{"token"}})
+}
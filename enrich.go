@@ -0,0 +1,79 @@
+// enrich.go: Handle-time computed-field enrichment
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"context"
+	"log/slog"
+	"sync/atomic"
+	"time"
+
+	"github.com/agilira/iris"
+)
+
+// Enricher computes derived fields for a record, such as the environment,
+// the build SHA or the state of feature flags.
+//
+// Enrichers run in Handle, once per admitted record, so they can read the
+// caller's context. Records dropped by level, filters, samplers or throttling
+// are not enriched. The returned fields are appended after the record
+// attributes during conversion; fields beyond the Iris field limit are
+// dropped. Enrichers must be safe for concurrent use.
+type Enricher func(ctx context.Context, record slog.Record) []iris.Field
+
+// WithEnricher appends enrichers evaluated for every admitted record, in the
+// order given across all WithEnricher options; nil entries are ignored.
+//
+//	env := func(context.Context, slog.Record) []iris.Field {
+//	    return []iris.Field{iris.String("env", os.Getenv("APP_ENV"))}
+//	}
+//	provider := slogprovider.New(1000, slogprovider.WithEnricher(env))
+func WithEnricher(enrichers ...Enricher) Option {
+	return func(o *options) {
+		for _, e := range enrichers {
+			if e != nil {
+				o.enrichers = append(o.enrichers, e)
+			}
+		}
+	}
+}
+
+// enrich evaluates the enrichers for record.
+func (o *options) enrich(ctx context.Context, record slog.Record) []iris.Field {
+	var fields []iris.Field
+	for _, e := range o.enrichers {
+		fields = append(fields, e(ctx, record)...)
+	}
+	return fields
+}
+
+// cachedFields is a snapshot of enricher output with its expiry.
+type cachedFields struct {
+	fields  []iris.Field
+	expires time.Time
+}
+
+// CachedEnricher wraps e so that its result is computed at most once per ttl
+// and shared by all records in between. It suits fields that are expensive
+// to compute but change rarely, such as feature flag snapshots. A
+// non-positive ttl caches the first result forever.
+//
+// The cached result ignores the context and record of later calls, so e
+// must not depend on them. Concurrent refreshes may evaluate e more than
+// once; the last result wins.
+func CachedEnricher(e Enricher, ttl time.Duration) Enricher {
+	var cache atomic.Pointer[cachedFields]
+	return func(ctx context.Context, record slog.Record) []iris.Field {
+		now := time.Now()
+		if c := cache.Load(); c != nil && (ttl <= 0 || now.Before(c.expires)) {
+			return c.fields
+		}
+		fields := e(ctx, record)
+		cache.Store(&cachedFields{fields: fields, expires: now.Add(ttl)})
+		return fields
+	}
+}
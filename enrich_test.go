@@ -0,0 +1,113 @@
+// enrich_test.go: Tests for Handle-time computed-field enrichment
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/agilira/iris"
+)
+
+type flagKey struct{}
+
+func TestWithEnricher_AppendsComputedFields(t *testing.T) {
+	env := func(context.Context, slog.Record) []iris.Field {
+		return []iris.Field{iris.String("env", "prod")}
+	}
+	flags := func(ctx context.Context, _ slog.Record) []iris.Field {
+		if v, ok := ctx.Value(flagKey{}).(bool); ok {
+			return []iris.Field{iris.Bool("flag.new_checkout", v)}
+		}
+		return nil
+	}
+	provider := New(10, WithEnricher(env, nil), WithEnricher(flags))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	ctx := context.WithValue(context.Background(), flagKey{}, true)
+	record := readRecord(t, provider, func(l *slog.Logger) {
+		l.InfoContext(ctx, "checkout", "user", "alice")
+	})
+
+	if got := fieldKeys(record); len(got) != 3 {
+		t.Fatalf("Fields = %v, want 3", got)
+	}
+	if key := fieldKeys(record)[0]; key != "user" {
+		t.Errorf("first field = %q, want record attributes before enrichment", key)
+	}
+	if f, ok := findField(record, "env"); !ok || f.StringValue() != "prod" {
+		t.Errorf("env field = %v, %v; want prod", f.StringValue(), ok)
+	}
+	if f, ok := findField(record, "flag.new_checkout"); !ok || !f.BoolValue() {
+		t.Error("flag field missing or false")
+	}
+}
+
+func TestWithEnricher_SkipsDroppedRecords(t *testing.T) {
+	calls := 0
+	count := func(context.Context, slog.Record) []iris.Field { calls++; return nil }
+	provider := New(10, WithMinLevel(slog.LevelInfo), WithEnricher(count))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	logger := slog.New(provider)
+	logger.Debug("filtered")
+	logger.Info("kept")
+
+	if calls != 1 {
+		t.Errorf("enricher calls = %d, want 1", calls)
+	}
+}
+
+func TestCachedEnricher_ReusesResultWithinTTL(t *testing.T) {
+	calls := 0
+	sha := func(context.Context, slog.Record) []iris.Field {
+		calls++
+		return []iris.Field{iris.Int("build", calls)}
+	}
+	cached := CachedEnricher(sha, 20*time.Millisecond)
+
+	ctx := context.Background()
+	first := cached(ctx, slog.Record{})
+	second := cached(ctx, slog.Record{})
+	if calls != 1 || first[0].IntValue() != second[0].IntValue() {
+		t.Fatalf("calls = %d, want cached result within ttl", calls)
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	if third := cached(ctx, slog.Record{}); calls != 2 || third[0].IntValue() != 2 {
+		t.Errorf("calls = %d, want refresh after ttl", calls)
+	}
+}
+
+func TestCachedEnricher_NonPositiveTTLCachesForever(t *testing.T) {
+	calls := 0
+	cached := CachedEnricher(func(context.Context, slog.Record) []iris.Field {
+		calls++
+		return nil
+	}, 0)
+	for i := 0; i < 3; i++ {
+		cached(context.Background(), slog.Record{})
+	}
+	if calls != 1 {
+		t.Errorf("calls = %d, want 1", calls)
+	}
+}
+
+func TestWithEnricher_FollowsJournaldFieldNames(t *testing.T) {
+	region := func(context.Context, slog.Record) []iris.Field {
+		return []iris.Field{iris.String("cloud.region", "eu-west-1")}
+	}
+	provider := New(10, WithJournald(JournaldConfig{UppercaseFields: true}), WithEnricher(region))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	record := readRecord(t, provider, func(l *slog.Logger) { l.Info("started") })
+	if _, ok := findField(record, "CLOUD_REGION"); !ok {
+		t.Error("enriched field not renamed to CLOUD_REGION")
+	}
+}
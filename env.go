@@ -0,0 +1,150 @@
+// env.go: Environment-driven provider configuration
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultBufferSize is the buffer size used by NewFromEnv when
+// IRIS_SLOG_BUFFER_SIZE is not set.
+const DefaultBufferSize = 1000
+
+// EnvPrefix prefixes the environment variables read by ConfigFromEnv.
+const EnvPrefix = "IRIS_SLOG_"
+
+// NewFromEnv creates a provider configured by the environment, see
+// ConfigFromEnv, so operators can tune it per deployment without
+// recompiling. opts are applied after the environment settings. It returns
+// an error naming every malformed variable or invalid setting instead of
+// creating a provider.
+func NewFromEnv(opts ...Option) (*Provider, error) {
+	cfg, err := ConfigFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	cfg.Options = append(cfg.Options, opts...)
+	return NewWithConfig(cfg)
+}
+
+// ConfigFromEnv returns the Config described by the environment. Unset
+// variables keep their defaults: a buffer of DefaultBufferSize records and
+// the zero value of every other setting.
+//
+//	IRIS_SLOG_BUFFER_SIZE      buffer size, e.g. 5000
+//	IRIS_SLOG_DROP_POLICY      drop_newest, drop_oldest or evict_weighted
+//	IRIS_SLOG_RETRY_GRACE      Go duration, e.g. 2ms
+//	IRIS_SLOG_MIN_LEVEL        slog level name, e.g. INFO or DEBUG+2
+//	IRIS_SLOG_LEVEL_OVERRIDES  logger=level pairs, e.g. db=WARN,http.client=ERROR
+//	IRIS_SLOG_READ_LEVEL       slog level name
+//	IRIS_SLOG_RECORD_TTL       level=duration pairs, e.g. DEBUG=30s,INFO=5m
+//	IRIS_SLOG_ERR_CLOSED       boolean, e.g. true or 1
+//	IRIS_SLOG_SEQUENCE         boolean
+//	IRIS_SLOG_SLOG_LEVEL       boolean
+//	IRIS_SLOG_OMIT_TIME        boolean
+//
+// Malformed values are reported together in the returned error; the Config
+// is not validated, see Config.Validate.
+func ConfigFromEnv() (Config, error) {
+	cfg := Config{BufferSize: DefaultBufferSize}
+	var errs []error
+	env := func(name string, parse func(string) error) {
+		value, ok := os.LookupEnv(EnvPrefix + name)
+		if !ok || strings.TrimSpace(value) == "" {
+			return
+		}
+		if err := parse(strings.TrimSpace(value)); err != nil {
+			errs = append(errs, fmt.Errorf("%s%s=%q: %w", EnvPrefix, name, value, err))
+		}
+	}
+
+	env("BUFFER_SIZE", func(v string) (err error) {
+		cfg.BufferSize, err = strconv.Atoi(v)
+		return err
+	})
+	env("DROP_POLICY", func(v string) error {
+		cfg.DropPolicy = OverflowPolicy(strings.ToLower(v))
+		return nil
+	})
+	env("RETRY_GRACE", func(v string) (err error) {
+		cfg.RetryGrace, err = time.ParseDuration(v)
+		return err
+	})
+	env("MIN_LEVEL", func(v string) error {
+		cfg.MinLevel = new(slog.Level)
+		return cfg.MinLevel.UnmarshalText([]byte(v))
+	})
+	env("READ_LEVEL", func(v string) error {
+		cfg.ReadLevel = new(slog.Level)
+		return cfg.ReadLevel.UnmarshalText([]byte(v))
+	})
+	env("LEVEL_OVERRIDES", func(v string) error {
+		cfg.LevelOverrides = make(map[string]slog.Level)
+		return parsePairs(v, func(name, value string) error {
+			var level slog.Level
+			if err := level.UnmarshalText([]byte(value)); err != nil {
+				return err
+			}
+			cfg.LevelOverrides[name] = level
+			return nil
+		})
+	})
+	env("RECORD_TTL", func(v string) error {
+		cfg.RecordTTL = make(map[slog.Level]time.Duration)
+		return parsePairs(v, func(name, value string) error {
+			var level slog.Level
+			if err := level.UnmarshalText([]byte(name)); err != nil {
+				return err
+			}
+			ttl, err := time.ParseDuration(value)
+			cfg.RecordTTL[level] = ttl
+			return err
+		})
+	})
+	env("ERR_CLOSED", func(v string) (err error) {
+		cfg.ErrClosed, err = strconv.ParseBool(v)
+		return err
+	})
+	env("SEQUENCE", func(v string) (err error) {
+		cfg.Sequence, err = strconv.ParseBool(v)
+		return err
+	})
+	env("SLOG_LEVEL", func(v string) (err error) {
+		cfg.SlogLevel, err = strconv.ParseBool(v)
+		return err
+	})
+	env("OMIT_TIME", func(v string) (err error) {
+		cfg.OmitTime, err = strconv.ParseBool(v)
+		return err
+	})
+
+	if err := errors.Join(errs...); err != nil {
+		return cfg, fmt.Errorf("slog provider: invalid environment: %w", err)
+	}
+	return cfg, nil
+}
+
+// parsePairs calls set for every comma-separated name=value pair of s.
+func parsePairs(s string, set func(name, value string) error) error {
+	for _, pair := range strings.Split(s, ",") {
+		name, value, ok := strings.Cut(pair, "=")
+		name, value = strings.TrimSpace(name), strings.TrimSpace(value)
+		if !ok || name == "" {
+			return fmt.Errorf("expected name=value, got %q", pair)
+		}
+		if err := set(name, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
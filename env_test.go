@@ -0,0 +1,96 @@
+// env_test.go: Tests for environment-driven provider configuration
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestConfigFromEnv_Defaults(t *testing.T) {
+	cfg, err := ConfigFromEnv()
+	if err != nil {
+		t.Fatalf("ConfigFromEnv failed: %v", err)
+	}
+	if !reflect.DeepEqual(cfg, Config{BufferSize: DefaultBufferSize}) {
+		t.Errorf("ConfigFromEnv() = %+v, want the defaults", cfg)
+	}
+}
+
+func TestConfigFromEnv_ParsesVariables(t *testing.T) {
+	t.Setenv("IRIS_SLOG_BUFFER_SIZE", "5000")
+	t.Setenv("IRIS_SLOG_DROP_POLICY", "DROP_OLDEST")
+	t.Setenv("IRIS_SLOG_RETRY_GRACE", "2ms")
+	t.Setenv("IRIS_SLOG_MIN_LEVEL", "debug")
+	t.Setenv("IRIS_SLOG_LEVEL_OVERRIDES", "db=WARN, http.client=ERROR")
+	t.Setenv("IRIS_SLOG_RECORD_TTL", "DEBUG=30s,INFO=5m")
+	t.Setenv("IRIS_SLOG_SEQUENCE", "1")
+	t.Setenv("IRIS_SLOG_OMIT_TIME", "true")
+
+	cfg, err := ConfigFromEnv()
+	if err != nil {
+		t.Fatalf("ConfigFromEnv failed: %v", err)
+	}
+	debug := slog.LevelDebug
+	want := Config{
+		BufferSize:     5000,
+		DropPolicy:     DropOldest,
+		RetryGrace:     2 * time.Millisecond,
+		MinLevel:       &debug,
+		LevelOverrides: map[string]slog.Level{"db": slog.LevelWarn, "http.client": slog.LevelError},
+		RecordTTL:      map[slog.Level]time.Duration{slog.LevelDebug: 30 * time.Second, slog.LevelInfo: 5 * time.Minute},
+		Sequence:       true,
+		OmitTime:       true,
+	}
+	if !reflect.DeepEqual(cfg, want) {
+		t.Errorf("ConfigFromEnv() = %+v, want %+v", cfg, want)
+	}
+}
+
+func TestConfigFromEnv_ReportsEveryMalformedVariable(t *testing.T) {
+	t.Setenv("IRIS_SLOG_BUFFER_SIZE", "lots")
+	t.Setenv("IRIS_SLOG_MIN_LEVEL", "loud")
+	t.Setenv("IRIS_SLOG_LEVEL_OVERRIDES", "db")
+	t.Setenv("IRIS_SLOG_ERR_CLOSED", "maybe")
+
+	_, err := ConfigFromEnv()
+	if err == nil {
+		t.Fatal("Expected an invalid environment error")
+	}
+	for _, name := range []string{"BUFFER_SIZE", "MIN_LEVEL", "LEVEL_OVERRIDES", "ERR_CLOSED"} {
+		if !strings.Contains(err.Error(), "IRIS_SLOG_"+name) {
+			t.Errorf("Expected IRIS_SLOG_%s in %q", name, err)
+		}
+	}
+}
+
+func TestNewFromEnv(t *testing.T) {
+	t.Setenv("IRIS_SLOG_BUFFER_SIZE", "64")
+	t.Setenv("IRIS_SLOG_MIN_LEVEL", "WARN")
+
+	provider, err := NewFromEnv(WithSequence())
+	if err != nil {
+		t.Fatalf("NewFromEnv failed: %v", err)
+	}
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	if provider.Cap() != 64 {
+		t.Errorf("Cap() = %d, want 64", provider.Cap())
+	}
+	if provider.Enabled(context.Background(), slog.LevelInfo) {
+		t.Error("Expected IRIS_SLOG_MIN_LEVEL to be applied")
+	}
+
+	t.Setenv("IRIS_SLOG_DROP_POLICY", "drop_random")
+	if _, err := NewFromEnv(); err == nil || !strings.Contains(err.Error(), "drop_random") {
+		t.Errorf("Expected the invalid drop policy to be reported, got %v", err)
+	}
+}
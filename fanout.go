@@ -0,0 +1,93 @@
+// fanout.go: Fan-out of one record stream to multiple Iris loggers
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import "github.com/agilira/iris"
+
+// FanOut delivers every record of a SyncReader to several subscribers.
+//
+// Where Router sends each record to one route, FanOut copies each record to
+// all subscribers, so one provider can feed independent Iris pipelines, e.g.
+// a hot path to stdout and a durable path to a file:
+//
+//	fan := slogprovider.NewFanOut(provider, 1000, "stdout", "file")
+//	defer fan.Close()
+//
+//	hot, _ := iris.NewReaderLogger(stdoutConfig, []iris.SyncReader{fan.Reader("stdout")})
+//	durable, _ := iris.NewReaderLogger(fileConfig, []iris.SyncReader{fan.Reader("file")})
+//
+// Each subscriber has its own buffer of bufferSize records and its own drop
+// accounting: a slow subscriber drops records, counted in Dropped, without
+// slowing down the others. Every subscriber receives its own copy of the
+// record.
+type FanOut struct {
+	subs   []*routeReader
+	byName map[string]*routeReader
+	pump   *recordPump
+}
+
+// NewFanOut creates a FanOut reading from source and starts delivering to
+// the named subscribers. Duplicate names are ignored. Closing the FanOut
+// closes source and delivers the records still buffered in it.
+func NewFanOut(source iris.SyncReader, bufferSize int, subscribers ...string) *FanOut {
+	f := &FanOut{
+		byName: make(map[string]*routeReader, len(subscribers)),
+		pump:   newRecordPump(source),
+	}
+	for _, name := range subscribers {
+		if _, ok := f.byName[name]; ok {
+			continue
+		}
+		sub := newRouteReader(nil, bufferSize, f.pump.stopped)
+		f.subs = append(f.subs, sub)
+		f.byName[name] = sub
+	}
+
+	go f.pump.run(f.publish)
+	return f
+}
+
+// Reader returns the iris.SyncReader of the named subscriber, or nil if no
+// such subscriber exists. Closing a subscriber's reader only stops delivery
+// to that subscriber.
+func (f *FanOut) Reader(name string) iris.SyncReader {
+	if sub, ok := f.byName[name]; ok {
+		return sub
+	}
+	return nil
+}
+
+// Dropped returns the number of records dropped for the named subscriber
+// because its buffer was full or its reader was closed.
+func (f *FanOut) Dropped(name string) uint64 {
+	if sub, ok := f.byName[name]; ok {
+		return sub.dropped.Load()
+	}
+	return 0
+}
+
+// Close closes the source reader, delivers the records still buffered in it
+// and stops delivery. As with Router.Close, the source must report end of
+// stream once closed and drained.
+//
+// Subscriber readers return the records already delivered to them and then
+// report end of stream. Close is idempotent.
+func (f *FanOut) Close() error {
+	return f.pump.close()
+}
+
+// publish delivers a copy of record to every subscriber.
+func (f *FanOut) publish(record *iris.Record) {
+	for i, sub := range f.subs {
+		if i == len(f.subs)-1 {
+			sub.deliver(record) // The last subscriber takes the original
+			return
+		}
+		clone := *record
+		sub.deliver(&clone)
+	}
+}
@@ -0,0 +1,112 @@
+// fanout_test.go: Tests for record fan-out
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func TestFanOut_DeliversToAllSubscribers(t *testing.T) {
+	provider := New(10)
+	fan := NewFanOut(provider, 10, "hot", "durable", "hot")
+	defer func() { _ = fan.Close() }() // Ignore error in test cleanup
+
+	slog.New(provider).Info("shared", "k", "v")
+
+	hot := readWithTimeout(t, fan.Reader("hot"))
+	durable := readWithTimeout(t, fan.Reader("durable"))
+	if hot.Msg != "shared" || durable.Msg != "shared" {
+		t.Fatalf("Expected both subscribers to receive the record, got %q and %q", hot.Msg, durable.Msg)
+	}
+	if hot == durable {
+		t.Error("Expected subscribers to receive independent copies")
+	}
+	if fan.Reader("missing") != nil {
+		t.Error("Expected nil reader for unknown subscriber")
+	}
+}
+
+func TestFanOut_IndependentDropAccounting(t *testing.T) {
+	provider := New(10)
+	fan := NewFanOut(provider, 2, "fast", "slow")
+	defer func() { _ = fan.Close() }() // Ignore error in test cleanup
+
+	logger := slog.New(provider)
+	fast := fan.Reader("fast")
+	for i := 0; i < 5; i++ {
+		logger.Info("message", "i", i)
+		if record := readWithTimeout(t, fast); record.Msg != "message" {
+			t.Fatalf("Unexpected record %q", record.Msg)
+		}
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for fan.Dropped("slow") != 3 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if got := fan.Dropped("slow"); got != 3 {
+		t.Errorf("Expected 3 records dropped for the slow subscriber, got %d", got)
+	}
+	if got := fan.Dropped("fast"); got != 0 {
+		t.Errorf("Expected no drops for the fast subscriber, got %d", got)
+	}
+}
+
+func TestFanOut_CloseDrainsSubscribers(t *testing.T) {
+	provider := New(10)
+	fan := NewFanOut(provider, 10, "a")
+	slog.New(provider).Info("pending")
+
+	reader := fan.Reader("a")
+	if record := readWithTimeout(t, reader); record.Msg != "pending" {
+		t.Fatalf("Unexpected record %q", record.Msg)
+	}
+	if err := fan.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if record := readWithTimeout(t, reader); record != nil {
+		t.Errorf("Expected end of stream after Close, got %q", record.Msg)
+	}
+}
+
+func TestFanOut_CloseDeliversBufferedRecords(t *testing.T) {
+	provider := New(10)
+	fan := NewFanOut(provider, 10, "a", "b")
+
+	logger := slog.New(provider)
+	for i := 0; i < 5; i++ {
+		logger.Info("pending")
+	}
+	if err := fan.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	for _, name := range []string{"a", "b"} {
+		delivered := 0
+		for readWithTimeout(t, fan.Reader(name)) != nil {
+			delivered++
+		}
+		if delivered != 5 {
+			t.Errorf("Subscriber %s got %d records after Close, want 5", name, delivered)
+		}
+	}
+}
+
+func TestFanOut_BacksOffOnReadErrors(t *testing.T) {
+	source := &failingReader{closed: make(chan struct{})}
+	fan := NewFanOut(source, 10, "a")
+
+	time.Sleep(50 * time.Millisecond)
+	if err := fan.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if reads := source.reads.Load(); reads > 20 {
+		t.Errorf("Source read %d times in 50ms, want the pump to back off", reads)
+	}
+}
@@ -0,0 +1,79 @@
+// fault.go: Fault injection for resilience testing
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// FaultConversionValue is the value recorded for attributes whose conversion
+// was failed by a FaultPolicy.
+const FaultConversionValue = "!ERROR: injected conversion fault"
+
+// FaultPolicy injects faults into the provider so applications can test
+// their behavior when the logging bridge degrades. Nil hooks inject nothing.
+//
+// Hooks are called on the hot paths and must be safe for concurrent use.
+// Fault injection is meant for tests; production code should not configure
+// it.
+type FaultPolicy struct {
+	// BufferFull reports whether Handle should behave as if the buffer were
+	// full for record, dropping it.
+	BufferFull func(record slog.Record) bool
+
+	// ReadDelay returns a delay applied by Read before it takes the next
+	// record from the buffer, simulating a slow consumer. The delay is cut
+	// short when the Read context is cancelled.
+	ReadDelay func() time.Duration
+
+	// FailConversion reports whether the conversion of attr should fail. A
+	// failed attribute is recorded as FaultConversionValue and counted in
+	// Stats().ConversionErrors.
+	FailConversion func(attr slog.Attr) bool
+}
+
+// WithFaults installs a fault-injection policy:
+//
+//	provider := slogprovider.New(100, slogprovider.WithFaults(slogprovider.FaultPolicy{
+//	    ReadDelay:      func() time.Duration { return 50 * time.Millisecond },
+//	    FailConversion: func(a slog.Attr) bool { return a.Key == "payload" },
+//	}))
+func WithFaults(policy FaultPolicy) Option {
+	return func(o *options) { o.faults = &policy }
+}
+
+// bufferFull reports whether a full buffer is simulated for record.
+func (f *FaultPolicy) bufferFull(record slog.Record) bool {
+	return f != nil && f.BufferFull != nil && f.BufferFull(record)
+}
+
+// failConversion reports whether the conversion of attr is failed.
+func (f *FaultPolicy) failConversion(attr slog.Attr) bool {
+	return f != nil && f.FailConversion != nil && f.FailConversion(attr)
+}
+
+// delayRead waits for the configured read delay or until ctx is done,
+// returning ctx.Err() in the latter case.
+func (f *FaultPolicy) delayRead(ctx context.Context) error {
+	if f == nil || f.ReadDelay == nil {
+		return nil
+	}
+	delay := f.ReadDelay()
+	if delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
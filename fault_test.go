@@ -0,0 +1,70 @@
+// fault_test.go: Tests for fault injection
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func TestWithFaults_BufferFull(t *testing.T) {
+	provider := New(10, WithFaults(FaultPolicy{
+		BufferFull: func(r slog.Record) bool { return r.Message == "lost" },
+	}))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	logger := slog.New(provider)
+	logger.Info("lost")
+	logger.Info("kept")
+
+	if got := provider.queue.len(); got != 1 {
+		t.Fatalf("buffered %d records, want 1", got)
+	}
+	if record := readRecord(t, provider, func(*slog.Logger) {}); record.Msg != "kept" {
+		t.Errorf("record = %q, want kept", record.Msg)
+	}
+}
+
+func TestWithFaults_ReadDelay(t *testing.T) {
+	provider := New(10, WithFaults(FaultPolicy{
+		ReadDelay: func() time.Duration { return time.Hour },
+	}))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	slog.New(provider).Info("slow")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := provider.Read(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Read() error = %v, want deadline exceeded", err)
+	}
+	if got := provider.queue.len(); got != 1 {
+		t.Errorf("buffered %d records, want record kept for the next Read", got)
+	}
+}
+
+func TestWithFaults_FailConversion(t *testing.T) {
+	provider := New(10, WithFaults(FaultPolicy{
+		FailConversion: func(a slog.Attr) bool { return a.Key == "payload" },
+	}))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	record := readRecord(t, provider, func(l *slog.Logger) {
+		l.Info("upload", "payload", 42, "user", "alice")
+	})
+	if f, _ := findField(record, "payload"); f.StringValue() != FaultConversionValue {
+		t.Errorf("payload = %q, want %q", f.StringValue(), FaultConversionValue)
+	}
+	if f, _ := findField(record, "user"); f.StringValue() != "alice" {
+		t.Errorf("user = %q, want alice", f.StringValue())
+	}
+	if got := provider.Stats().ConversionErrors; got != 1 {
+		t.Errorf("Stats().ConversionErrors = %d, want 1", got)
+	}
+}
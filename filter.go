@@ -0,0 +1,52 @@
+// filter.go: Record filtering evaluated before buffering
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import "log/slog"
+
+// RecordFilter reports whether a record should be kept.
+//
+// Filters run synchronously in Handle, on the caller's goroutine, before the
+// record consumes buffer space. They must be safe for concurrent use and
+// should be cheap: every slog call pays their cost.
+type RecordFilter func(record slog.Record) bool
+
+// WithFilter adds a predicate evaluated in Handle. Records for which filter
+// returns false are dropped silently before buffering.
+//
+// WithFilter may be given several times; a record is kept only if every
+// filter keeps it. Filters run in the order they were added.
+//
+// Example dropping health-check noise:
+//
+//	provider := slogprovider.New(1000, slogprovider.WithFilter(func(r slog.Record) bool {
+//	    keep := true
+//	    r.Attrs(func(a slog.Attr) bool {
+//	        if a.Key == "path" && a.Value.String() == "/healthz" {
+//	            keep = false
+//	        }
+//	        return keep
+//	    })
+//	    return keep
+//	}))
+func WithFilter(filter RecordFilter) Option {
+	return func(o *options) {
+		if filter != nil {
+			o.filters = append(o.filters, filter)
+		}
+	}
+}
+
+// keep reports whether record passes every configured filter.
+func (o *options) keep(record slog.Record) bool {
+	for _, filter := range o.filters {
+		if !filter(record) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,53 @@
+// filter_test.go: Tests for Handle-time record filtering
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"log/slog"
+	"testing"
+)
+
+func TestWithFilter_DropsRejectedRecords(t *testing.T) {
+	dropHealth := func(r slog.Record) bool {
+		keep := true
+		r.Attrs(func(a slog.Attr) bool {
+			if a.Key == "path" && a.Value.String() == "/healthz" {
+				keep = false
+			}
+			return keep
+		})
+		return keep
+	}
+	provider := New(10, WithFilter(dropHealth), WithFilter(nil))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	logger := slog.New(provider)
+	logger.Info("request", "path", "/healthz")
+	logger.Info("request", "path", "/api/users")
+
+	if got := provider.queue.len(); got != 1 {
+		t.Fatalf("buffered %d records, want 1", got)
+	}
+	record := readRecord(t, provider, func(*slog.Logger) {})
+	if field, _ := findField(record, "path"); field.StringValue() != "/api/users" {
+		t.Errorf("kept record path = %q, want /api/users", field.StringValue())
+	}
+}
+
+func TestWithFilter_AllFiltersMustKeep(t *testing.T) {
+	calls := 0
+	reject := func(slog.Record) bool { calls++; return false }
+	never := func(slog.Record) bool { t.Error("filter evaluated after rejection"); return true }
+
+	provider := New(10, WithFilter(reject), WithFilter(never))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	slog.New(provider).Info("dropped")
+	if calls != 1 || provider.queue.len() != 0 {
+		t.Errorf("calls = %d, buffered = %d; want 1 call and nothing buffered", calls, provider.queue.len())
+	}
+}
@@ -2,10 +2,12 @@ module github.com/agilira/iris-provider-slog
 
 go 1.24.5
 
-require github.com/agilira/iris v1.1.0
+require (
+	github.com/agilira/argus v1.0.1
+	github.com/agilira/iris v1.1.0
+)
 
 require (
-	github.com/agilira/argus v1.0.1 // indirect
 	github.com/agilira/flash-flags v1.0.1 // indirect
 	github.com/agilira/go-errors v1.1.0 // indirect
 	github.com/agilira/go-timecache v1.0.1 // indirect
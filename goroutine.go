@@ -0,0 +1,53 @@
+// goroutine.go: Goroutine ID capture for concurrency debugging
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"runtime"
+	"strconv"
+
+	"github.com/agilira/iris"
+)
+
+// GoroutineIDKey is the field key used for the logging goroutine's ID.
+const GoroutineIDKey = "goroutine"
+
+// WithGoroutineID attaches the ID of the goroutine calling Handle to every
+// admitted record under GoroutineIDKey, so logs can be correlated by
+// goroutine when debugging concurrency bugs.
+//
+// Go deliberately does not expose goroutine IDs; the ID is parsed from the
+// header of runtime.Stack. This costs roughly a microsecond per record, so
+// the option is intended for debugging builds rather than hot production
+// paths. IDs are reused after a goroutine exits.
+func WithGoroutineID() Option {
+	return WithEnricher(goroutineIDEnricher)
+}
+
+// goroutineIDEnricher captures the current goroutine ID.
+func goroutineIDEnricher(context.Context, slog.Record) []iris.Field {
+	if id, ok := goroutineID(); ok {
+		return []iris.Field{iris.Uint64(GoroutineIDKey, id)}
+	}
+	return nil
+}
+
+// goroutineID parses the current goroutine ID from a stack header such as
+// "goroutine 42 [running]:".
+func goroutineID() (uint64, bool) {
+	var buf [64]byte
+	header := buf[:runtime.Stack(buf[:], false)]
+	header = bytes.TrimPrefix(header, []byte("goroutine "))
+	if i := bytes.IndexByte(header, ' '); i > 0 {
+		header = header[:i]
+	}
+	id, err := strconv.ParseUint(string(header), 10, 64)
+	return id, err == nil
+}
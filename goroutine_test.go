@@ -0,0 +1,44 @@
+// goroutine_test.go: Tests for goroutine ID capture
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"log/slog"
+	"testing"
+)
+
+func TestGoroutineID_DistinguishesGoroutines(t *testing.T) {
+	main, ok := goroutineID()
+	if !ok || main == 0 {
+		t.Fatalf("goroutineID() = %d, %v; want a positive id", main, ok)
+	}
+
+	other := make(chan uint64)
+	go func() {
+		id, _ := goroutineID()
+		other <- id
+	}()
+	if id := <-other; id == main || id == 0 {
+		t.Errorf("goroutine id %d, want distinct from %d", id, main)
+	}
+}
+
+func TestWithGoroutineID_AttachesField(t *testing.T) {
+	provider := New(10, WithGoroutineID())
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	want, _ := goroutineID()
+	record := readRecord(t, provider, func(l *slog.Logger) { l.Info("work") })
+
+	field, ok := findField(record, GoroutineIDKey)
+	if !ok || !field.IsUint() {
+		t.Fatalf("missing uint %q field", GoroutineIDKey)
+	}
+	if got := field.UintValue(); got != want {
+		t.Errorf("goroutine = %d, want %d", got, want)
+	}
+}
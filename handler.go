@@ -0,0 +1,73 @@
+// handler.go: Derived slog handlers sharing a provider buffer
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"context"
+	"log/slog"
+)
+
+// groupHandler is the slog.Handler returned by Provider.WithGroup,
+// Provider.WithAttrs and NewScoped.
+//
+// It shares the buffer of its Provider and carries the dotted group path,
+// which names the logger for per-group level rules, and the attributes bound
+// so far. The effective minimum level is resolved once at creation, so
+// Enabled stays a single comparison.
+type groupHandler struct {
+	p     *Provider
+	name  string       // Dotted group path, e.g. "db.pool"
+	level slog.Leveler // Effective minimum level, nil for none
+	bound *boundAttrs  // Attributes bound with WithAttrs, nil for none
+	scope *scope       // Record quota shared by a NewScoped handler, nil for none
+}
+
+// newGroupHandler creates a handler for the group path name with the bound
+// attributes bound.
+func (p *Provider) newGroupHandler(name string, bound *boundAttrs) *groupHandler {
+	return &groupHandler{
+		p:     p,
+		name:  name,
+		level: p.opts.levelFor(name),
+		bound: bound,
+	}
+}
+
+// Enabled implements slog.Handler using the group's effective minimum level.
+func (h *groupHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.p.enabledFor(h.name, h.level, level) || h.p.boostEnabled(level)
+}
+
+// Handle implements slog.Handler by buffering record in the shared provider.
+func (h *groupHandler) Handle(ctx context.Context, record slog.Record) error {
+	if h.scope != nil && !h.scope.admit() {
+		h.p.stats.quotaDropped.Add(1)
+		return nil
+	}
+	return h.p.handle(ctx, record, h.name, h.level, h.bound)
+}
+
+// WithAttrs implements slog.Handler by binding attrs under the current group
+// path, see Provider.WithAttrs.
+func (h *groupHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	derived := *h
+	derived.bound = h.p.bind(h.bound, h.name, attrs)
+	return &derived
+}
+
+// WithGroup implements slog.Handler by extending the group path.
+func (h *groupHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	derived := h.p.newGroupHandler(joinPath(h.name, name), h.bound)
+	derived.scope = h.scope
+	return derived
+}
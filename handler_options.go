@@ -0,0 +1,195 @@
+// handler_options.go: slog.HandlerOptions compatibility
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"log/slog"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// Keys of the source location fields added with WithAddSource.
+const (
+	SourceFunctionKey = slog.SourceKey + ".function" // Fully qualified function name, omitted when unknown
+	SourceFileKey     = slog.SourceKey + ".file"     // Absolute file path
+	SourceLineKey     = slog.SourceKey + ".line"     // Line number
+)
+
+// NewWithHandlerOptions creates a provider honoring ho like the standard
+// library handlers, so HandlerOptions used with slog.NewJSONHandler carry
+// over verbatim:
+//
+//	provider := slogprovider.NewWithHandlerOptions(1000, &slog.HandlerOptions{
+//	    Level:       slog.LevelDebug,
+//	    AddSource:   true,
+//	    ReplaceAttr: replaceAttr,
+//	})
+//
+// As with slog.NewJSONHandler, a nil ho or Level admits records from
+// slog.LevelInfo up. ho.Level, ho.AddSource and ho.ReplaceAttr are applied
+// with WithMinLevel, WithAddSource and WithReplaceAttr, before opts.
+func NewWithHandlerOptions(bufferSize int, ho *slog.HandlerOptions, opts ...Option) *Provider {
+	if ho == nil {
+		ho = &slog.HandlerOptions{}
+	}
+	var level slog.Leveler = slog.LevelInfo
+	if ho.Level != nil {
+		level = ho.Level
+	}
+	base := []Option{WithMinLevel(level)}
+	if ho.AddSource {
+		base = append(base, WithAddSource())
+	}
+	if ho.ReplaceAttr != nil {
+		base = append(base, WithReplaceAttr(ho.ReplaceAttr))
+	}
+	return New(bufferSize, append(base, opts...)...)
+}
+
+// WithAddSource attaches the source location of the logging call, as
+// slog.HandlerOptions.AddSource does, in the SourceFunctionKey,
+// SourceFileKey and SourceLineKey fields. Records without a program counter,
+// such as those built with a zero PC, get no location.
+func WithAddSource() Option {
+	return func(o *options) { o.addSource = true }
+}
+
+// WithReplaceAttr rewrites or removes attributes before they are buffered,
+// with the semantics of slog.HandlerOptions.ReplaceAttr:
+//
+//   - fn is called with the built-in slog.TimeKey, slog.LevelKey,
+//     slog.MessageKey and, with WithAddSource, slog.SourceKey attributes,
+//     with nil groups. A replaced time, level or message updates the record;
+//     a level replaced by a non-level value, such as a custom name, is kept
+//     in the LevelNameKey field; removing the level has no effect, as Iris
+//     records always have one.
+//   - fn is called with every other attribute, with the open groups of the
+//     handler (see WithGroup) and of enclosing group attributes, but not
+//     with group attributes themselves. Values are resolved first.
+//   - An attribute replaced by one with an empty key is removed, and groups
+//     left empty are removed too.
+//
+// Attributes bound with WithAttrs are rewritten once, when bound.
+func WithReplaceAttr(fn func(groups []string, a slog.Attr) slog.Attr) Option {
+	return func(o *options) { o.replaceAttr = fn }
+}
+
+// formatRecord applies WithAddSource and WithReplaceAttr to record handled
+// for the logger name. The level name and source location are returned as
+// built-in attributes, which, unlike record attributes, are not qualified by
+// the group path.
+func (o *options) formatRecord(record slog.Record, name string) (slog.Record, []slog.Attr) {
+	t, level, msg := record.Time, record.Level, record.Message
+	var levelName string
+	if o.replaceAttr != nil {
+		if !t.IsZero() {
+			a := o.replace(nil, slog.Time(slog.TimeKey, t))
+			switch {
+			case a.Key == "":
+				t = time.Time{}
+			case a.Value.Kind() == slog.KindTime:
+				t = a.Value.Time()
+			}
+		}
+		if a := o.replace(nil, slog.Any(slog.LevelKey, level)); a.Key != "" {
+			if l, ok := a.Value.Any().(slog.Level); ok {
+				level = l
+			} else {
+				levelName = a.Value.String()
+			}
+		}
+		if a := o.replace(nil, slog.String(slog.MessageKey, msg)); a.Key == "" {
+			msg = ""
+		} else {
+			msg = a.Value.String()
+		}
+	}
+
+	var builtin []slog.Attr
+	if levelName != "" {
+		builtin = append(builtin, slog.String(LevelNameKey, levelName))
+	}
+	if o.addSource && record.PC != 0 {
+		builtin = append(builtin, o.sourceAttrs(record.PC)...)
+	}
+	out := slog.NewRecord(t, level, msg, record.PC)
+	groups := loggerGroups(name)
+	record.Attrs(func(a slog.Attr) bool {
+		out.AddAttrs(o.replaceAttrs(groups, a)...)
+		return true
+	})
+	return out, builtin
+}
+
+// sourceAttrs returns the source location attributes of pc.
+func (o *options) sourceAttrs(pc uintptr) []slog.Attr {
+	frame, _ := runtime.CallersFrames([]uintptr{pc}).Next()
+	a := slog.Any(slog.SourceKey, &slog.Source{Function: frame.Function, File: frame.File, Line: frame.Line})
+	if o.replaceAttr != nil {
+		a = o.replace(nil, a)
+	}
+	if a.Key == "" {
+		return nil
+	}
+	src, ok := a.Value.Any().(*slog.Source)
+	if !ok || a.Key != slog.SourceKey {
+		return []slog.Attr{a}
+	}
+	attrs := make([]slog.Attr, 0, 3)
+	if src.Function != "" {
+		attrs = append(attrs, slog.String(SourceFunctionKey, src.Function))
+	}
+	return append(attrs, slog.String(SourceFileKey, src.File), slog.Int(SourceLineKey, src.Line))
+}
+
+// replaceAttrs applies WithReplaceAttr to a, opened under groups, returning
+// the attributes that remain: none, a, or the members of an inline group.
+func (o *options) replaceAttrs(groups []string, a slog.Attr) []slog.Attr {
+	if o.replaceAttr == nil {
+		return []slog.Attr{a}
+	}
+	a.Value = a.Value.Resolve()
+	if a.Value.Kind() != slog.KindGroup {
+		if a = o.replace(groups, a); a.Key == "" {
+			return nil
+		}
+		return []slog.Attr{a}
+	}
+
+	inner := groups
+	if a.Key != "" {
+		inner = append(groups[:len(groups):len(groups)], a.Key)
+	}
+	var members []slog.Attr
+	for _, member := range a.Value.Group() {
+		members = append(members, o.replaceAttrs(inner, member)...)
+	}
+	switch {
+	case len(members) == 0:
+		return nil
+	case a.Key == "":
+		return members
+	default:
+		return []slog.Attr{{Key: a.Key, Value: slog.GroupValue(members...)}}
+	}
+}
+
+// replace calls the WithReplaceAttr function with a resolved value.
+func (o *options) replace(groups []string, a slog.Attr) slog.Attr {
+	a = o.replaceAttr(groups, a)
+	a.Value = a.Value.Resolve()
+	return a
+}
+
+// loggerGroups splits the logger name into the open group names.
+func loggerGroups(name string) []string {
+	if name == "" {
+		return nil
+	}
+	return strings.Split(name, ".")
+}
@@ -0,0 +1,103 @@
+// handler_options_test.go: Tests for slog.HandlerOptions compatibility
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestNewWithHandlerOptions_Level(t *testing.T) {
+	provider := NewWithHandlerOptions(10, nil)
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+	if provider.Enabled(context.Background(), slog.LevelDebug) || !provider.Enabled(context.Background(), slog.LevelInfo) {
+		t.Error("Expected the slog default of LevelInfo")
+	}
+
+	var level slog.LevelVar
+	level.Set(slog.LevelWarn)
+	dynamic := NewWithHandlerOptions(10, &slog.HandlerOptions{Level: &level})
+	defer func() { _ = dynamic.Close() }() // Ignore error in test cleanup
+	if dynamic.Enabled(context.Background(), slog.LevelInfo) {
+		t.Error("Expected the HandlerOptions level to apply")
+	}
+	level.Set(slog.LevelDebug)
+	if !dynamic.Enabled(context.Background(), slog.LevelDebug) {
+		t.Error("Expected LevelVar changes to apply")
+	}
+}
+
+func TestNewWithHandlerOptions_AddSource(t *testing.T) {
+	provider := NewWithHandlerOptions(10, &slog.HandlerOptions{AddSource: true})
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	record := readRecord(t, provider, func(l *slog.Logger) { l.Info("located") })
+	file, ok := findField(record, SourceFileKey)
+	if !ok || !strings.HasSuffix(file.StringValue(), "handler_options_test.go") {
+		t.Errorf("%s = %v, want this file", SourceFileKey, file)
+	}
+	if fn, ok := findField(record, SourceFunctionKey); !ok || !strings.Contains(fn.StringValue(), "TestNewWithHandlerOptions_AddSource") {
+		t.Errorf("%s = %v, want this test", SourceFunctionKey, fn)
+	}
+	if line, ok := findField(record, SourceLineKey); !ok || line.IntValue() <= 0 {
+		t.Errorf("%s = %v, want a line number", SourceLineKey, line)
+	}
+}
+
+func TestNewWithHandlerOptions_ReplaceAttr(t *testing.T) {
+	var seen [][]string
+	provider := NewWithHandlerOptions(10, &slog.HandlerOptions{
+		Level: slog.LevelDebug - 4,
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			switch {
+			case a.Key == slog.LevelKey && len(groups) == 0:
+				if a.Value.Any().(slog.Level) == slog.LevelDebug-4 {
+					return slog.String(a.Key, "TRACE")
+				}
+			case a.Key == slog.MessageKey && len(groups) == 0:
+				return slog.String(a.Key, strings.ToUpper(a.Value.String()))
+			case a.Key == "password":
+				return slog.Attr{}
+			case a.Key == "user":
+				seen = append(seen, groups)
+				return slog.String("user", "redacted")
+			}
+			return a
+		},
+	})
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	record := readRecord(t, provider, func(l *slog.Logger) {
+		l.WithGroup("req").With("user", "alice").Info("login", "password", "secret",
+			slog.Group("auth", "user", "bob", "password", "hunter2"))
+	})
+	if record.Msg != "LOGIN" {
+		t.Errorf("Msg = %q, want the replaced message", record.Msg)
+	}
+	if _, ok := findField(record, "req.password"); ok {
+		t.Error("Expected removed attributes to be omitted")
+	}
+	if user, ok := findField(record, "req.user"); !ok || user.StringValue() != "redacted" {
+		t.Errorf("Expected the bound attribute to be replaced, got %v", user)
+	}
+	if auth, ok := findField(record, "req.auth.user"); !ok || auth.StringValue() != "redacted" {
+		t.Errorf("Expected group members to be replaced, got %v", auth)
+	}
+	if _, ok := findField(record, "req.auth.password"); ok {
+		t.Error("Expected removed group members to be omitted")
+	}
+	if len(seen) != 2 || strings.Join(seen[0], ".") != "req" || strings.Join(seen[1], ".") != "req.auth" {
+		t.Errorf("Unexpected groups %v", seen)
+	}
+
+	trace := readRecord(t, provider, func(l *slog.Logger) { l.Log(context.Background(), slog.LevelDebug-4, "fine") })
+	if name, ok := findField(trace, LevelNameKey); !ok || name.StringValue() != "TRACE" || trace.Msg != "FINE" {
+		t.Errorf("Expected the replaced level name, got %v", name)
+	}
+}
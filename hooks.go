@@ -0,0 +1,123 @@
+// hooks.go: Before-buffer and after-convert hook interfaces
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/agilira/iris"
+)
+
+// HandleHook is called in Handle for every record that passed the level,
+// filter, sampling and throttling checks, just before it is enriched and
+// buffered. Returning false drops the record.
+type HandleHook interface {
+	OnHandle(ctx context.Context, record slog.Record) bool
+}
+
+// EmitHook is called on the Read path with every record about to be returned
+// to Iris, after conversion, schema validation and middleware. It may modify
+// the record in place.
+type EmitHook interface {
+	OnEmit(record *iris.Record)
+}
+
+// HandleHookFunc adapts a function to the HandleHook interface.
+type HandleHookFunc func(ctx context.Context, record slog.Record) bool
+
+// OnHandle calls f(ctx, record).
+func (f HandleHookFunc) OnHandle(ctx context.Context, record slog.Record) bool {
+	return f(ctx, record)
+}
+
+// EmitHookFunc adapts a function to the EmitHook interface.
+type EmitHookFunc func(record *iris.Record)
+
+// OnEmit calls f(record).
+func (f EmitHookFunc) OnEmit(record *iris.Record) {
+	f(record)
+}
+
+// WithHooks registers hooks with the provider. Each hook must implement
+// HandleHook, EmitHook or both, and is registered for every interface it
+// implements, so a single value can observe both ends of the pipeline:
+//
+//	type latency struct{ ... }
+//	func (l *latency) OnHandle(ctx context.Context, r slog.Record) bool { ...; return true }
+//	func (l *latency) OnEmit(r *iris.Record)                             { ... }
+//
+//	provider := slogprovider.New(1000, slogprovider.WithHooks(&latency{}))
+//
+// Hooks run in registration order; nil hooks are ignored. WithHooks panics
+// if a hook implements neither interface.
+func WithHooks(hooks ...any) Option {
+	var handle []HandleHook
+	var emit []EmitHook
+	for _, hook := range hooks {
+		if hook == nil {
+			continue
+		}
+		h, isHandle := hook.(HandleHook)
+		e, isEmit := hook.(EmitHook)
+		if !isHandle && !isEmit {
+			panic(fmt.Sprintf("slogprovider: hook %T implements neither HandleHook nor EmitHook", hook))
+		}
+		if isHandle {
+			handle = append(handle, h)
+		}
+		if isEmit {
+			emit = append(emit, e)
+		}
+	}
+	return func(o *options) {
+		o.handleHooks = append(o.handleHooks, handle...)
+		o.emitHooks = append(o.emitHooks, emit...)
+	}
+}
+
+// ChainHandleHooks composes hooks into one HandleHook that accepts a record
+// only if every hook accepts it, stopping at the first rejection.
+func ChainHandleHooks(hooks ...HandleHook) HandleHook {
+	return HandleHookFunc(func(ctx context.Context, record slog.Record) bool {
+		for _, h := range hooks {
+			if h != nil && !h.OnHandle(ctx, record) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// ChainEmitHooks composes hooks into one EmitHook calling each in order.
+func ChainEmitHooks(hooks ...EmitHook) EmitHook {
+	return EmitHookFunc(func(record *iris.Record) {
+		for _, h := range hooks {
+			if h != nil {
+				h.OnEmit(record)
+			}
+		}
+	})
+}
+
+// accept runs the handle hooks, stopping at the first rejection.
+func (o *options) accept(ctx context.Context, record slog.Record) bool {
+	for _, h := range o.handleHooks {
+		if !h.OnHandle(ctx, record) {
+			return false
+		}
+	}
+	return true
+}
+
+// emit runs the emit hooks.
+func (o *options) emit(record *iris.Record) {
+	for _, h := range o.emitHooks {
+		h.OnEmit(record)
+	}
+}
@@ -0,0 +1,75 @@
+// hooks_test.go: Tests for the handle and emit hook interfaces
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/agilira/iris"
+)
+
+// countingHook implements both hook interfaces.
+type countingHook struct {
+	handled, emitted int
+}
+
+func (h *countingHook) OnHandle(context.Context, slog.Record) bool { h.handled++; return true }
+func (h *countingHook) OnEmit(*iris.Record)                        { h.emitted++ }
+
+func TestWithHooks_RegistersBothInterfaces(t *testing.T) {
+	hook := &countingHook{}
+	dropNoise := HandleHookFunc(func(_ context.Context, r slog.Record) bool { return r.Message != "noise" })
+	tag := EmitHookFunc(func(r *iris.Record) { r.AddField(iris.String("emitted", "yes")) })
+
+	provider := New(10, WithHooks(hook, dropNoise, nil, tag))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	slog.New(provider).Info("noise")
+	record := readRecord(t, provider, func(l *slog.Logger) { l.Info("signal") })
+
+	if hook.handled != 2 || hook.emitted != 1 {
+		t.Errorf("handled = %d, emitted = %d; want 2 and 1", hook.handled, hook.emitted)
+	}
+	if record.Msg != "signal" {
+		t.Errorf("record = %q, want signal", record.Msg)
+	}
+	if _, ok := findField(record, "emitted"); !ok {
+		t.Error("emit hook did not modify the record")
+	}
+}
+
+func TestWithHooks_PanicsOnInvalidHook(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("WithHooks did not panic for a non-hook value")
+		}
+	}()
+	WithHooks(42)
+}
+
+func TestChainHandleHooks_StopsAtFirstRejection(t *testing.T) {
+	calls := 0
+	reject := HandleHookFunc(func(context.Context, slog.Record) bool { calls++; return false })
+	never := HandleHookFunc(func(context.Context, slog.Record) bool { t.Error("hook called after rejection"); return true })
+
+	if ChainHandleHooks(reject, never).OnHandle(context.Background(), slog.Record{}) || calls != 1 {
+		t.Errorf("chain accepted or called %d times, want one rejection", calls)
+	}
+}
+
+func TestChainEmitHooks_RunsInOrder(t *testing.T) {
+	var order []string
+	first := EmitHookFunc(func(*iris.Record) { order = append(order, "first") })
+	second := EmitHookFunc(func(*iris.Record) { order = append(order, "second") })
+
+	ChainEmitHooks(first, nil, second).OnEmit(iris.NewRecord(iris.Info, "m"))
+	if len(order) != 2 || order[0] != "first" || order[1] != "second" {
+		t.Errorf("order = %v, want [first second]", order)
+	}
+}
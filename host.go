@@ -0,0 +1,147 @@
+// host.go: Host and container identity enrichment
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"log/slog"
+	"net"
+	"os"
+	"regexp"
+
+	"github.com/agilira/iris"
+)
+
+// Field keys used by WithHostIdentity, following OpenTelemetry naming.
+const (
+	HostNameKey    = "host.name"
+	HostIPKey      = "host.ip"
+	ContainerIDKey = "container.id"
+)
+
+// HostIdentity identifies the host and container a process runs in.
+//
+// Empty fields are omitted from records; ContainerID, for example, is empty
+// outside containers.
+type HostIdentity struct {
+	Hostname    string
+	IP          string
+	ContainerID string
+}
+
+// WithHostIdentity stamps every admitted record with the host name, IP
+// address and container ID, so aggregated logs from many hosts can be told
+// apart without relying on the log shipper.
+//
+// The identity is resolved once when the option is created. Fields set in
+// override take precedence over resolved values, which allows per-provider
+// configuration such as a Kubernetes pod name taken from the environment:
+//
+//	provider := slogprovider.New(1000, slogprovider.WithHostIdentity(
+//	    slogprovider.HostIdentity{Hostname: os.Getenv("POD_NAME")},
+//	))
+func WithHostIdentity(override HostIdentity) Option {
+	id := ResolveHostIdentity()
+	if override.Hostname != "" {
+		id.Hostname = override.Hostname
+	}
+	if override.IP != "" {
+		id.IP = override.IP
+	}
+	if override.ContainerID != "" {
+		id.ContainerID = override.ContainerID
+	}
+
+	fields := id.fields()
+	return WithEnricher(func(context.Context, slog.Record) []iris.Field {
+		return fields
+	})
+}
+
+// ResolveHostIdentity determines the identity of the current host.
+//
+// The hostname comes from os.Hostname, the IP is the first non-loopback
+// interface address (IPv4 preferred), and the container ID is parsed from
+// /proc/self/cgroup, falling back to /proc/self/mountinfo for cgroup v2
+// hosts. Values that cannot be resolved are left empty.
+func ResolveHostIdentity() HostIdentity {
+	var id HostIdentity
+	if name, err := os.Hostname(); err == nil {
+		id.Hostname = name
+	}
+	if addrs, err := net.InterfaceAddrs(); err == nil {
+		id.IP = primaryIP(addrs)
+	}
+	for _, path := range []string{"/proc/self/cgroup", "/proc/self/mountinfo"} {
+		if id.ContainerID = containerIDFromFile(path); id.ContainerID != "" {
+			break
+		}
+	}
+	return id
+}
+
+// fields returns the non-empty identity fields.
+func (id HostIdentity) fields() []iris.Field {
+	var fields []iris.Field
+	if id.Hostname != "" {
+		fields = append(fields, iris.String(HostNameKey, id.Hostname))
+	}
+	if id.IP != "" {
+		fields = append(fields, iris.String(HostIPKey, id.IP))
+	}
+	if id.ContainerID != "" {
+		fields = append(fields, iris.String(ContainerIDKey, id.ContainerID))
+	}
+	return fields
+}
+
+// primaryIP returns the first non-loopback unicast address, preferring IPv4.
+func primaryIP(addrs []net.Addr) string {
+	var v6 string
+	for _, addr := range addrs {
+		ipNet, ok := addr.(*net.IPNet)
+		if !ok || ipNet.IP.IsLoopback() || !ipNet.IP.IsGlobalUnicast() {
+			continue
+		}
+		if ip4 := ipNet.IP.To4(); ip4 != nil {
+			return ip4.String()
+		}
+		if v6 == "" {
+			v6 = ipNet.IP.String()
+		}
+	}
+	return v6
+}
+
+// containerIDPattern matches the 64 hex character IDs used by Docker,
+// containerd and CRI-O, as they appear in cgroup paths and mount sources
+// such as "/docker/<id>", "cri-containerd-<id>.scope" or
+// "/var/lib/docker/containers/<id>/hostname".
+var containerIDPattern = regexp.MustCompile(`(?:^|[/\-:])([0-9a-f]{64})(?:$|[/.\s])`)
+
+// containerIDFromFile parses a container ID from a /proc file.
+func containerIDFromFile(path string) string {
+	f, err := os.Open(path) // #nosec G304 -- fixed /proc paths
+	if err != nil {
+		return ""
+	}
+	defer func() { _ = f.Close() }() // Read-only file, close error is irrelevant
+	return parseContainerID(f)
+}
+
+// parseContainerID returns the first container ID found in r.
+func parseContainerID(r io.Reader) string {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		if m := containerIDPattern.FindStringSubmatch(scanner.Text()); m != nil {
+			return m[1]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,66 @@
+// host_test.go: Tests for host and container identity enrichment
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"log/slog"
+	"net"
+	"strings"
+	"testing"
+)
+
+const testContainerID = "3f4a9c2b8e1d7f6a5b4c3d2e1f0a9b8c7d6e5f4a3b2c1d0e9f8a7b6c5d4e3f2a"
+
+func TestParseContainerID(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"docker cgroup v1", "12:pids:/docker/" + testContainerID + "\n", testContainerID},
+		{"kubepods containerd", "0::/kubepods.slice/kubepods-pod1.slice/cri-containerd-" + testContainerID + ".scope\n", testContainerID},
+		{"systemd docker scope", "1:name=systemd:/system.slice/docker-" + testContainerID + ".scope\n", testContainerID},
+		{"mountinfo", "612 590 254:1 /var/lib/docker/containers/" + testContainerID + "/hostname /etc/hostname rw\n", testContainerID},
+		{"host cgroup v2", "0::/user.slice/user-1000.slice/session-2.scope\n", ""},
+		{"too long", "0::/docker/" + testContainerID + "ff\n", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseContainerID(strings.NewReader(tt.input)); got != tt.want {
+				t.Errorf("parseContainerID() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrimaryIP_PrefersIPv4(t *testing.T) {
+	addrs := []net.Addr{
+		&net.IPNet{IP: net.ParseIP("127.0.0.1"), Mask: net.CIDRMask(8, 32)},
+		&net.IPNet{IP: net.ParseIP("fe80::1"), Mask: net.CIDRMask(64, 128)},
+		&net.IPNet{IP: net.ParseIP("2001:db8::10"), Mask: net.CIDRMask(64, 128)},
+		&net.IPNet{IP: net.ParseIP("10.0.0.7"), Mask: net.CIDRMask(24, 32)},
+	}
+	if got := primaryIP(addrs); got != "10.0.0.7" {
+		t.Errorf("primaryIP() = %q, want 10.0.0.7", got)
+	}
+	if got := primaryIP(addrs[:3]); got != "2001:db8::10" {
+		t.Errorf("primaryIP() without IPv4 = %q, want 2001:db8::10", got)
+	}
+}
+
+func TestWithHostIdentity_OverridesResolvedValues(t *testing.T) {
+	provider := New(10, WithHostIdentity(HostIdentity{Hostname: "web-1", ContainerID: testContainerID}))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	record := readRecord(t, provider, func(l *slog.Logger) { l.Info("started") })
+	if f, _ := findField(record, HostNameKey); f.StringValue() != "web-1" {
+		t.Errorf("%s = %q, want web-1", HostNameKey, f.StringValue())
+	}
+	if f, _ := findField(record, ContainerIDKey); f.StringValue() != testContainerID {
+		t.Errorf("%s = %q, want override", ContainerIDKey, f.StringValue())
+	}
+}
@@ -0,0 +1,122 @@
+// identity.go: Identity and equality of provider-backed handlers
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"log/slog"
+	"slices"
+
+	"github.com/agilira/iris"
+)
+
+// HandlerIdentity describes what a provider-backed slog.Handler emits into:
+// the provider whose buffer it shares, the group path qualifying its keys
+// and the attributes bound with WithAttrs.
+type HandlerIdentity struct {
+	// Provider is the provider buffering the handler's records.
+	Provider *Provider
+
+	// Group is the dotted group path, e.g. "db.pool", or "" for none.
+	Group string
+
+	// Attrs are the bound attributes, converted back from the cached
+	// fields with ToSlogAttr, so their keys are qualified by the group path
+	// they were bound under.
+	Attrs []slog.Attr
+}
+
+// Identity returns the identity of h, which must be a Provider or a handler
+// derived from one with WithAttrs, WithGroup or NewScoped. It reports false
+// for any other handler, including wrappers such as ContextHandler.
+func Identity(h slog.Handler) (HandlerIdentity, bool) {
+	var (
+		p     *Provider
+		name  string
+		bound *boundAttrs
+	)
+	switch h := h.(type) {
+	case *Provider:
+		p = h
+	case *groupHandler:
+		p, name, bound = h.p, h.name, h.bound
+	default:
+		return HandlerIdentity{}, false
+	}
+	fields := bound.collect(nil)
+	attrs := make([]slog.Attr, len(fields))
+	for i, field := range fields {
+		attrs[i] = ToSlogAttr(field)
+	}
+	return HandlerIdentity{Provider: p, Group: name, Attrs: attrs}, true
+}
+
+// Equal reports whether other is a handler equivalent to p, so caching
+// layers keyed by handler can dedupe them: the provider itself, or a handler
+// derived from it without group path or bound attributes.
+func (p *Provider) Equal(other slog.Handler) bool {
+	return handlersEqual(p, p.newGroupHandler("", nil), other)
+}
+
+// Equal reports whether other is a handler equivalent to h: one sharing its
+// provider, group path, bound attributes and NewScoped quota.
+func (h *groupHandler) Equal(other slog.Handler) bool {
+	return handlersEqual(h.p, h, other)
+}
+
+// handlersEqual reports whether other is equivalent to h, a handler of p.
+// Bound attributes are compared by key and value, so handlers that bound
+// the same attributes independently are equal.
+func handlersEqual(p *Provider, h *groupHandler, other slog.Handler) bool {
+	var o *groupHandler
+	switch other := other.(type) {
+	case *Provider:
+		o = other.newGroupHandler("", nil)
+	case *groupHandler:
+		o = other
+	default:
+		return false
+	}
+	if o.p != p || o.name != h.name || o.scope != h.scope {
+		return false
+	}
+	if o.bound == h.bound {
+		return true
+	}
+	return slices.EqualFunc(h.bound.collect(nil), o.bound.collect(nil), func(a, b iris.Field) bool {
+		return a.Key() == b.Key() && ToSlogAttr(a).Value.Equal(ToSlogAttr(b).Value)
+	})
+}
+
+// collect appends the fields bound in b, oldest first, to dst.
+func (b *boundAttrs) collect(dst []iris.Field) []iris.Field {
+	if b == nil {
+		return dst
+	}
+	return append(b.parent.collect(dst), b.seg.fields[:b.n]...)
+}
+
+// WithOptions returns a new provider with the buffer size and options of p
+// followed by opts, so wrappers can derive a variant of a provider, e.g. with
+// a different minimum level, without knowing how it was built:
+//
+//	verbose := provider.WithOptions(slogprovider.WithMinLevel(slog.LevelDebug))
+//
+// The runtime rules active on p, installed with SetRules, WatchRules or
+// UpdateConfig, carry over and keep taking precedence over the options;
+// later rule changes apply to one provider only. The new provider has its
+// own buffer, which must be read by its own Iris reader, and Close does not
+// propagate between the two. As with NewSharded, stateful option values,
+// such as a Sampler instance, are shared, and so is the sampling state of
+// the carried-over rules.
+func (p *Provider) WithOptions(opts ...Option) *Provider {
+	derived := New(p.queue.cap(), append(slices.Clip(p.settings), opts...)...)
+	if active := p.rules.Load(); active != nil {
+		derived.opts.warmRules(active)
+		derived.rules.Store(active)
+	}
+	return derived
+}
@@ -0,0 +1,104 @@
+// identity_test.go: Tests for handler identity and equality
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+)
+
+func TestIdentity(t *testing.T) {
+	provider := New(10)
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	id, ok := Identity(provider.WithAttrs([]slog.Attr{slog.String("svc", "api")}).WithGroup("req").WithAttrs([]slog.Attr{slog.Int("id", 7)}))
+	if !ok || id.Provider != provider || id.Group != "req" {
+		t.Fatalf("Identity() = %+v, %v", id, ok)
+	}
+	if len(id.Attrs) != 2 || id.Attrs[0].String() != "svc=api" || id.Attrs[1].String() != "req.id=7" {
+		t.Errorf("Attrs = %v, want [svc=api req.id=7]", id.Attrs)
+	}
+	if _, ok := Identity(NewContextHandler(provider)); ok {
+		t.Error("Expected no identity for a wrapping handler")
+	}
+}
+
+func TestEqual(t *testing.T) {
+	provider := New(10)
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+	other := New(10)
+	defer func() { _ = other.Close() }() // Ignore error in test cleanup
+
+	bound := func(p slog.Handler, v string) slog.Handler {
+		return p.WithGroup("req").WithAttrs([]slog.Attr{slog.String("user", v)})
+	}
+	tests := []struct {
+		name string
+		a, b slog.Handler
+		want bool
+	}{
+		{"same provider", provider, provider, true},
+		{"empty derivations", provider, provider.WithAttrs(nil).WithGroup(""), true},
+		{"independent bindings", bound(provider, "alice"), bound(provider, "alice"), true},
+		{"different values", bound(provider, "alice"), bound(provider, "bob"), false},
+		{"different groups", provider.WithGroup("a"), provider.WithGroup("b"), false},
+		{"different providers", bound(provider, "alice"), bound(other, "alice"), false},
+		{"scoped", provider, NewScoped(provider, 5), false},
+		{"wrapped", provider, NewContextHandler(provider), false},
+	}
+	for _, tt := range tests {
+		eq, ok := tt.a.(interface{ Equal(slog.Handler) bool })
+		if !ok {
+			t.Fatalf("%s: %T has no Equal method", tt.name, tt.a)
+		}
+		if got := eq.Equal(tt.b); got != tt.want {
+			t.Errorf("%s: Equal() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestWithOptions(t *testing.T) {
+	provider := New(10, WithMinLevel(slog.LevelWarn), WithSequence())
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	derived := provider.WithOptions(WithMinLevel(slog.LevelDebug))
+	defer func() { _ = derived.Close() }() // Ignore error in test cleanup
+
+	if derived == provider || derived.Cap() != provider.Cap() {
+		t.Fatalf("Expected a new provider with capacity %d, got %d", provider.Cap(), derived.Cap())
+	}
+	if !derived.Enabled(context.Background(), slog.LevelDebug) || provider.Enabled(context.Background(), slog.LevelInfo) {
+		t.Error("Expected the extra options to apply to the derived provider only")
+	}
+	record := readRecord(t, derived, func(l *slog.Logger) { l.Debug("verbose") })
+	if _, ok := findField(record, SequenceKey); !ok {
+		t.Error("Expected the derived provider to keep the original options")
+	}
+}
+
+func TestWithOptions_CarriesRuntimeRules(t *testing.T) {
+	provider := New(10)
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	errorLevel := slog.LevelError
+	if err := provider.UpdateConfig(Config{BufferSize: 10, MinLevel: &errorLevel}); err != nil {
+		t.Fatalf("UpdateConfig failed: %v", err)
+	}
+	derived := provider.WithOptions(WithSequence())
+	defer func() { _ = derived.Close() }() // Ignore error in test cleanup
+
+	if derived.Enabled(context.Background(), slog.LevelWarn) {
+		t.Error("Expected the runtime minimum level to carry over")
+	}
+	if err := derived.SetRules(nil); err != nil {
+		t.Fatalf("SetRules failed: %v", err)
+	}
+	if !derived.Enabled(context.Background(), slog.LevelWarn) || provider.Enabled(context.Background(), slog.LevelWarn) {
+		t.Error("Expected rule changes to apply to one provider only")
+	}
+}
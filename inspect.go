@@ -0,0 +1,59 @@
+// inspect.go: Non-destructive inspection of buffered records
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"log/slog"
+	"time"
+)
+
+// RecordMeta summarizes a buffered record for diagnostics.
+type RecordMeta struct {
+	Level   slog.Level
+	Message string
+	Time    time.Time     // Record time as set by slog
+	Age     time.Duration // Time since Time, zero when the record has no time
+	Attrs   int           // Number of top-level attributes
+	Size    int           // Approximate payload size in bytes, see below
+	Seq     uint64        // Index assigned by WithSequence, 0 if none
+}
+
+// Range calls fn for each buffered record, oldest first, until fn returns
+// false. It does not consume records or convert them, so a debug endpoint
+// can show what is queued at little cost.
+//
+// Size is the EstimateSize estimate of the record plus its enriched fields.
+//
+// The buffer is locked while Range runs, blocking Read and the handling of
+// records that overflow the buffer, so fn must be fast and must not call back
+// into the provider. Records handled while Range runs are not visited.
+func (p *Provider) Range(fn func(meta RecordMeta) bool) {
+	now := time.Now()
+	p.queue.each(func(e *entry) bool {
+		return fn(entryMeta(e, now, true))
+	})
+}
+
+// entryMeta summarizes e at now. Size is estimated when estimate is set, and
+// otherwise taken from WithSizeAccounting, if any.
+func entryMeta(e *entry, now time.Time, estimate bool) RecordMeta {
+	meta := RecordMeta{
+		Level:   e.record.Level,
+		Message: e.record.Message,
+		Time:    e.record.Time,
+		Attrs:   e.record.NumAttrs(),
+		Size:    e.size,
+		Seq:     e.seq,
+	}
+	if estimate {
+		meta.Size = entrySize(e)
+	}
+	if !e.record.Time.IsZero() {
+		meta.Age = max(now.Sub(e.record.Time), 0)
+	}
+	return meta
+}
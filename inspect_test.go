@@ -0,0 +1,63 @@
+// inspect_test.go: Tests for non-destructive inspection of buffered records
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func TestRange_ListsBufferedRecordsWithoutConsuming(t *testing.T) {
+	provider := New(10, WithSequence())
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	logger := slog.New(provider)
+	logger.Info("first", "user", "bob", "n", 1)
+	logger.Warn("second")
+
+	var metas []RecordMeta
+	provider.Range(func(meta RecordMeta) bool {
+		metas = append(metas, meta)
+		return true
+	})
+
+	if len(metas) != 2 {
+		t.Fatalf("Range visited %d records, want 2", len(metas))
+	}
+	first := metas[0]
+	if first.Message != "first" || first.Level != slog.LevelInfo || first.Attrs != 2 || first.Seq != 1 {
+		t.Errorf("first = %+v", first)
+	}
+	if want := len("first") + len("user") + len("bob") + len("n") + 8; first.Size != want {
+		t.Errorf("first.Size = %d, want %d", first.Size, want)
+	}
+	if first.Age < 0 || first.Age > time.Minute {
+		t.Errorf("first.Age = %v, want small positive age", first.Age)
+	}
+	if metas[1].Message != "second" || metas[1].Level != slog.LevelWarn {
+		t.Errorf("second = %+v", metas[1])
+	}
+	if got := provider.queue.len(); got != 2 {
+		t.Errorf("buffered %d records after Range, want 2", got)
+	}
+}
+
+func TestRange_StopsEarly(t *testing.T) {
+	provider := New(10)
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	logger := slog.New(provider)
+	for i := 0; i < 5; i++ {
+		logger.Info("queued")
+	}
+	visited := 0
+	provider.Range(func(RecordMeta) bool { visited++; return visited < 2 })
+	if visited != 2 {
+		t.Errorf("visited %d records, want 2", visited)
+	}
+}
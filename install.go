@@ -0,0 +1,58 @@
+// install.go: Installing the provider as the slog default
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"context"
+	"log"
+	"log/slog"
+	"os"
+)
+
+// InstallDefault creates a provider configured by cfg, starts an Iris logger
+// writing its records to os.Stderr as JSON, like Setup, and installs it with
+// slog.SetDefault, so libraries logging through slog.Info and the log
+// package go through Iris:
+//
+//	shutdown, err := slogprovider.InstallDefault(slogprovider.Config{BufferSize: 10000})
+//	if err != nil {
+//	    return err
+//	}
+//	defer shutdown(context.Background())
+//
+// shutdown restores the previous slog default and log package output, then
+// waits, until ctx is done, for the buffered records to be written; it
+// returns ctx.Err() if they were not. Set cfg.Options to configure behavior
+// without a Config field.
+func InstallDefault(cfg Config) (func(ctx context.Context) error, error) {
+	provider, err := NewWithConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	stop, err := start(provider, os.Stderr)
+	if err != nil {
+		return nil, err
+	}
+
+	previous, output, flags := slog.Default(), log.Writer(), log.Flags()
+	slog.SetDefault(slog.New(provider))
+	return func(ctx context.Context) error {
+		if slog.Default().Handler() == slog.Handler(provider) {
+			slog.SetDefault(previous)
+			log.SetOutput(output)
+			log.SetFlags(flags)
+		}
+		done := make(chan error, 1)
+		go func() { done <- stop() }()
+		select {
+		case err := <-done:
+			return err
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}, nil
+}
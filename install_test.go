@@ -0,0 +1,82 @@
+// install_test.go: Tests for InstallDefault
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"context"
+	"errors"
+	"log"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// redirectStderr points os.Stderr to a temporary file for the test and
+// returns a function reading its content.
+func redirectStderr(t *testing.T) func() string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "stderr")
+	f, err := os.Create(path) // #nosec G304 -- test temp dir
+	if err != nil {
+		t.Fatalf("Failed to create file: %v", err)
+	}
+	stderr := os.Stderr
+	os.Stderr = f
+	t.Cleanup(func() {
+		os.Stderr = stderr
+		_ = f.Close() // Ignore error in test cleanup
+	})
+	return func() string {
+		data, err := os.ReadFile(path) // #nosec G304 -- test temp dir
+		if err != nil {
+			t.Fatalf("Failed to read file: %v", err)
+		}
+		return string(data)
+	}
+}
+
+func TestInstallDefault(t *testing.T) {
+	output := redirectStderr(t)
+	previous := slog.Default()
+
+	shutdown, err := InstallDefault(Config{BufferSize: 100})
+	if err != nil {
+		t.Fatalf("InstallDefault failed: %v", err)
+	}
+	if _, ok := slog.Default().Handler().(*Provider); !ok {
+		t.Fatalf("Expected provider as default handler, got %T", slog.Default().Handler())
+	}
+
+	slog.Info("through slog", "user_id", 42)
+	log.Print("through log")
+	if err := shutdown(context.Background()); err != nil {
+		t.Fatalf("shutdown failed: %v", err)
+	}
+
+	if slog.Default() != previous {
+		t.Error("Expected previous default logger restored")
+	}
+	got := output()
+	for _, want := range []string{"through slog", `"user_id":42`, "through log"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Expected %q in output, got:\n%s", want, got)
+		}
+	}
+}
+
+func TestInstallDefaultInvalidConfig(t *testing.T) {
+	previous := slog.Default()
+	_, err := InstallDefault(Config{})
+	if !errors.Is(err, ErrInvalidBufferSize) {
+		t.Errorf("Expected ErrInvalidBufferSize, got %v", err)
+	}
+	if slog.Default() != previous {
+		t.Error("Expected default logger untouched on error")
+	}
+}
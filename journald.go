@@ -0,0 +1,119 @@
+// journald.go: journald MESSAGE_ID and structured-field conventions
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	"github.com/agilira/iris"
+)
+
+// JournaldMessageIDKey is the field key used for the journald message identifier.
+const JournaldMessageIDKey = "MESSAGE_ID"
+
+// JournaldConfig configures journald-friendly stamping of converted records.
+//
+// systemd-journald identifies message catalog entries through a 128-bit
+// MESSAGE_ID, and only accepts field names made of uppercase ASCII letters,
+// digits and underscores. Services that ultimately log to the journal can use
+// this configuration so that records can be filtered with journalctl:
+//
+//	journalctl MESSAGE_ID=8d45620c1a4348dbb17410da57c60c66
+//	journalctl USER_ID=12345
+type JournaldConfig struct {
+	// MessageIDs maps message templates (the slog message string) to stable
+	// MESSAGE_ID values. IDs may be given as 32 hex characters or in UUID
+	// form; they are normalized to the lowercase 32 character form.
+	MessageIDs map[string]string
+
+	// DefaultMessageID is stamped on messages without an entry in MessageIDs.
+	// Leave empty to omit MESSAGE_ID for unmapped messages.
+	DefaultMessageID string
+
+	// DeriveMessageIDs derives a stable MESSAGE_ID from the message template
+	// (first 128 bits of its SHA-256) for messages without an explicit entry.
+	// It takes precedence over DefaultMessageID.
+	DeriveMessageIDs bool
+
+	// UppercaseFields renames every field key to the journald convention,
+	// e.g. "user.id" becomes "USER_ID".
+	UppercaseFields bool
+}
+
+// WithJournald stamps converted records with a journald MESSAGE_ID and,
+// optionally, renames field keys to journald-compatible uppercase names.
+//
+// The configuration is copied, so later modifications of cfg.MessageIDs
+// do not affect the provider.
+func WithJournald(cfg JournaldConfig) Option {
+	ids := make(map[string]string, len(cfg.MessageIDs))
+	for template, id := range cfg.MessageIDs {
+		ids[template] = normalizeMessageID(id)
+	}
+	cfg.MessageIDs = ids
+	cfg.DefaultMessageID = normalizeMessageID(cfg.DefaultMessageID)
+
+	return func(o *options) { o.journald = &cfg }
+}
+
+// messageID returns the MESSAGE_ID for msg, or "" when none applies.
+func (c *JournaldConfig) messageID(msg string) string {
+	if id, ok := c.MessageIDs[msg]; ok {
+		return id
+	}
+	if c.DeriveMessageIDs {
+		sum := sha256.Sum256([]byte(msg))
+		return hex.EncodeToString(sum[:16])
+	}
+	return c.DefaultMessageID
+}
+
+// stampMessageID adds the MESSAGE_ID field for msg to record, if any.
+func (c *JournaldConfig) stampMessageID(record *iris.Record, msg string) {
+	if id := c.messageID(msg); id != "" {
+		record.AddField(iris.String(JournaldMessageIDKey, id))
+	}
+}
+
+// normalizeMessageID converts UUID-style or uppercase identifiers to the
+// 32 lowercase hex characters journald expects.
+func normalizeMessageID(id string) string {
+	return strings.ToLower(strings.ReplaceAll(id, "-", ""))
+}
+
+// journaldFieldName converts key to a valid journald field name.
+//
+// journald field names may only contain uppercase ASCII letters, digits and
+// underscores, must not start with an underscore (reserved for trusted
+// fields) and must not start with a digit. Invalid characters are replaced
+// with underscores.
+func journaldFieldName(key string) string {
+	var b strings.Builder
+	b.Grow(len(key) + 2)
+	for i := 0; i < len(key); i++ {
+		c := key[i]
+		switch {
+		case c >= 'a' && c <= 'z':
+			b.WriteByte(c - ('a' - 'A'))
+		case c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+			b.WriteByte(c)
+		default:
+			b.WriteByte('_')
+		}
+	}
+
+	name := strings.TrimLeft(b.String(), "_")
+	if name == "" {
+		return "FIELD"
+	}
+	if name[0] >= '0' && name[0] <= '9' {
+		return "F_" + name
+	}
+	return name
+}
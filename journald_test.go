@@ -0,0 +1,84 @@
+// journald_test.go: Tests for journald MESSAGE_ID and field conventions
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"log/slog"
+	"testing"
+)
+
+func TestJournald_MessageID(t *testing.T) {
+	provider := New(10, WithJournald(JournaldConfig{
+		MessageIDs: map[string]string{
+			"user login": "8D45620C-1A43-48DB-B174-10DA57C60C66",
+		},
+		DefaultMessageID: "00000000000000000000000000000001",
+	}))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	record := readRecord(t, provider, func(l *slog.Logger) { l.Info("user login") })
+	field, ok := findField(record, JournaldMessageIDKey)
+	if !ok {
+		t.Fatal("MESSAGE_ID field missing")
+	}
+	if got := field.StringValue(); got != "8d45620c1a4348dbb17410da57c60c66" {
+		t.Errorf("MESSAGE_ID = %q, want normalized mapped ID", got)
+	}
+
+	record = readRecord(t, provider, func(l *slog.Logger) { l.Info("other") })
+	field, _ = findField(record, JournaldMessageIDKey)
+	if got := field.StringValue(); got != "00000000000000000000000000000001" {
+		t.Errorf("MESSAGE_ID = %q, want default ID", got)
+	}
+}
+
+func TestJournald_DerivedMessageIDIsStable(t *testing.T) {
+	cfg := JournaldConfig{DeriveMessageIDs: true}
+	first := cfg.messageID("cache miss")
+	if len(first) != 32 {
+		t.Fatalf("derived ID %q has length %d, want 32", first, len(first))
+	}
+	if second := cfg.messageID("cache miss"); second != first {
+		t.Errorf("derived ID not stable: %q != %q", second, first)
+	}
+	if other := cfg.messageID("cache hit"); other == first {
+		t.Error("different templates produced the same ID")
+	}
+}
+
+func TestJournald_UppercaseFields(t *testing.T) {
+	provider := New(10, WithJournald(JournaldConfig{UppercaseFields: true}))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	record := readRecord(t, provider, func(l *slog.Logger) {
+		l.Info("request", "user.id", "42", "_private", true)
+	})
+	if _, ok := findField(record, "USER_ID"); !ok {
+		t.Error("USER_ID field missing")
+	}
+	if _, ok := findField(record, "PRIVATE"); !ok {
+		t.Error("PRIVATE field missing")
+	}
+	if _, ok := findField(record, JournaldMessageIDKey); ok {
+		t.Error("MESSAGE_ID stamped without any configured ID")
+	}
+}
+
+func TestJournaldFieldName(t *testing.T) {
+	tests := map[string]string{
+		"user_id":     "USER_ID",
+		"http.status": "HTTP_STATUS",
+		"__trusted":   "TRUSTED",
+		"2fa":         "F_2FA",
+		"___":         "FIELD",
+	}
+	for in, want := range tests {
+		if got := journaldFieldName(in); got != want {
+			t.Errorf("journaldFieldName(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
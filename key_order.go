@@ -0,0 +1,156 @@
+// key_order.go: Per-key timestamp ordering of buffered records
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// KeyOrderConfig configures per-key ordered delivery.
+type KeyOrderConfig struct {
+	// Key is the attribute identifying the entity whose records must be
+	// delivered in order, e.g. "order_id". It is required.
+	Key string
+
+	// Delay is how long a record is held after its timestamp, waiting for
+	// earlier records of concurrent producers; 10ms when zero. It should
+	// exceed the usual delay between creating a record and handling it.
+	Delay time.Duration
+
+	// MaxPending bounds the number of held records; the oldest record is
+	// released early when it is exceeded. Defaults to the buffer capacity.
+	MaxPending int
+}
+
+// WithKeyOrdering guarantees that records carrying cfg.Key are buffered in
+// timestamp order.
+//
+// Records of the same entity logged from different goroutines can reach
+// the buffer out of wall-clock order, because a goroutine may be preempted
+// between creating a record and handing it to the provider. With this option
+// records carrying the key are held for cfg.Delay after their timestamp and
+// released oldest first, so each entity's records appear in the order they
+// were logged:
+//
+//	provider := slogprovider.New(10000, slogprovider.WithKeyOrdering(slogprovider.KeyOrderConfig{
+//	    Key: "order_id",
+//	}))
+//
+// Records without the key are buffered immediately, so ordering between
+// keyed and other records is not guaranteed. Held records are released on
+// Close. An empty Key panics.
+func WithKeyOrdering(cfg KeyOrderConfig) Option {
+	if cfg.Key == "" {
+		panic("slogprovider: WithKeyOrdering requires a key")
+	}
+	if cfg.Delay <= 0 {
+		cfg.Delay = 10 * time.Millisecond
+	}
+	return func(o *options) { o.keyOrder = &cfg }
+}
+
+// keyOrderer holds keyed records until they are due.
+type keyOrderer struct {
+	cfg     KeyOrderConfig
+	mu      sync.Mutex
+	pending orderHeap
+	timer   *time.Timer // Fires when the oldest held record is due
+	closed  bool
+}
+
+// newKeyOrderer creates a keyOrderer for cfg, or nil if cfg is nil.
+func newKeyOrderer(cfg *KeyOrderConfig, capacity int) *keyOrderer {
+	if cfg == nil {
+		return nil
+	}
+	k := &keyOrderer{cfg: *cfg}
+	if k.cfg.MaxPending <= 0 {
+		k.cfg.MaxPending = capacity
+	}
+	k.cfg.MaxPending = max(k.cfg.MaxPending, 1)
+	return k
+}
+
+// keyed reports whether e carries the ordering key.
+func (k *keyOrderer) keyed(e *entry) bool {
+	found := false
+	e.record.Attrs(func(attr slog.Attr) bool {
+		found = attr.Key == k.cfg.Key
+		return !found
+	})
+	return found
+}
+
+// orderByKey holds e until it is due, reporting whether it did.
+//
+// Released records are buffered under the orderer's lock so that concurrent
+// releases cannot reorder them.
+func (p *Provider) orderByKey(e entry) bool {
+	k := p.keyOrder
+	if !k.keyed(&e) {
+		return false
+	}
+	if e.record.Time.IsZero() {
+		e.record.Time = time.Now()
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if k.closed {
+		return false
+	}
+	k.pending.add(e)
+	for k.pending.len() > k.cfg.MaxPending {
+		_ = p.enqueue(k.pending.pop()) // Drops are counted in Stats
+	}
+	k.schedule(p)
+	return true
+}
+
+// releaseKeyOrdered buffers the held records that are due.
+func (p *Provider) releaseKeyOrdered() {
+	k := p.keyOrder
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if k.closed {
+		return
+	}
+	due := time.Now().Add(-k.cfg.Delay)
+	for k.pending.len() > 0 && !k.pending.oldest().After(due) {
+		_ = p.enqueue(k.pending.pop()) // Drops are counted in Stats
+	}
+	k.schedule(p)
+}
+
+// schedule arms the timer for the oldest held record. k.mu must be held.
+func (k *keyOrderer) schedule(p *Provider) {
+	if k.pending.len() == 0 {
+		return
+	}
+	wait := time.Until(k.pending.oldest().Add(k.cfg.Delay))
+	if k.timer == nil {
+		k.timer = time.AfterFunc(wait, p.releaseKeyOrdered)
+		return
+	}
+	k.timer.Reset(wait)
+}
+
+// flushKeyOrdered buffers all held records and stops ordering.
+func (p *Provider) flushKeyOrdered() {
+	k := p.keyOrder
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.closed = true
+	if k.timer != nil {
+		k.timer.Stop()
+	}
+	for k.pending.len() > 0 {
+		_ = p.enqueue(k.pending.pop()) // Best effort: dropped if the buffer is full
+	}
+}
@@ -0,0 +1,73 @@
+// key_order_test.go: Tests for per-key ordered delivery
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func TestWithKeyOrdering_ReordersByTimestamp(t *testing.T) {
+	provider := New(100, WithKeyOrdering(KeyOrderConfig{Key: "order_id", Delay: 20 * time.Millisecond}))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	now := time.Now()
+	late := slog.NewRecord(now.Add(time.Millisecond), slog.LevelInfo, "shipped", 0)
+	late.AddAttrs(slog.Int("order_id", 7))
+	early := slog.NewRecord(now, slog.LevelInfo, "paid", 0)
+	early.AddAttrs(slog.Int("order_id", 7))
+	plain := slog.NewRecord(now, slog.LevelInfo, "unkeyed", 0)
+
+	// The later record is handed over first, as by a preempted producer.
+	for _, record := range []slog.Record{late, early, plain} {
+		if err := provider.Handle(context.Background(), record); err != nil {
+			t.Fatalf("Handle failed: %v", err)
+		}
+	}
+
+	msgs := readMessages(t, provider, 3)
+	if msgs[0] != "unkeyed" || msgs[1] != "paid" || msgs[2] != "shipped" {
+		t.Errorf("Expected keyed records in timestamp order, got %v", msgs)
+	}
+}
+
+func TestWithKeyOrdering_MaxPendingReleasesOldest(t *testing.T) {
+	provider := New(100, WithKeyOrdering(KeyOrderConfig{Key: "k", Delay: time.Hour, MaxPending: 2}))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+	logger := slog.New(provider)
+
+	logger.Info("1", "k", 1)
+	logger.Info("2", "k", 1)
+	if got := provider.queue.len(); got != 0 {
+		t.Fatalf("Expected records held, got %d buffered", got)
+	}
+	logger.Info("3", "k", 1)
+	if msgs := readMessages(t, provider, 1); msgs[0] != "1" {
+		t.Errorf("Expected the oldest record released, got %v", msgs)
+	}
+}
+
+func TestWithKeyOrdering_ReleasedOnClose(t *testing.T) {
+	provider := New(100, WithKeyOrdering(KeyOrderConfig{Key: "k", Delay: time.Hour}))
+	slog.New(provider).Info("held", "k", 1)
+	_ = provider.Close()
+
+	if msgs := readMessages(t, provider, 1); msgs[0] != "held" {
+		t.Errorf("Expected held record after Close, got %v", msgs)
+	}
+}
+
+func TestWithKeyOrdering_RequiresKey(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected a panic for an empty key")
+		}
+	}()
+	WithKeyOrdering(KeyOrderConfig{})
+}
@@ -0,0 +1,184 @@
+// latency.go: End-to-end latency tracking from Handle to Read
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"github.com/agilira/iris"
+)
+
+// LatencyKey is the field attached by WithLatencyTracking to records read
+// later than LatencyConfig.Threshold after being handled.
+const LatencyKey = "log_latency_ms"
+
+// LatencyConfig configures WithLatencyTracking.
+type LatencyConfig struct {
+	// Threshold is the latency from which records carry the LatencyKey
+	// field, in milliseconds. Zero disables the field.
+	Threshold time.Duration
+
+	// Buckets are the upper bounds of the histogram buckets. Defaults to
+	// 100µs, 1ms, 5ms, 10ms, 50ms, 100ms, 500ms, 1s and 5s.
+	Buckets []time.Duration
+}
+
+// LatencyHistogram is the distribution of Handle to Read latencies reported
+// by Provider.Latency.
+type LatencyHistogram struct {
+	// Bounds are the upper bounds of the buckets, ascending.
+	Bounds []time.Duration `json:"bounds"`
+
+	// Counts are the number of records per bucket: Counts[i] counts
+	// latencies up to Bounds[i], and the last entry those above every
+	// bound.
+	Counts []uint64 `json:"counts"`
+
+	// Count is the number of records observed.
+	Count uint64 `json:"count"`
+
+	// Sum is the total latency of the observed records.
+	Sum time.Duration `json:"sum"`
+
+	// Max is the highest observed latency.
+	Max time.Duration `json:"max"`
+}
+
+// Quantile returns an upper bound of the q-quantile latency, 0 <= q <= 1:
+// the bound of the bucket holding it, or Max for the last bucket.
+func (h LatencyHistogram) Quantile(q float64) time.Duration {
+	if h.Count == 0 {
+		return 0
+	}
+	rank := uint64(q * float64(h.Count))
+	var seen uint64
+	for i, n := range h.Counts {
+		seen += n
+		if seen > rank && i < len(h.Bounds) {
+			return min(h.Bounds[i], h.Max)
+		}
+	}
+	return h.Max
+}
+
+// WithLatencyTracking measures how long records wait between Handle and
+// Read, including time held by transactions or grouping, so slow-pipeline
+// incidents are visible:
+//
+//	provider := slogprovider.New(1000, slogprovider.WithLatencyTracking(slogprovider.LatencyConfig{
+//	    Threshold: 100 * time.Millisecond,
+//	}))
+//
+// Provider.Latency reports the latency histogram, and records read later
+// than cfg.Threshold carry the latency in milliseconds in the LatencyKey
+// field, so the delay shows up in the logs themselves. Records created by
+// the provider, such as throttling summaries, are not measured.
+func WithLatencyTracking(cfg LatencyConfig) Option {
+	if cfg.Buckets == nil {
+		cfg.Buckets = []time.Duration{
+			100 * time.Microsecond, time.Millisecond, 5 * time.Millisecond, 10 * time.Millisecond,
+			50 * time.Millisecond, 100 * time.Millisecond, 500 * time.Millisecond, time.Second, 5 * time.Second,
+		}
+	}
+	cfg.Buckets = append([]time.Duration(nil), cfg.Buckets...)
+	sort.Slice(cfg.Buckets, func(i, j int) bool { return cfg.Buckets[i] < cfg.Buckets[j] })
+	return func(o *options) { o.latency = &cfg }
+}
+
+// latencyEpoch is the origin of the monotonic handle times in entries.
+var latencyEpoch = time.Now()
+
+// latencyTracker implements WithLatencyTracking.
+type latencyTracker struct {
+	cfg    LatencyConfig
+	counts []atomic.Uint64 // One per bucket, plus the overflow bucket
+	count  atomic.Uint64
+	sum    atomic.Int64
+	max    atomic.Int64
+}
+
+// newLatencyTracker creates the tracker for cfg, or nil if cfg is nil.
+func newLatencyTracker(cfg *LatencyConfig) *latencyTracker {
+	if cfg == nil {
+		return nil
+	}
+	return &latencyTracker{cfg: *cfg, counts: make([]atomic.Uint64, len(cfg.Buckets)+1)}
+}
+
+// stamp returns the handle time of a record handled now.
+func (l *latencyTracker) stamp() int64 {
+	return int64(time.Since(latencyEpoch)) + 1 // Never 0, which marks unmeasured entries
+}
+
+// since returns the latency of an entry stamped with handled.
+func (l *latencyTracker) since(handled int64) time.Duration {
+	return max(time.Since(latencyEpoch)-time.Duration(handled-1), 0)
+}
+
+// observe adds the latency of e to the histogram.
+func (l *latencyTracker) observe(e *entry) {
+	if e.handled == 0 {
+		return
+	}
+	latency := l.since(e.handled)
+	i := sort.Search(len(l.cfg.Buckets), func(i int) bool { return latency <= l.cfg.Buckets[i] })
+	l.counts[i].Add(1)
+	l.count.Add(1)
+	l.sum.Add(int64(latency))
+	for {
+		highest := l.max.Load()
+		if int64(latency) <= highest || l.max.CompareAndSwap(highest, int64(latency)) {
+			return
+		}
+	}
+}
+
+// field returns the LatencyKey field of e if its latency reached the
+// threshold.
+func (l *latencyTracker) field(e *entry) (iris.Field, bool) {
+	if l.cfg.Threshold <= 0 || e.handled == 0 {
+		return iris.Field{}, false
+	}
+	latency := l.since(e.handled)
+	if latency < l.cfg.Threshold {
+		return iris.Field{}, false
+	}
+	return iris.Float64(LatencyKey, float64(latency)/float64(time.Millisecond)), true
+}
+
+// reset clears the histogram.
+func (l *latencyTracker) reset() {
+	for i := range l.counts {
+		l.counts[i].Store(0)
+	}
+	l.count.Store(0)
+	l.sum.Store(0)
+	l.max.Store(0)
+}
+
+// Latency returns the histogram of Handle to Read latencies measured with
+// WithLatencyTracking since creation or the last ResetCounters. It is empty
+// without that option.
+func (p *Provider) Latency() LatencyHistogram {
+	l := p.latency
+	if l == nil {
+		return LatencyHistogram{}
+	}
+	h := LatencyHistogram{
+		Bounds: append([]time.Duration(nil), l.cfg.Buckets...),
+		Counts: make([]uint64, len(l.counts)),
+		Count:  l.count.Load(),
+		Sum:    time.Duration(l.sum.Load()),
+		Max:    time.Duration(l.max.Load()),
+	}
+	for i := range l.counts {
+		h.Counts[i] = l.counts[i].Load()
+	}
+	return h
+}
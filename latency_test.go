@@ -0,0 +1,74 @@
+// latency_test.go: Tests for Handle to Read latency tracking
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func TestWithLatencyTracking_Histogram(t *testing.T) {
+	provider := New(10, WithLatencyTracking(LatencyConfig{Buckets: []time.Duration{time.Hour, 10 * time.Millisecond}}))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	logger := slog.New(provider)
+	logger.Info("fast")
+	readMessages(t, provider, 1)
+	logger.Info("slow")
+	time.Sleep(20 * time.Millisecond)
+	readMessages(t, provider, 1)
+
+	h := provider.Latency()
+	if h.Count != 2 || len(h.Counts) != 3 || h.Counts[0] != 1 || h.Counts[1] != 1 {
+		t.Fatalf("Unexpected histogram %+v", h)
+	}
+	if h.Bounds[0] != 10*time.Millisecond || h.Max < 20*time.Millisecond || h.Sum < h.Max {
+		t.Errorf("Unexpected histogram %+v", h)
+	}
+	if q := h.Quantile(0.99); q != h.Max {
+		t.Errorf("Quantile(0.99) = %v, want the bucket bound capped at Max %v", q, h.Max)
+	}
+	if q := h.Quantile(0); q != 10*time.Millisecond {
+		t.Errorf("Quantile(0) = %v, want 10ms", q)
+	}
+
+	provider.ResetCounters()
+	if h := provider.Latency(); h.Count != 0 || h.Max != 0 {
+		t.Errorf("Expected ResetCounters to clear the histogram, got %+v", h)
+	}
+}
+
+func TestWithLatencyTracking_ThresholdField(t *testing.T) {
+	provider := New(10, WithLatencyTracking(LatencyConfig{Threshold: 10 * time.Millisecond}))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	fast := readRecord(t, provider, func(l *slog.Logger) { l.Info("fast") })
+	if _, ok := findField(fast, LatencyKey); ok {
+		t.Error("Expected no latency field below the threshold")
+	}
+
+	slog.New(provider).Info("slow")
+	time.Sleep(15 * time.Millisecond)
+	slow := readWithTimeout(t, provider)
+	if f, ok := findField(slow, LatencyKey); !ok || f.FloatValue() < 15 {
+		t.Errorf("%s = %v, want at least 15ms", LatencyKey, f)
+	}
+}
+
+func TestProvider_LatencyWithoutTracking(t *testing.T) {
+	provider := New(10)
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	record := readRecord(t, provider, func(l *slog.Logger) { l.Info("untracked") })
+	if _, ok := findField(record, LatencyKey); ok {
+		t.Error("Expected no latency field without WithLatencyTracking")
+	}
+	if h := provider.Latency(); h.Count != 0 || h.Quantile(0.5) != 0 {
+		t.Errorf("Expected an empty histogram, got %+v", h)
+	}
+}
@@ -0,0 +1,202 @@
+// levels.go: Minimum levels, per-logger level overrides, level names and mapping
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/agilira/iris"
+)
+
+// WithMinLevel sets the default minimum level for records accepted by the
+// provider. Records below it are rejected by Enabled and Handle.
+//
+// Passing a *slog.LevelVar allows the level to be changed at runtime.
+// Without this option every level is accepted and filtering is left to Iris.
+func WithMinLevel(level slog.Leveler) Option {
+	return func(o *options) { o.minLevel = level }
+}
+
+// WithLevelOverrides sets minimum levels per logger name, similar to
+// logback/log4j category levels.
+//
+// A logger's name is its dotted group path: slog.New(provider).WithGroup("db")
+// is named "db", and a further WithGroup("pool") names it "db.pool". A rule
+// applies to its name and every name below it; the longest matching rule
+// wins, and loggers without a matching rule use WithMinLevel.
+//
+//	provider := slogprovider.New(1000,
+//	    slogprovider.WithMinLevel(slog.LevelDebug),
+//	    slogprovider.WithLevelOverrides(map[string]slog.Leveler{
+//	        "db":   slog.LevelWarn,
+//	        "http": slog.LevelInfo,
+//	    }),
+//	)
+//
+// Rules are resolved once when a handler is derived, so the per-record cost
+// is a single comparison. WithLevelOverrides may be given several times;
+// later rules for the same name replace earlier ones.
+func WithLevelOverrides(overrides map[string]slog.Leveler) Option {
+	return func(o *options) {
+		if o.levelOverrides == nil {
+			o.levelOverrides = make(map[string]slog.Leveler, len(overrides))
+		}
+		for name, level := range overrides {
+			o.levelOverrides[name] = level
+		}
+	}
+}
+
+// levelFor resolves the effective minimum level for the logger name.
+func (o *options) levelFor(name string) slog.Leveler {
+	level := o.minLevel
+	best := -1
+	for prefix, l := range o.levelOverrides {
+		if len(prefix) > best && matchesLoggerName(name, prefix) {
+			level, best = l, len(prefix)
+		}
+	}
+	return level
+}
+
+// matchesLoggerName reports whether the rule prefix applies to name.
+func matchesLoggerName(name, prefix string) bool {
+	if prefix == "" || name == prefix {
+		return true
+	}
+	return strings.HasPrefix(name, prefix) && name[len(prefix)] == '.'
+}
+
+// LevelNameKey is the field key carrying the name of a level registered with
+// WithLevelNames.
+const LevelNameKey = "level_name"
+
+// WithLevelNames registers display names for custom slog levels:
+//
+//	const (
+//	    LevelTrace  = slog.Level(-8)
+//	    LevelNotice = slog.Level(2)
+//	)
+//	provider := slogprovider.New(1000, slogprovider.WithLevelNames(map[slog.Level]string{
+//	    LevelTrace:  "TRACE",
+//	    LevelNotice: "NOTICE",
+//	}))
+//
+// Iris only knows its own levels, so records at a named level are converted
+// with the nearest Iris level as usual and carry the name in a LevelNameKey
+// field, e.g. level_name="NOTICE" instead of the "INFO+2" slog would show.
+// Names are also used wherever the provider renders levels, such as DumpJSON.
+// WithLevelNames may be given several times; later names for the same level
+// replace earlier ones.
+func WithLevelNames(names map[slog.Level]string) Option {
+	return func(o *options) {
+		if o.levelNames == nil {
+			o.levelNames = make(map[slog.Level]string, len(names))
+		}
+		for level, name := range names {
+			o.levelNames[level] = name
+		}
+	}
+}
+
+// levelName returns the display name of level: its registered name, or the
+// slog name otherwise.
+func (o *options) levelName(level slog.Level) string {
+	if name, ok := o.levelNames[level]; ok {
+		return name
+	}
+	return level.String()
+}
+
+// SlogLevelKey is the field key carrying the numeric slog level.
+const SlogLevelKey = "slog_level"
+
+// WithSlogLevel attaches the numeric slog level of every record under
+// SlogLevelKey. Level mapping is lossy (slog.LevelInfo+1 and slog.LevelWarn
+// both become iris.Warn), so the field lets downstream analysis recover the
+// fine-grained severity.
+func WithSlogLevel() Option {
+	return func(o *options) { o.slogLevel = true }
+}
+
+// LevelMapper maps slog levels to Iris levels, overriding the default
+// mapping for exactly the listed levels.
+type LevelMapper map[slog.Level]iris.Level
+
+// WithLevelMapper maps the listed slog levels to the given Iris levels instead
+// of collapsing them into the Debug, Info, Warn and Error buckets, including
+// Iris levels above Error:
+//
+//	provider := slogprovider.New(1000, slogprovider.WithLevelMapper(slogprovider.LevelMapper{
+//	    LevelNotice:         iris.Info,
+//	    slog.LevelError + 4: iris.DPanic,
+//	    slog.LevelError + 8: iris.Fatal,
+//	}))
+//
+// Levels without an entry use the default mapping. Targets are validated
+// when the option is created: WithLevelMapper panics if a target is not a
+// level Iris defines. Mapping to Panic or Fatal only changes the level
+// shown by Iris encoders; the provider never panics or exits because of it.
+// WithLevelMapper may be given several times; later entries for the same
+// level replace earlier ones.
+func WithLevelMapper(mapper LevelMapper) Option {
+	for level, target := range mapper {
+		if target < iris.Debug || target > iris.Fatal {
+			panic(fmt.Sprintf("slogprovider: level mapper target %d for slog level %v is not an Iris level", target, level))
+		}
+	}
+	return func(o *options) {
+		if o.levelMapper == nil {
+			o.levelMapper = make(LevelMapper, len(mapper))
+		}
+		for level, target := range mapper {
+			o.levelMapper[level] = target
+		}
+	}
+}
+
+// LevelHook decides the Iris level of a record. It receives the level
+// produced by the default mapping and WithLevelMapper, and returns the level
+// to use.
+type LevelHook func(record slog.Record, mapped iris.Level) iris.Level
+
+// WithLevelHook installs a hook that escalates or demotes individual records
+// at the bridge, so severity policy lives in one place:
+//
+//	promote := func(r slog.Record, mapped iris.Level) iris.Level {
+//	    if r.Message == "payment failed" {
+//	        return iris.Error
+//	    }
+//	    return mapped
+//	}
+//	provider := slogprovider.New(1000, slogprovider.WithLevelHook(promote))
+//
+// The hook runs on the Read path during conversion, after level filtering,
+// so records must pass WithMinLevel and WithLevelOverrides with their slog
+// level. Results that are not Iris levels are ignored. The hook must be safe
+// for concurrent use.
+func WithLevelHook(hook LevelHook) Option {
+	return func(o *options) { o.levelHook = hook }
+}
+
+// recordLevel returns the Iris level of record.
+func (p *Provider) recordLevel(record slog.Record) iris.Level {
+	level := p.convertLevel(record.Level)
+	if p.opts.levelHook != nil {
+		if hooked := p.opts.levelHook(record, level); hooked >= iris.Debug && hooked <= iris.Fatal {
+			level = hooked
+		}
+	}
+	return level
+}
+
+// levelEnabled reports whether level passes the minimum min (nil accepts all).
+func levelEnabled(min slog.Leveler, level slog.Level) bool {
+	return min == nil || level >= min.Level()
+}
@@ -0,0 +1,208 @@
+// levels_test.go: Tests for minimum levels, per-logger overrides and level names
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agilira/iris"
+)
+
+func TestWithLevelOverrides(t *testing.T) {
+	provider := New(10,
+		WithMinLevel(slog.LevelDebug),
+		WithLevelOverrides(map[string]slog.Leveler{
+			"db":   slog.LevelWarn,
+			"http": slog.LevelInfo,
+		}),
+	)
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	ctx := context.Background()
+	root := slog.New(provider)
+	tests := []struct {
+		name   string
+		logger *slog.Logger
+		level  slog.Level
+		want   bool
+	}{
+		{"root debug", root, slog.LevelDebug, true},
+		{"db info", root.WithGroup("db"), slog.LevelInfo, false},
+		{"db warn", root.WithGroup("db"), slog.LevelWarn, true},
+		{"db.pool inherits db", root.WithGroup("db").WithGroup("pool"), slog.LevelInfo, false},
+		{"dbx is not db", root.WithGroup("dbx"), slog.LevelDebug, true},
+		{"http debug", root.WithGroup("http"), slog.LevelDebug, false},
+		{"http info", root.WithGroup("http"), slog.LevelInfo, true},
+	}
+	for _, tt := range tests {
+		if got := tt.logger.Enabled(ctx, tt.level); got != tt.want {
+			t.Errorf("%s: Enabled() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestWithLevelOverrides_LongestPrefixWins(t *testing.T) {
+	provider := New(10, WithLevelOverrides(map[string]slog.Leveler{
+		"db":      slog.LevelError,
+		"db.pool": slog.LevelDebug,
+	}))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	pool := slog.New(provider).WithGroup("db").WithGroup("pool")
+	pool.Debug("connection acquired")
+	slog.New(provider).WithGroup("db").Warn("slow query")
+
+	if got := provider.queue.len(); got != 1 {
+		t.Errorf("buffered %d records, want 1", got)
+	}
+}
+
+func TestHandle_EnforcesMinLevel(t *testing.T) {
+	level := new(slog.LevelVar)
+	level.Set(slog.LevelWarn)
+	provider := New(10, WithMinLevel(level))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	ctx := context.Background()
+	_ = provider.Handle(ctx, slog.NewRecord(time.Time{}, slog.LevelInfo, "ignored", 0))
+	level.Set(slog.LevelInfo)
+	_ = provider.Handle(ctx, slog.NewRecord(time.Time{}, slog.LevelInfo, "kept", 0))
+
+	if got := provider.queue.len(); got != 1 {
+		t.Errorf("buffered %d records, want 1", got)
+	}
+}
+
+func TestWithLevelNames_CarriesNameIntoRecord(t *testing.T) {
+	const levelNotice = slog.Level(2)
+	provider := New(10,
+		WithLevelNames(map[slog.Level]string{levelNotice: "wrong"}),
+		WithLevelNames(map[slog.Level]string{levelNotice: "NOTICE", slog.Level(-8): "TRACE"}),
+	)
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	record := readRecord(t, provider, func(logger *slog.Logger) {
+		logger.Log(context.Background(), levelNotice, "notice")
+	})
+	if record.Level != iris.Warn {
+		t.Errorf("Expected nearest Iris level warn, got %v", record.Level)
+	}
+	field, ok := findField(record, LevelNameKey)
+	if !ok || field.StringValue() != "NOTICE" {
+		t.Errorf("Expected %s=NOTICE, got %v", LevelNameKey, field)
+	}
+
+	record = readRecord(t, provider, func(logger *slog.Logger) { logger.Info("plain") })
+	if _, ok := findField(record, LevelNameKey); ok {
+		t.Error("Expected no level name for unnamed levels")
+	}
+}
+
+func TestWithLevelNames_DumpJSON(t *testing.T) {
+	provider := New(10,
+		WithMinLevel(slog.Level(-8)),
+		WithLevelNames(map[slog.Level]string{slog.Level(-8): "TRACE"}),
+	)
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	var buf bytes.Buffer
+	if err := provider.DumpJSON(&buf); err != nil {
+		t.Fatalf("DumpJSON failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), `"min_level": "TRACE"`) {
+		t.Errorf("Expected named min level in dump, got %s", buf.String())
+	}
+}
+
+func TestWithLevelMapper_MapsListedLevels(t *testing.T) {
+	provider := New(10, WithLevelMapper(LevelMapper{
+		slog.LevelInfo + 2:  iris.Info,
+		slog.LevelError + 8: iris.Fatal,
+	}))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	tests := []struct {
+		level slog.Level
+		want  iris.Level
+	}{
+		{slog.LevelInfo + 2, iris.Info},
+		{slog.LevelError + 8, iris.Fatal},
+		{slog.LevelInfo + 1, iris.Warn}, // Default mapping
+		{slog.LevelError + 4, iris.Error},
+	}
+	for _, tt := range tests {
+		record := readRecord(t, provider, func(logger *slog.Logger) {
+			logger.Log(context.Background(), tt.level, "message")
+		})
+		if record.Level != tt.want {
+			t.Errorf("slog level %v mapped to %v, want %v", tt.level, record.Level, tt.want)
+		}
+	}
+}
+
+func TestWithLevelMapper_RejectsInvalidTargets(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected WithLevelMapper to panic for an invalid target")
+		}
+	}()
+	WithLevelMapper(LevelMapper{slog.LevelInfo: iris.Level(42)})
+}
+
+func TestWithLevelHook_RemapsRecords(t *testing.T) {
+	promote := func(r slog.Record, mapped iris.Level) iris.Level {
+		switch r.Message {
+		case "payment failed":
+			return iris.Error
+		case "invalid":
+			return iris.Level(99)
+		}
+		return mapped
+	}
+	provider := New(10, WithLevelHook(promote))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	tests := []struct {
+		msg  string
+		want iris.Level
+	}{
+		{"payment failed", iris.Error},
+		{"payment ok", iris.Info},
+		{"invalid", iris.Info}, // Out-of-range results are ignored
+	}
+	for _, tt := range tests {
+		record := readRecord(t, provider, func(logger *slog.Logger) { logger.Info(tt.msg) })
+		if record.Level != tt.want {
+			t.Errorf("%q mapped to %v, want %v", tt.msg, record.Level, tt.want)
+		}
+	}
+}
+
+func TestWithSlogLevel(t *testing.T) {
+	provider := New(10, WithSlogLevel())
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	record := readRecord(t, provider, func(logger *slog.Logger) {
+		logger.Log(context.Background(), slog.LevelInfo+1, "fine-grained")
+	})
+	if record.Level != iris.Warn {
+		t.Errorf("Expected mapped level warn, got %v", record.Level)
+	}
+	if field, ok := findField(record, SlogLevelKey); !ok || field.IntValue() != int64(slog.LevelInfo+1) {
+		t.Errorf("Expected %s=%d, got %v", SlogLevelKey, slog.LevelInfo+1, field)
+	}
+
+	converted := ConvertRecord(slog.NewRecord(time.Time{}, slog.LevelError, "m", 0), WithSlogLevel())
+	if field, ok := findField(converted, SlogLevelKey); !ok || field.IntValue() != int64(slog.LevelError) {
+		t.Errorf("Expected ConvertRecord to honor WithSlogLevel, got %v", field)
+	}
+}
@@ -0,0 +1,170 @@
+// memory.go: Memory-pressure-aware buffer shrinking and sampling
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"log/slog"
+	"math"
+	"runtime/debug"
+	"runtime/metrics"
+	"sync/atomic"
+	"time"
+)
+
+// MemoryPressureConfig configures WithMemoryPressure. Zero fields take the
+// documented defaults.
+type MemoryPressureConfig struct {
+	// Limit is the memory budget in bytes. Defaults to the Go soft memory
+	// limit (debug.SetMemoryLimit, GOMEMLIMIT); when neither is set the
+	// option is disabled.
+	Limit uint64
+
+	// HighWatermark is the fraction of Limit at which the provider enters
+	// the pressure state. Defaults to 0.85.
+	HighWatermark float64
+
+	// LowWatermark is the fraction of Limit below which the provider leaves
+	// the pressure state again. Defaults to HighWatermark - 0.1.
+	LowWatermark float64
+
+	// Interval is the polling interval. Defaults to one second.
+	Interval time.Duration
+
+	// ShrinkTo is the fraction of the buffer capacity usable under
+	// pressure. Defaults to 0.25.
+	ShrinkTo float64
+
+	// KeepLevel is the minimum level always admitted under pressure.
+	// Defaults to slog.LevelWarn; set it explicitly to keep info records.
+	KeepLevel *slog.Level
+
+	// SampleEvery admits one in SampleEvery records below KeepLevel under
+	// pressure. Defaults to 10.
+	SampleEvery int
+
+	// Usage reports the current memory usage in bytes. Defaults to the
+	// memory governed by the Go memory limit (total mapped memory minus
+	// memory released to the OS), read with runtime/metrics.
+	Usage func() uint64
+}
+
+// WithMemoryPressure makes the provider back off when the process approaches
+// its memory limit, rather than contributing to an OOM kill.
+//
+// A supervised goroutine polls memory usage. Above the high watermark the
+// provider shrinks its usable buffer to cfg.ShrinkTo of its capacity (buffered
+// records are kept and drained normally, but fewer new ones are accepted) and
+// samples records below cfg.KeepLevel. Records dropped by this sampling are
+// counted in Stats().PressureSampled, and Stats().MemoryPressure reports the
+// current state. Normal operation resumes below the low watermark.
+func WithMemoryPressure(cfg MemoryPressureConfig) Option {
+	return func(o *options) { o.memory = &cfg }
+}
+
+// memoryMonitor implements WithMemoryPressure.
+type memoryMonitor struct {
+	cfg      MemoryPressureConfig
+	keep     slog.Level
+	high     uint64
+	low      uint64
+	pressure atomic.Bool
+	counter  atomic.Uint64
+}
+
+// newMemoryMonitor applies the defaults to cfg and returns the monitor, or
+// nil when disabled or no memory limit is known.
+func newMemoryMonitor(cfg *MemoryPressureConfig) *memoryMonitor {
+	if cfg == nil {
+		return nil
+	}
+	c := *cfg
+	if c.Limit == 0 {
+		limit := debug.SetMemoryLimit(-1)
+		if limit <= 0 || limit == math.MaxInt64 {
+			return nil
+		}
+		c.Limit = uint64(limit)
+	}
+	if c.HighWatermark <= 0 || c.HighWatermark > 1 {
+		c.HighWatermark = 0.85
+	}
+	if c.LowWatermark <= 0 || c.LowWatermark >= c.HighWatermark {
+		c.LowWatermark = c.HighWatermark - 0.1
+	}
+	if c.Interval <= 0 {
+		c.Interval = time.Second
+	}
+	if c.ShrinkTo <= 0 || c.ShrinkTo > 1 {
+		c.ShrinkTo = 0.25
+	}
+	if c.SampleEvery <= 0 {
+		c.SampleEvery = 10
+	}
+	if c.Usage == nil {
+		c.Usage = runtimeMemoryUsage
+	}
+
+	m := &memoryMonitor{
+		cfg:  c,
+		keep: slog.LevelWarn,
+		high: uint64(float64(c.Limit) * c.HighWatermark),
+		low:  uint64(float64(c.Limit) * c.LowWatermark),
+	}
+	if c.KeepLevel != nil {
+		m.keep = *c.KeepLevel
+	}
+	return m
+}
+
+// run polls memory usage until the provider is closed.
+func (m *memoryMonitor) run(p *Provider) {
+	ticker := time.NewTicker(m.cfg.Interval)
+	defer ticker.Stop()
+	for {
+		m.poll(p)
+		select {
+		case <-p.closed:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// poll updates the pressure state and the usable buffer capacity.
+func (m *memoryMonitor) poll(p *Provider) {
+	usage := m.cfg.Usage()
+	under := m.pressure.Load()
+	switch {
+	case !under && usage >= m.high:
+		m.pressure.Store(true)
+		p.queue.setLimit(max(1, int(float64(p.queue.cap())*m.cfg.ShrinkTo)))
+	case under && usage < m.low:
+		m.pressure.Store(false)
+		p.queue.setLimit(p.queue.cap())
+	}
+}
+
+// admit applies pressure sampling to a record at level.
+func (m *memoryMonitor) admit(level slog.Level) bool {
+	if !m.pressure.Load() || level >= m.keep {
+		return true
+	}
+	return m.counter.Add(1)%uint64(m.cfg.SampleEvery) == 1 // #nosec G115 -- SampleEvery is positive
+}
+
+// runtimeMemoryUsage returns the memory governed by the Go memory limit.
+func runtimeMemoryUsage() uint64 {
+	samples := []metrics.Sample{
+		{Name: "/memory/classes/total:bytes"},
+		{Name: "/memory/classes/heap/released:bytes"},
+	}
+	metrics.Read(samples)
+	if samples[0].Value.Kind() != metrics.KindUint64 || samples[1].Value.Kind() != metrics.KindUint64 {
+		return 0
+	}
+	return samples[0].Value.Uint64() - samples[1].Value.Uint64()
+}
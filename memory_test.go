@@ -0,0 +1,138 @@
+// memory_test.go: Tests for memory-pressure-aware buffer shrinking
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"context"
+	"log/slog"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeUsage is a settable memory usage source.
+type fakeUsage struct{ bytes atomic.Uint64 }
+
+func (f *fakeUsage) usage() uint64 { return f.bytes.Load() }
+
+// waitForPressure polls until the provider's pressure state equals want.
+func waitForPressure(t *testing.T, provider *Provider, want bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for provider.Stats().MemoryPressure != want {
+		if time.Now().After(deadline) {
+			t.Fatalf("MemoryPressure did not become %v", want)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestWithMemoryPressure_ShrinksBufferAndSamples(t *testing.T) {
+	usage := &fakeUsage{}
+	provider := New(100, WithMemoryPressure(MemoryPressureConfig{
+		Limit:       1000,
+		Interval:    time.Millisecond,
+		ShrinkTo:    0.1,
+		SampleEvery: 2,
+		Usage:       usage.usage,
+	}))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+	logger := slog.New(provider)
+
+	usage.bytes.Store(900)
+	waitForPressure(t, provider, true)
+
+	for i := 0; i < 20; i++ {
+		logger.Info("sampled")
+	}
+	stats := provider.Stats()
+	if stats.PressureSampled != 10 {
+		t.Errorf("Expected 10 info records sampled out, got %d", stats.PressureSampled)
+	}
+	if stats.Buffered != 10 {
+		t.Errorf("Expected shrunk buffer to hold 10 records, got %d", stats.Buffered)
+	}
+
+	logger.Error("kept")
+	if provider.Stats().PressureSampled != 10 {
+		t.Error("Expected error records to bypass pressure sampling")
+	}
+	if provider.Dropped() == 0 {
+		t.Error("Expected records beyond the shrunk capacity to be dropped")
+	}
+	if err := provider.Verify(); err != nil {
+		t.Errorf("Verify failed under pressure: %v", err)
+	}
+}
+
+func TestWithMemoryPressure_RecoversBelowLowWatermark(t *testing.T) {
+	usage := &fakeUsage{}
+	provider := New(100, WithMemoryPressure(MemoryPressureConfig{
+		Limit:    1000,
+		Interval: time.Millisecond,
+		Usage:    usage.usage,
+	}))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	usage.bytes.Store(900)
+	waitForPressure(t, provider, true)
+
+	// Between the watermarks the state is kept.
+	usage.bytes.Store(800)
+	time.Sleep(10 * time.Millisecond)
+	if !provider.Stats().MemoryPressure {
+		t.Fatal("Expected pressure to persist above the low watermark")
+	}
+
+	usage.bytes.Store(100)
+	waitForPressure(t, provider, false)
+
+	logger := slog.New(provider)
+	for i := 0; i < 100; i++ {
+		logger.Info("normal", "i", i)
+	}
+	if got := provider.Stats().Buffered; got != 100 {
+		t.Errorf("Expected full capacity after recovery, got %d buffered", got)
+	}
+	if provider.Stats().PressureSampled != 0 {
+		t.Error("Expected no sampling after recovery")
+	}
+}
+
+func TestWithMemoryPressure_KeepLevel(t *testing.T) {
+	usage := &fakeUsage{}
+	usage.bytes.Store(1000)
+	keep := slog.LevelInfo
+	provider := New(10, WithMemoryPressure(MemoryPressureConfig{
+		Limit:     1000,
+		Interval:  time.Millisecond,
+		KeepLevel: &keep,
+		Usage:     usage.usage,
+	}))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+	waitForPressure(t, provider, true)
+
+	record := readRecord(t, provider, func(logger *slog.Logger) {
+		logger.Info("kept")
+	})
+	if record.Msg != "kept" {
+		t.Errorf("Expected info record to be kept, got %q", record.Msg)
+	}
+}
+
+func TestWithMemoryPressure_DisabledWithoutLimit(t *testing.T) {
+	provider := New(10, WithMemoryPressure(MemoryPressureConfig{}))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	if provider.memory != nil {
+		t.Skip("process has a memory limit configured")
+	}
+	slog.New(provider).Info("message")
+	if _, err := provider.Read(context.Background()); err != nil {
+		t.Fatalf("Read failed: %v", err)
+	}
+}
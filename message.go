@@ -0,0 +1,103 @@
+// message.go: Message prefixing and templating per logger
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"log/slog"
+	"strings"
+)
+
+// Placeholders expanded by WithMessageTemplate.
+const (
+	TemplateLogger  = "{logger}"
+	TemplateLevel   = "{level}"
+	TemplateMessage = "{message}"
+)
+
+// messageTemplate is a parsed WithMessageTemplate template.
+type messageTemplate struct {
+	parts     []string // Literal text and placeholders, in order
+	namedOnly bool     // Only apply to records of named loggers
+}
+
+// WithMessagePrefix prefixes the messages of named loggers with their group
+// path, e.g. "[db] connection reset" for slog.New(provider).WithGroup("db").
+// Records of the root logger are unchanged. It is a shorthand for a
+// "[{logger}] {message}" template applied to named loggers only.
+func WithMessagePrefix() Option {
+	tmpl := parseMessageTemplate("[" + TemplateLogger + "] " + TemplateMessage)
+	tmpl.namedOnly = true
+	return func(o *options) { o.message = tmpl }
+}
+
+// WithMessageTemplate rewrites converted messages with a template in which
+// TemplateLogger, TemplateLevel and TemplateMessage are replaced by the
+// logger's group path, the slog level (as named by WithLevelNames) and the
+// original message:
+//
+//	provider := slogprovider.New(1000, slogprovider.WithMessageTemplate("{level} {logger}: {message}"))
+//
+// This aids human scanning of text-encoded output; structured fields are
+// not affected. The template is applied during conversion, so filters,
+// sampling and throttling see the original message.
+func WithMessageTemplate(template string) Option {
+	tmpl := parseMessageTemplate(template)
+	return func(o *options) { o.message = tmpl }
+}
+
+// parseMessageTemplate splits template into literals and placeholders.
+func parseMessageTemplate(template string) *messageTemplate {
+	tmpl := &messageTemplate{}
+	for template != "" {
+		i := strings.IndexByte(template, '{')
+		if i < 0 {
+			tmpl.parts = append(tmpl.parts, template)
+			break
+		}
+		placeholder := ""
+		for _, p := range []string{TemplateLogger, TemplateLevel, TemplateMessage} {
+			if strings.HasPrefix(template[i:], p) {
+				placeholder = p
+				break
+			}
+		}
+		if placeholder == "" {
+			tmpl.parts = append(tmpl.parts, template[:i+1])
+			template = template[i+1:]
+			continue
+		}
+		if i > 0 {
+			tmpl.parts = append(tmpl.parts, template[:i])
+		}
+		tmpl.parts = append(tmpl.parts, placeholder)
+		template = template[i+len(placeholder):]
+	}
+	return tmpl
+}
+
+// formatMessage returns the converted message of a record logged through the
+// logger name.
+func (p *Provider) formatMessage(name string, record slog.Record) string {
+	tmpl := p.opts.message
+	if tmpl == nil || (tmpl.namedOnly && name == "") {
+		return record.Message
+	}
+	var b strings.Builder
+	for _, part := range tmpl.parts {
+		switch part {
+		case TemplateLogger:
+			b.WriteString(name)
+		case TemplateLevel:
+			b.WriteString(p.opts.levelName(record.Level))
+		case TemplateMessage:
+			b.WriteString(record.Message)
+		default:
+			b.WriteString(part)
+		}
+	}
+	return b.String()
+}
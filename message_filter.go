@@ -0,0 +1,158 @@
+// message_filter.go: Message pattern filters (regexp and glob)
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+)
+
+// FilterAction is the decision taken when a message rule matches.
+type FilterAction int
+
+const (
+	// DropMessage drops records whose message matches the rule.
+	DropMessage FilterAction = iota
+	// KeepMessage keeps records whose message matches the rule.
+	KeepMessage
+)
+
+// MessageRule matches record messages by regular expression or glob.
+//
+// Exactly one of Regexp and Glob must be set. Glob patterns support '*'
+// (any sequence of characters) and '?' (any single character) and must match
+// the whole message; regular expressions use RE2 syntax and match anywhere
+// unless anchored.
+type MessageRule struct {
+	Action FilterAction
+	Regexp string
+	Glob   string
+}
+
+// MessageFilter is a precompiled, immutable set of message rules.
+//
+// Rules are evaluated in order and the first matching rule decides. Messages
+// matching no rule are kept, unless every rule is a KeepMessage rule, in which
+// case the filter acts as an allow-list and unmatched messages are dropped.
+//
+// MessageFilter is safe for concurrent use.
+type MessageFilter struct {
+	rules       []compiledMessageRule
+	defaultKeep bool
+}
+
+// compiledMessageRule is a MessageRule reduced to a match function.
+type compiledMessageRule struct {
+	keep  bool
+	match func(msg string) bool
+}
+
+// NewMessageFilter compiles rules into a MessageFilter.
+//
+// Patterns are compiled once here so that evaluation in Handle only pays for
+// matching. Simple globs ("exact", "prefix*", "*suffix", "*infix*") are
+// matched with string operations instead of regular expressions.
+func NewMessageFilter(rules ...MessageRule) (*MessageFilter, error) {
+	f := &MessageFilter{}
+	for i, rule := range rules {
+		var match func(string) bool
+		switch {
+		case rule.Regexp != "" && rule.Glob != "":
+			return nil, fmt.Errorf("message rule %d: both Regexp and Glob set", i)
+		case rule.Regexp != "":
+			re, err := regexp.Compile(rule.Regexp)
+			if err != nil {
+				return nil, fmt.Errorf("message rule %d: %w", i, err)
+			}
+			match = re.MatchString
+		case rule.Glob != "":
+			match = compileGlob(rule.Glob)
+		default:
+			return nil, fmt.Errorf("message rule %d: no pattern set", i)
+		}
+
+		keep := rule.Action == KeepMessage
+		if !keep {
+			f.defaultKeep = true
+		}
+		f.rules = append(f.rules, compiledMessageRule{keep: keep, match: match})
+	}
+	if len(f.rules) == 0 {
+		f.defaultKeep = true
+	}
+	return f, nil
+}
+
+// Allow reports whether a record with message msg should be kept.
+func (f *MessageFilter) Allow(msg string) bool {
+	for _, rule := range f.rules {
+		if rule.match(msg) {
+			return rule.keep
+		}
+	}
+	return f.defaultKeep
+}
+
+// WithMessageFilter drops records in Handle according to f.
+//
+//	filter, err := slogprovider.NewMessageFilter(
+//	    slogprovider.MessageRule{Action: slogprovider.DropMessage, Glob: "grpc: addrConn.*"},
+//	    slogprovider.MessageRule{Action: slogprovider.DropMessage, Regexp: `^retrying in \d+ms$`},
+//	)
+//	if err != nil {
+//	    return err
+//	}
+//	provider := slogprovider.New(1000, slogprovider.WithMessageFilter(filter))
+func WithMessageFilter(f *MessageFilter) Option {
+	if f == nil {
+		return nil
+	}
+	return WithFilter(func(record slog.Record) bool {
+		return f.Allow(record.Message)
+	})
+}
+
+// compileGlob turns a glob pattern into a whole-string match function. The
+// wildcards match any character, including newlines of multi-line messages.
+func compileGlob(glob string) func(string) bool {
+	if !strings.ContainsAny(glob, "?") {
+		inner := strings.Trim(glob, "*")
+		if !strings.Contains(inner, "*") {
+			prefix := strings.HasPrefix(glob, "*")
+			suffix := strings.HasSuffix(glob, "*")
+			switch {
+			case inner == "":
+				return func(string) bool { return true }
+			case prefix && suffix:
+				return func(msg string) bool { return strings.Contains(msg, inner) }
+			case suffix:
+				return func(msg string) bool { return strings.HasPrefix(msg, inner) }
+			case prefix:
+				return func(msg string) bool { return strings.HasSuffix(msg, inner) }
+			default:
+				return func(msg string) bool { return msg == inner }
+			}
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString(`^(?s)`)
+	for _, r := range glob {
+		switch r {
+		case '*':
+			b.WriteString(`.*`)
+		case '?':
+			b.WriteString(`.`)
+		default:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	b.WriteString(`$`)
+	return regexp.MustCompile(b.String()).MatchString
+}
@@ -0,0 +1,113 @@
+// message_filter_test.go: Tests and benchmarks for message pattern filters
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"log/slog"
+	"testing"
+)
+
+func TestMessageFilter_DropRules(t *testing.T) {
+	f, err := NewMessageFilter(
+		MessageRule{Action: DropMessage, Glob: "grpc: addrConn.*"},
+		MessageRule{Action: DropMessage, Regexp: `^retrying in \d+ms$`},
+		MessageRule{Action: DropMessage, Glob: "conn ?? closed"},
+	)
+	if err != nil {
+		t.Fatalf("NewMessageFilter() error = %v", err)
+	}
+
+	tests := map[string]bool{
+		"grpc: addrConn.createTransport failed": false,
+		"retrying in 250ms":                     false,
+		"conn 42 closed":                        false,
+		"conn 4242 closed":                      true,
+		"user login":                            true,
+	}
+	for msg, want := range tests {
+		if got := f.Allow(msg); got != want {
+			t.Errorf("Allow(%q) = %v, want %v", msg, got, want)
+		}
+	}
+}
+
+func TestMessageFilter_KeepRulesActAsAllowList(t *testing.T) {
+	f, err := NewMessageFilter(MessageRule{Action: KeepMessage, Glob: "*payment*"})
+	if err != nil {
+		t.Fatalf("NewMessageFilter() error = %v", err)
+	}
+	if !f.Allow("payment failed") || f.Allow("cache miss") {
+		t.Error("keep-only filter must allow matches and drop everything else")
+	}
+}
+
+func TestMessageFilter_GlobsMatchMultiLineMessages(t *testing.T) {
+	msg := "panic: boom\ngoroutine 1 [running]:\nmain.main()"
+	for _, glob := range []string{"panic*", "*main()", "*goroutine*", "panic*goroutine*main()", "panic: boom?goroutine*"} {
+		f, err := NewMessageFilter(MessageRule{Action: DropMessage, Glob: glob})
+		if err != nil {
+			t.Fatalf("NewMessageFilter(%q) error = %v", glob, err)
+		}
+		if f.Allow(msg) {
+			t.Errorf("Glob %q does not match the multi-line message", glob)
+		}
+	}
+}
+
+func TestMessageFilter_InvalidRules(t *testing.T) {
+	invalid := []MessageRule{
+		{Regexp: "("},
+		{},
+		{Regexp: "a", Glob: "b"},
+	}
+	for _, rule := range invalid {
+		if _, err := NewMessageFilter(rule); err == nil {
+			t.Errorf("NewMessageFilter(%+v) returned no error", rule)
+		}
+	}
+}
+
+func TestWithMessageFilter(t *testing.T) {
+	f, _ := NewMessageFilter(MessageRule{Action: DropMessage, Glob: "noisy*"})
+	provider := New(10, WithMessageFilter(f), WithMessageFilter(nil))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	logger := slog.New(provider)
+	logger.Info("noisy library chatter")
+	logger.Info("useful")
+
+	if got := provider.queue.len(); got != 1 {
+		t.Errorf("buffered %d records, want 1", got)
+	}
+}
+
+func BenchmarkMessageFilter_Glob(b *testing.B) {
+	f, _ := NewMessageFilter(MessageRule{Action: DropMessage, Glob: "grpc: addrConn.*"})
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = f.Allow("grpc: addrConn.createTransport failed to connect")
+	}
+}
+
+func BenchmarkMessageFilter_Regexp(b *testing.B) {
+	f, _ := NewMessageFilter(MessageRule{Action: DropMessage, Regexp: `^retrying in \d+ms$`})
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = f.Allow("retrying in 250ms")
+	}
+}
+
+func BenchmarkMessageFilter_NoMatch(b *testing.B) {
+	f, _ := NewMessageFilter(
+		MessageRule{Action: DropMessage, Glob: "grpc: addrConn.*"},
+		MessageRule{Action: DropMessage, Regexp: `^retrying in \d+ms$`},
+	)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = f.Allow("user login succeeded")
+	}
+}
@@ -0,0 +1,109 @@
+// message_rewrite.go: Message rewriting table applied before buffering
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// MessageRewrite replaces record messages matching a pattern.
+//
+// Exactly one of Exact and Regexp must be set. Exact matches the whole
+// message; regular expressions use RE2 syntax and match anywhere unless
+// anchored. The whole message is replaced by Replacement, in which $1,
+// ${name} and the like expand to the submatches of Regexp, as with
+// regexp.Regexp.Expand.
+type MessageRewrite struct {
+	Exact       string
+	Regexp      string
+	Replacement string
+}
+
+// MessageRewriter is a precompiled, immutable table of message rewrites.
+//
+// Rewrites are evaluated in order and the first matching one decides;
+// messages matching none are kept. MessageRewriter is safe for concurrent
+// use.
+type MessageRewriter struct {
+	rewrites []compiledMessageRewrite
+	exact    map[string]int // Index of the first Exact rewrite of each message
+}
+
+// compiledMessageRewrite is a MessageRewrite with its regular expression
+// compiled.
+type compiledMessageRewrite struct {
+	re          *regexp.Regexp // nil for Exact rewrites
+	replacement string
+}
+
+// NewMessageRewriter compiles rewrites into a MessageRewriter.
+func NewMessageRewriter(rewrites ...MessageRewrite) (*MessageRewriter, error) {
+	r := &MessageRewriter{exact: make(map[string]int)}
+	for i, rewrite := range rewrites {
+		compiled := compiledMessageRewrite{replacement: rewrite.Replacement}
+		switch {
+		case rewrite.Exact != "" && rewrite.Regexp != "":
+			return nil, fmt.Errorf("message rewrite %d: both Exact and Regexp set", i)
+		case rewrite.Regexp != "":
+			re, err := regexp.Compile(rewrite.Regexp)
+			if err != nil {
+				return nil, fmt.Errorf("message rewrite %d: %w", i, err)
+			}
+			compiled.re = re
+		case rewrite.Exact != "":
+			if _, ok := r.exact[rewrite.Exact]; !ok {
+				r.exact[rewrite.Exact] = i
+			}
+		default:
+			return nil, fmt.Errorf("message rewrite %d: no pattern set", i)
+		}
+		r.rewrites = append(r.rewrites, compiled)
+	}
+	return r, nil
+}
+
+// Rewrite returns the rewritten msg and true, or msg and false if no rewrite
+// matches it.
+func (r *MessageRewriter) Rewrite(msg string) (string, bool) {
+	exact, isExact := r.exact[msg]
+	for i, rewrite := range r.rewrites {
+		if isExact && i == exact {
+			return rewrite.replacement, true
+		}
+		if rewrite.re == nil {
+			continue
+		}
+		if match := rewrite.re.FindStringSubmatchIndex(msg); match != nil {
+			return string(rewrite.re.ExpandString(nil, rewrite.replacement, msg, match)), true
+		}
+	}
+	return msg, false
+}
+
+// WithMessageRewrites normalizes messages with r in Handle, before filters,
+// sampling and throttling see them, so known noisy or misleading
+// third-party messages can be mapped into the application's taxonomy at the
+// bridge:
+//
+//	rewriter, err := slogprovider.NewMessageRewriter(
+//	    slogprovider.MessageRewrite{Exact: "transport is closing", Replacement: "grpc connection closed"},
+//	    slogprovider.MessageRewrite{Regexp: `^retrying in (\d+)ms$`, Replacement: "retry scheduled after ${1}ms"},
+//	)
+//	if err != nil {
+//	    return err
+//	}
+//	provider := slogprovider.New(1000, slogprovider.WithMessageRewrites(rewriter))
+//
+// Level checks run before rewriting, so WithLevelOverrides and WithMinLevel
+// are unaffected.
+func WithMessageRewrites(r *MessageRewriter) Option {
+	if r == nil {
+		return nil
+	}
+	return func(o *options) { o.rewriter = r }
+}
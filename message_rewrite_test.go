@@ -0,0 +1,78 @@
+// message_rewrite_test.go: Tests for message rewriting
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"log/slog"
+	"testing"
+)
+
+func TestMessageRewriter(t *testing.T) {
+	r, err := NewMessageRewriter(
+		MessageRewrite{Exact: "transport is closing", Replacement: "grpc connection closed"},
+		MessageRewrite{Regexp: `^retrying in (\d+)ms$`, Replacement: "retry scheduled after ${1}ms"},
+		MessageRewrite{Regexp: `(?P<code>5\d\d)`, Replacement: "upstream error $code"},
+		MessageRewrite{Exact: "retrying in 10ms", Replacement: "shadowed by the regexp above"},
+	)
+	if err != nil {
+		t.Fatalf("NewMessageRewriter failed: %v", err)
+	}
+
+	tests := []struct {
+		msg  string
+		want string
+		ok   bool
+	}{
+		{"transport is closing", "grpc connection closed", true},
+		{"retrying in 250ms", "retry scheduled after 250ms", true},
+		{"retrying in 10ms", "retry scheduled after 10ms", true},
+		{"got status 503 from backend", "upstream error 503", true},
+		{"transport is closing now", "transport is closing now", false},
+	}
+	for _, tt := range tests {
+		if got, ok := r.Rewrite(tt.msg); got != tt.want || ok != tt.ok {
+			t.Errorf("Rewrite(%q) = %q, %v, want %q, %v", tt.msg, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestNewMessageRewriter_Errors(t *testing.T) {
+	for _, rewrite := range []MessageRewrite{
+		{Replacement: "no pattern"},
+		{Exact: "a", Regexp: "b"},
+		{Regexp: "("},
+	} {
+		if _, err := NewMessageRewriter(rewrite); err == nil {
+			t.Errorf("Expected error for %+v", rewrite)
+		}
+	}
+}
+
+func TestWithMessageRewrites(t *testing.T) {
+	r, err := NewMessageRewriter(MessageRewrite{Regexp: `^conn \d+ reset$`, Replacement: "connection reset"})
+	if err != nil {
+		t.Fatalf("NewMessageRewriter failed: %v", err)
+	}
+	filter, err := NewMessageFilter(MessageRule{Action: DropMessage, Glob: "connection reset"})
+	if err != nil {
+		t.Fatalf("NewMessageFilter failed: %v", err)
+	}
+
+	provider := New(10, WithMessageRewrites(r))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+	record := readRecord(t, provider, func(l *slog.Logger) { l.Info("conn 42 reset") })
+	if record.Msg != "connection reset" {
+		t.Errorf("Msg = %q, want the rewritten message", record.Msg)
+	}
+
+	filtered := New(10, WithMessageRewrites(r), WithMessageFilter(filter))
+	defer func() { _ = filtered.Close() }() // Ignore error in test cleanup
+	slog.New(filtered).Info("conn 7 reset")
+	if n := filtered.Len(); n != 0 {
+		t.Errorf("Expected filters to see the rewritten message, got %d buffered", n)
+	}
+}
@@ -0,0 +1,63 @@
+// message_test.go: Tests for message prefixing and templating
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+)
+
+func TestWithMessagePrefix(t *testing.T) {
+	provider := New(10, WithMessagePrefix())
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	record := readRecord(t, provider, func(logger *slog.Logger) {
+		logger.WithGroup("db").WithGroup("pool").Info("connection reset", "attempt", 2)
+	})
+	if record.Msg != "[db.pool] connection reset" {
+		t.Errorf("Expected prefixed message, got %q", record.Msg)
+	}
+	if _, ok := findField(record, "db.pool.attempt"); !ok {
+		t.Error("Expected structured fields to be kept")
+	}
+
+	record = readRecord(t, provider, func(logger *slog.Logger) { logger.Info("root") })
+	if record.Msg != "root" {
+		t.Errorf("Expected root logger messages unchanged, got %q", record.Msg)
+	}
+}
+
+func TestWithMessageTemplate(t *testing.T) {
+	provider := New(10,
+		WithMessageTemplate("{level} {logger}: {message} {unknown}"),
+		WithLevelNames(map[slog.Level]string{slog.Level(2): "NOTICE"}),
+	)
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	record := readRecord(t, provider, func(logger *slog.Logger) {
+		logger.WithGroup("http").Log(context.Background(), slog.Level(2), "slow request")
+	})
+	if want := "NOTICE http: slow request {unknown}"; record.Msg != want {
+		t.Errorf("Expected %q, got %q", want, record.Msg)
+	}
+}
+
+func TestWithMessageTemplate_FiltersSeeOriginalMessage(t *testing.T) {
+	provider := New(10,
+		WithMessagePrefix(),
+		WithFilter(func(r slog.Record) bool { return r.Message == "kept" }),
+	)
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	record := readRecord(t, provider, func(logger *slog.Logger) {
+		logger.WithGroup("db").Info("kept")
+	})
+	if record.Msg != "[db] kept" {
+		t.Errorf("Expected filtered record to be prefixed, got %q", record.Msg)
+	}
+}
@@ -0,0 +1,182 @@
+// metrics_only.go: Counting records instead of forwarding them
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"log/slog"
+	"regexp"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// MetricsOtherKey is the message of the series collecting records beyond
+// MetricsOnlyConfig.MaxSeries.
+const MetricsOtherKey = "(other)"
+
+// MetricsOnlyConfig selects the records counted instead of forwarded.
+type MetricsOnlyConfig struct {
+	// MaxLevel selects records at or below this level, e.g. slog.LevelDebug
+	// for high-volume debug events. Nil selects every level.
+	MaxLevel *slog.Level
+
+	// Patterns selects records whose message matches one of these patterns,
+	// globs unless prefixed with "re:" as in LoadRules. Records are counted
+	// per matching pattern, so messages with variable parts share a series.
+	// When empty, every message is selected and counted per message.
+	Patterns []string
+
+	// MaxSeries bounds the number of distinct series; further records are
+	// counted under MetricsOtherKey. Defaults to 1000.
+	MaxSeries int
+}
+
+// MetricSeries is the count of one level and message (or pattern).
+type MetricSeries struct {
+	Level   slog.Level `json:"level"`
+	Message string     `json:"message"`
+	Count   uint64     `json:"count"`
+}
+
+// WithMetricsOnly counts the records selected by cfg per level and message
+// instead of forwarding them to Iris, for ultra-high-volume events where
+// rates matter rather than text:
+//
+//	debug := slog.LevelDebug
+//	provider := slogprovider.New(1000, slogprovider.WithMetricsOnly(slogprovider.MetricsOnlyConfig{
+//	    MaxLevel: &debug,
+//	    Patterns: []string{"cache hit*", "re:^poll \\d+$"},
+//	}))
+//
+// Selected records pass the level, filter, sampling and rule checks and are
+// then counted and discarded; they are not buffered and do not appear in
+// Handled. Counts are cumulative and read with Metrics; their total is
+// Stats().MetricsOnly. An invalid pattern panics.
+func WithMetricsOnly(cfg MetricsOnlyConfig) Option {
+	counter := newMetricsCounter(cfg)
+	return func(o *options) { o.metricsOnly = counter }
+}
+
+// metricsCounter counts the selected records per series.
+type metricsCounter struct {
+	maxLevel *slog.Level
+	patterns []metricsPattern
+	max      int
+
+	mu     sync.RWMutex
+	series map[metricKey]*atomic.Uint64
+}
+
+// metricsPattern is a compiled MetricsOnlyConfig pattern.
+type metricsPattern struct {
+	pattern string
+	match   func(string) bool
+}
+
+// metricKey identifies a series.
+type metricKey struct {
+	level   slog.Level
+	message string
+}
+
+// newMetricsCounter compiles cfg.
+func newMetricsCounter(cfg MetricsOnlyConfig) *metricsCounter {
+	c := &metricsCounter{
+		max:    cfg.MaxSeries,
+		series: make(map[metricKey]*atomic.Uint64),
+	}
+	if cfg.MaxLevel != nil {
+		level := *cfg.MaxLevel
+		c.maxLevel = &level
+	}
+	if c.max <= 0 {
+		c.max = 1000
+	}
+	for _, pattern := range cfg.Patterns {
+		rule := messageRuleSetting(pattern)
+		match := compileGlob(rule.Glob)
+		if rule.Regexp != "" {
+			re, err := regexp.Compile(rule.Regexp)
+			if err != nil {
+				panic("slogprovider: invalid metrics pattern: " + err.Error())
+			}
+			match = re.MatchString
+		}
+		c.patterns = append(c.patterns, metricsPattern{pattern: pattern, match: match})
+	}
+	return c
+}
+
+// count counts record if it is selected, reporting whether it was.
+func (c *metricsCounter) count(record slog.Record) bool {
+	if c.maxLevel != nil && record.Level > *c.maxLevel {
+		return false
+	}
+	key := metricKey{level: record.Level, message: record.Message}
+	if len(c.patterns) > 0 {
+		matched := false
+		for _, p := range c.patterns {
+			if p.match(record.Message) {
+				key.message, matched = p.pattern, true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	c.mu.RLock()
+	n := c.series[key]
+	c.mu.RUnlock()
+	if n == nil {
+		n = c.add(key)
+	}
+	n.Add(1)
+	return true
+}
+
+// add returns the counter of key, creating it if the series limit allows.
+func (c *metricsCounter) add(key metricKey) *atomic.Uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if n := c.series[key]; n != nil {
+		return n
+	}
+	if len(c.series) >= c.max {
+		key.message = MetricsOtherKey
+		if n := c.series[key]; n != nil {
+			return n
+		}
+	}
+	n := new(atomic.Uint64)
+	c.series[key] = n
+	return n
+}
+
+// Metrics returns the counts of the records counted by WithMetricsOnly,
+// ordered by level and message, or nil when the option is not set.
+func (p *Provider) Metrics() []MetricSeries {
+	c := p.opts.metricsOnly
+	if c == nil {
+		return nil
+	}
+	c.mu.RLock()
+	series := make([]MetricSeries, 0, len(c.series))
+	for key, n := range c.series {
+		series = append(series, MetricSeries{Level: key.level, Message: key.message, Count: n.Load()})
+	}
+	c.mu.RUnlock()
+
+	sort.Slice(series, func(i, j int) bool {
+		if series[i].Level != series[j].Level {
+			return series[i].Level < series[j].Level
+		}
+		return series[i].Message < series[j].Message
+	})
+	return series
+}
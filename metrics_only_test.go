@@ -0,0 +1,97 @@
+// metrics_only_test.go: Tests for metrics-only mode
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"log/slog"
+	"reflect"
+	"testing"
+)
+
+func TestWithMetricsOnly_CountsInsteadOfForwarding(t *testing.T) {
+	debug := slog.LevelDebug
+	provider := New(100, WithMinLevel(slog.LevelDebug), WithMetricsOnly(MetricsOnlyConfig{MaxLevel: &debug}))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+	logger := slog.New(provider)
+
+	logger.Debug("cache hit")
+	logger.Debug("cache hit")
+	logger.Debug("cache miss")
+	logger.Info("request served")
+
+	want := []MetricSeries{
+		{Level: slog.LevelDebug, Message: "cache hit", Count: 2},
+		{Level: slog.LevelDebug, Message: "cache miss", Count: 1},
+	}
+	if got := provider.Metrics(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Metrics() = %+v, want %+v", got, want)
+	}
+	stats := provider.Stats()
+	if stats.MetricsOnly != 3 || stats.Handled != 1 || stats.Buffered != 1 {
+		t.Errorf("Expected only the info record buffered, got %+v", stats)
+	}
+	if err := provider.Verify(); err != nil {
+		t.Errorf("Verify failed: %v", err)
+	}
+}
+
+func TestWithMetricsOnly_Patterns(t *testing.T) {
+	provider := New(100, WithMetricsOnly(MetricsOnlyConfig{Patterns: []string{"poll *", `re:^tick \d+$`}}))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+	logger := slog.New(provider)
+
+	logger.Info("poll orders")
+	logger.Info("poll users")
+	logger.Warn("tick 42")
+	logger.Info("other")
+
+	want := []MetricSeries{
+		{Level: slog.LevelInfo, Message: "poll *", Count: 2},
+		{Level: slog.LevelWarn, Message: `re:^tick \d+$`, Count: 1},
+	}
+	if got := provider.Metrics(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Metrics() = %+v, want %+v", got, want)
+	}
+	if got := provider.Len(); got != 1 {
+		t.Errorf("Expected the unmatched record buffered, got %d", got)
+	}
+}
+
+func TestWithMetricsOnly_MaxSeries(t *testing.T) {
+	provider := New(100, WithMetricsOnly(MetricsOnlyConfig{MaxSeries: 1}))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+	logger := slog.New(provider)
+
+	logger.Info("a")
+	logger.Info("b")
+	logger.Info("c")
+
+	want := []MetricSeries{
+		{Level: slog.LevelInfo, Message: MetricsOtherKey, Count: 2},
+		{Level: slog.LevelInfo, Message: "a", Count: 1},
+	}
+	if got := provider.Metrics(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Metrics() = %+v, want %+v", got, want)
+	}
+}
+
+func TestWithMetricsOnly_InvalidPattern(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected a panic for an invalid pattern")
+		}
+	}()
+	WithMetricsOnly(MetricsOnlyConfig{Patterns: []string{"re:("}})
+}
+
+func TestMetrics_DisabledByDefault(t *testing.T) {
+	provider := New(10)
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+	if got := provider.Metrics(); got != nil {
+		t.Errorf("Expected nil metrics, got %+v", got)
+	}
+}
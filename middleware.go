@@ -0,0 +1,46 @@
+// middleware.go: Read-path record mutation middleware
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import "github.com/agilira/iris"
+
+// RecordMiddleware rewrites a converted record on the Read path.
+//
+// Middleware runs on the Iris reader goroutine after conversion, so it can
+// work with the converted form (field counts, Iris field types) without
+// slowing down slog callers. It may modify the record in place, return a
+// different record, or return nil to drop the record.
+type RecordMiddleware func(record *iris.Record) *iris.Record
+
+// WithRecordMiddleware appends middleware to the Read path. Middleware runs
+// in the order given, across all WithRecordMiddleware options; nil entries
+// are ignored.
+//
+//	tagService := func(r *iris.Record) *iris.Record {
+//	    r.Logger = "billing"
+//	    return r
+//	}
+//	provider := slogprovider.New(1000, slogprovider.WithRecordMiddleware(tagService))
+func WithRecordMiddleware(middleware ...RecordMiddleware) Option {
+	return func(o *options) {
+		for _, mw := range middleware {
+			if mw != nil {
+				o.middleware = append(o.middleware, mw)
+			}
+		}
+	}
+}
+
+// applyMiddleware runs the middleware chain, stopping when a record is dropped.
+func (o *options) applyMiddleware(record *iris.Record) *iris.Record {
+	for _, mw := range o.middleware {
+		if record = mw(record); record == nil {
+			return nil
+		}
+	}
+	return record
+}
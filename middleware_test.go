@@ -0,0 +1,55 @@
+// middleware_test.go: Tests for Read-path record middleware
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"log/slog"
+	"testing"
+
+	"github.com/agilira/iris"
+)
+
+func TestWithRecordMiddleware_RewritesInOrder(t *testing.T) {
+	countFields := func(r *iris.Record) *iris.Record {
+		r.AddField(iris.Int("field_count", r.FieldCount()))
+		return r
+	}
+	rename := func(r *iris.Record) *iris.Record {
+		r.Logger = "billing"
+		return r
+	}
+	provider := New(10, WithRecordMiddleware(countFields, nil, rename))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	record := readRecord(t, provider, func(l *slog.Logger) { l.Info("charge", "a", 1, "b", 2) })
+	if record.Logger != "billing" {
+		t.Errorf("Logger = %q, want billing", record.Logger)
+	}
+	// The record time, a and b.
+	if f, ok := findField(record, "field_count"); !ok || f.IntValue() != 3 {
+		t.Errorf("field_count = %v, want 3", f.IntValue())
+	}
+}
+
+func TestWithRecordMiddleware_NilDropsRecord(t *testing.T) {
+	dropDebug := func(r *iris.Record) *iris.Record {
+		if r.Level == iris.Debug {
+			return nil
+		}
+		return r
+	}
+	provider := New(10, WithRecordMiddleware(dropDebug))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	record := readRecord(t, provider, func(l *slog.Logger) {
+		l.Debug("dropped")
+		l.Info("kept")
+	})
+	if record.Msg != "kept" {
+		t.Errorf("Read() = %q, want the record after the dropped one", record.Msg)
+	}
+}
@@ -0,0 +1,93 @@
+// namespace.go: Per-provider namespacing of converted field keys
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"encoding/json"
+
+	"github.com/agilira/iris"
+)
+
+// NamespaceConfig configures the field namespacing enabled by WithNamespace.
+type NamespaceConfig struct {
+	// Prefix names the provider's namespace, e.g. "billing". It is required.
+	Prefix string
+
+	// Separator joins Prefix and field keys; "." when empty.
+	Separator string
+
+	// Group wraps all fields in a single field named Prefix instead of
+	// prefixing each key.
+	Group bool
+}
+
+// WithNamespace namespaces the fields of converted records, so that several
+// bridges feeding one Iris logger do not collide on keys such as "source":
+//
+//	billing := slogprovider.New(1000, slogprovider.WithNamespace(slogprovider.NamespaceConfig{Prefix: "billing"}))
+//	// source="api" becomes billing.source="api"
+//
+// By default every key is prefixed. With Group, the fields are wrapped in a
+// single field named Prefix; since Iris fields are flat, its value is the
+// JSON object of the fields, e.g. billing="{\"source\":\"api\"}", with
+// Secret fields redacted.
+//
+// Namespacing applies to every field produced by conversion, including
+// SequenceKey, the slog.TimeKey record time, LevelNameKey, the journald
+// MESSAGE_ID and enricher fields. Fields added later by middleware or
+// WithAcknowledgement are not namespaced. WithNamespace panics if cfg.Prefix
+// is empty.
+func WithNamespace(cfg NamespaceConfig) Option {
+	if cfg.Prefix == "" {
+		panic("slogprovider: WithNamespace requires a prefix")
+	}
+	if cfg.Separator == "" {
+		cfg.Separator = "."
+	}
+	return func(o *options) { o.namespace = &cfg }
+}
+
+// apply namespaces the fields of record in place.
+func (c *NamespaceConfig) apply(record *iris.Record) {
+	n := record.FieldCount()
+	if n == 0 {
+		return
+	}
+	fields := make(namespacedFields, n)
+	for i := range fields {
+		fields[i] = record.GetField(i)
+	}
+
+	level, msg, logger, caller, stack := record.Level, record.Msg, record.Logger, record.Caller, record.Stack
+	record.Reset()
+	record.Level, record.Msg, record.Logger, record.Caller, record.Stack = level, msg, logger, caller, stack
+
+	if c.Group {
+		record.AddField(iris.Stringer(c.Prefix, fields))
+		return
+	}
+	for _, field := range fields {
+		field.K = c.Prefix + c.Separator + field.K
+		record.AddField(field)
+	}
+}
+
+// namespacedFields renders grouped fields as a JSON object.
+type namespacedFields []iris.Field
+
+// String implements fmt.Stringer.
+func (f namespacedFields) String() string {
+	object := make(map[string]any, len(f))
+	for _, field := range f {
+		object[field.Key()] = ToSlogAttr(field).Value.Any()
+	}
+	data, err := json.Marshal(object)
+	if err != nil {
+		return "{}"
+	}
+	return string(data)
+}
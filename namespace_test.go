@@ -0,0 +1,87 @@
+// namespace_test.go: Tests for field key namespacing
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func TestWithNamespace_PrefixesKeys(t *testing.T) {
+	provider := New(100, WithSequence(), WithNamespace(NamespaceConfig{Prefix: "billing"}))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	record := readRecord(t, provider, func(logger *slog.Logger) {
+		logger.Warn("charge failed", "source", "api", "attempt", 2)
+	})
+
+	if record.Msg != "charge failed" {
+		t.Errorf("Unexpected message %q", record.Msg)
+	}
+	if f, ok := findField(record, "billing.source"); !ok || f.StringValue() != "api" {
+		t.Errorf("Expected billing.source=api, got %v", f)
+	}
+	if f, ok := findField(record, "billing.attempt"); !ok || f.IntValue() != 2 {
+		t.Errorf("Expected a typed billing.attempt field, got %v", f)
+	}
+	if _, ok := findField(record, "billing."+SequenceKey); !ok {
+		t.Error("Expected the sequence field to be namespaced")
+	}
+	if _, ok := findField(record, "source"); ok {
+		t.Error("Unexpected unprefixed field")
+	}
+}
+
+func TestWithNamespace_Group(t *testing.T) {
+	provider := New(100, WithNamespace(NamespaceConfig{Prefix: "billing", Group: true}))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	record := readRecord(t, provider, func(logger *slog.Logger) {
+		logger.Info("charged", "source", "api", "latency", time.Second)
+	})
+
+	if n := record.FieldCount(); n != 1 {
+		t.Fatalf("Expected a single group field, got %d fields", n)
+	}
+	f, ok := findField(record, "billing")
+	if !ok {
+		t.Fatal("Expected a billing field")
+	}
+	var group map[string]any
+	if err := json.Unmarshal([]byte(f.Obj.(namespacedFields).String()), &group); err != nil {
+		t.Fatalf("Group is not a JSON object: %v", err)
+	}
+	if _, ok := group[slog.TimeKey]; !ok {
+		t.Errorf("Expected the record time in the group, got %v", group)
+	}
+	delete(group, slog.TimeKey)
+	if got, want := fmt.Sprint(group), "map[latency:1e+09 source:api]"; got != want {
+		t.Errorf("Group = %s, want %s", got, want)
+	}
+}
+
+func TestConvertRecord_Namespace(t *testing.T) {
+	record := slog.NewRecord(time.Now(), slog.LevelInfo, "msg", 0)
+	record.AddAttrs(slog.String("source", "db"))
+
+	converted := ConvertRecord(record, WithNamespace(NamespaceConfig{Prefix: "db", Separator: "_"}))
+	if f, ok := findField(converted, "db_source"); !ok || f.StringValue() != "db" {
+		t.Errorf("Expected db_source=db, got %v", f)
+	}
+}
+
+func TestWithNamespace_RequiresPrefix(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected WithNamespace to panic without a prefix")
+		}
+	}()
+	WithNamespace(NamespaceConfig{})
+}
@@ -0,0 +1,168 @@
+// ndjson.go: NDJSON export and import of records
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/agilira/iris"
+)
+
+// ExportNDJSON writes the buffered records to w as newline-delimited JSON,
+// oldest first, without consuming them.
+//
+// Records are converted with the rules applied by Read and encoded with the
+// Iris JSON encoder, using the slog record time as timestamp, so the output
+// matches the JSON logs Iris would have written:
+//
+//	{"ts":"2025-09-06T14:30:45.123Z","level":"info","msg":"User action","user":"alice"}
+//
+// This lets records trapped in a wedged process be recovered through a debug
+// endpoint. Schema validation, middleware and hooks are not applied. The
+// buffer is only locked while it is copied; conversion and writing happen
+// without blocking Handle and Read.
+func (p *Provider) ExportNDJSON(w io.Writer) error {
+	return p.Export(w, NDJSONCodec{})
+}
+
+// ndjsonWriter encodes records as Iris JSON lines.
+type ndjsonWriter struct {
+	w       io.Writer
+	encoder *iris.JSONEncoder
+	buf     bytes.Buffer
+}
+
+// newNDJSONWriter returns an ndjsonWriter writing to w.
+func newNDJSONWriter(w io.Writer) *ndjsonWriter {
+	return &ndjsonWriter{w: w, encoder: iris.NewJSONEncoder()}
+}
+
+// write encodes record with timestamp ts, or the current time when ts is
+// zero.
+func (n *ndjsonWriter) write(ts time.Time, record *iris.Record) error {
+	if ts.IsZero() {
+		ts = time.Now()
+	}
+	n.buf.Reset()
+	n.encoder.Encode(record, ts, &n.buf)
+	if _, err := n.w.Write(n.buf.Bytes()); err != nil {
+		return fmt.Errorf("failed to export records: %w", err)
+	}
+	return nil
+}
+
+// ImportNDJSON parses records in the format written by ExportNDJSON (or Iris
+// JSON logs in general) and re-injects them through Handle, returning the
+// number of records handled.
+//
+// This allows re-shipping recovered logs and load-testing Iris configurations
+// with production-shaped data. Imported records pass through the full
+// pipeline, so levels, filters, sampling and throttling apply. The "ts",
+// "level" and "msg" keys become the record time, level and message; all
+// other keys become attributes in their original order. JSON numbers become
+// int64, uint64 or float64 attributes, the first that represents them
+// exactly, and objects and arrays become slog.Any values. Durations and times
+// are not distinguishable in JSON and are imported as numbers and strings.
+// With WithSequence, exported sequence fields are replaced by new ones.
+//
+// Blank lines are skipped. Import stops at the first malformed line, or when
+// Handle fails, e.g. with ErrClosed.
+func (p *Provider) ImportNDJSON(r io.Reader) (int, error) {
+	return p.Import(r, NDJSONCodec{})
+}
+
+// parseNDJSONRecord parses one exported JSON object, preserving key order.
+func parseNDJSONRecord(data []byte) (slog.Record, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return slog.Record{}, errors.New("expected a JSON object")
+	}
+
+	var (
+		ts    = time.Now()
+		level = slog.LevelInfo
+		msg   string
+		attrs []slog.Attr
+	)
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return slog.Record{}, err
+		}
+		key, _ := tok.(string)
+		var value any
+		if err := dec.Decode(&value); err != nil {
+			return slog.Record{}, err
+		}
+
+		switch key {
+		case "ts":
+			if ts, err = parseNDJSONTime(value); err != nil {
+				return slog.Record{}, err
+			}
+		case "level":
+			parsed, err := iris.ParseLevel(fmt.Sprint(value))
+			if err != nil {
+				return slog.Record{}, err
+			}
+			level = toSlogLevel(parsed)
+		case "msg":
+			msg = fmt.Sprint(value)
+		default:
+			attrs = append(attrs, ndjsonAttr(key, value))
+		}
+	}
+	if _, err := dec.Token(); err != nil {
+		return slog.Record{}, err
+	}
+
+	record := slog.NewRecord(ts, level, msg, 0)
+	record.AddAttrs(attrs...)
+	return record, nil
+}
+
+// parseNDJSONTime parses an RFC 3339 or Unix nanosecond timestamp.
+func parseNDJSONTime(value any) (time.Time, error) {
+	switch v := value.(type) {
+	case string:
+		return time.Parse(time.RFC3339Nano, v)
+	case json.Number:
+		ns, err := v.Int64()
+		return time.Unix(0, ns), err
+	default:
+		return time.Time{}, fmt.Errorf("invalid timestamp %v", value)
+	}
+}
+
+// ndjsonAttr converts a decoded JSON value to an attribute.
+func ndjsonAttr(key string, value any) slog.Attr {
+	switch v := value.(type) {
+	case string:
+		return slog.String(key, v)
+	case bool:
+		return slog.Bool(key, v)
+	case json.Number:
+		if i, err := strconv.ParseInt(v.String(), 10, 64); err == nil {
+			return slog.Int64(key, i)
+		}
+		if u, err := strconv.ParseUint(v.String(), 10, 64); err == nil {
+			return slog.Uint64(key, u)
+		}
+		f, _ := v.Float64()
+		return slog.Float64(key, f)
+	default:
+		return slog.Any(key, v)
+	}
+}
@@ -0,0 +1,157 @@
+// ndjson_test.go: Tests for NDJSON export and import
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+// failingWriter fails every write.
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errors.New("disk full") }
+
+func TestExportNDJSON_WritesBufferedRecords(t *testing.T) {
+	provider := New(10, WithSequence())
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	ts := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	for _, msg := range []string{"first", "second"} {
+		record := slog.NewRecord(ts, slog.LevelWarn, msg, 0)
+		record.AddAttrs(slog.String("user", "alice"), slog.Int("n", 3))
+		if err := provider.Handle(context.Background(), record); err != nil {
+			t.Fatalf("Handle failed: %v", err)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := provider.ExportNDJSON(&buf); err != nil {
+		t.Fatalf("ExportNDJSON failed: %v", err)
+	}
+
+	var lines []map[string]any
+	scanner := bufio.NewScanner(&buf)
+	for scanner.Scan() {
+		var line map[string]any
+		if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+			t.Fatalf("Invalid JSON line %q: %v", scanner.Text(), err)
+		}
+		lines = append(lines, line)
+	}
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 lines, got %d", len(lines))
+	}
+	first := lines[0]
+	if first["msg"] != "first" || first["level"] != "warn" || first["user"] != "alice" || first["n"] != 3.0 {
+		t.Errorf("Unexpected first line: %v", first)
+	}
+	if first["ts"] != "2025-01-02T03:04:05Z" {
+		t.Errorf("Expected the slog record time, got %v", first["ts"])
+	}
+	if first[SequenceKey] != 1.0 || lines[1][SequenceKey] != 2.0 {
+		t.Errorf("Expected sequence fields in order, got %v and %v", first[SequenceKey], lines[1][SequenceKey])
+	}
+
+	if provider.Len() != 2 {
+		t.Error("Expected export not to consume records")
+	}
+}
+
+func TestExportNDJSON_WriteError(t *testing.T) {
+	provider := New(10)
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+	slog.New(provider).Info("message")
+
+	if err := provider.ExportNDJSON(failingWriter{}); err == nil {
+		t.Error("Expected write error to be returned")
+	}
+}
+
+func TestImportNDJSON_RoundTrip(t *testing.T) {
+	source := New(10, WithSequence())
+	defer func() { _ = source.Close() }() // Ignore error in test cleanup
+
+	ts := time.Date(2025, 1, 2, 3, 4, 5, 6, time.UTC)
+	record := slog.NewRecord(ts, slog.LevelError, "failed", 0)
+	record.AddAttrs(slog.String("b", "x"), slog.Int("a", -1), slog.Uint64("big", 1<<63), slog.Float64("f", 0.5), slog.Bool("ok", false))
+	if err := source.Handle(context.Background(), record); err != nil {
+		t.Fatalf("Handle failed: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := source.ExportNDJSON(&buf); err != nil {
+		t.Fatalf("ExportNDJSON failed: %v", err)
+	}
+
+	target := New(10, WithSequence())
+	defer func() { _ = target.Close() }() // Ignore error in test cleanup
+	n, err := target.ImportNDJSON(strings.NewReader("\n" + buf.String() + "\n"))
+	if err != nil || n != 1 {
+		t.Fatalf("ImportNDJSON = %d, %v; want 1, nil", n, err)
+	}
+
+	var imported slog.Record
+	target.queue.each(func(e *entry) bool { imported = e.record; return false })
+	if !imported.Time.Equal(ts) || imported.Level != slog.LevelError || imported.Message != "failed" {
+		t.Errorf("Unexpected record header: %v %v %q", imported.Time, imported.Level, imported.Message)
+	}
+
+	var got []string
+	imported.Attrs(func(a slog.Attr) bool {
+		got = append(got, a.Key+"="+a.Value.Kind().String())
+		return true
+	})
+	want := "b=String a=Int64 big=Uint64 f=Float64 ok=Bool"
+	if strings.Join(got, " ") != want {
+		t.Errorf("Attributes = %s, want %s", strings.Join(got, " "), want)
+	}
+}
+
+func TestImportNDJSON_AppliesPipeline(t *testing.T) {
+	provider := New(10, WithMinLevel(slog.LevelWarn))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	input := `{"ts":"2025-01-02T03:04:05Z","level":"info","msg":"dropped"}
+{"ts":1735787045000000000,"level":"warn","msg":"kept","nested":{"k":"v"}}
+`
+	n, err := provider.ImportNDJSON(strings.NewReader(input))
+	if err != nil || n != 2 {
+		t.Fatalf("ImportNDJSON = %d, %v; want 2, nil", n, err)
+	}
+	if provider.Len() != 1 {
+		t.Fatalf("Expected only the warn record to be buffered, got %d", provider.Len())
+	}
+	record := readWithTimeout(t, provider)
+	if record.Msg != "kept" {
+		t.Errorf("Unexpected record %q", record.Msg)
+	}
+}
+
+func TestImportNDJSON_Errors(t *testing.T) {
+	provider := New(10)
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	input := `{"level":"info","msg":"ok"}
+not json
+`
+	n, err := provider.ImportNDJSON(strings.NewReader(input))
+	if err == nil || !strings.Contains(err.Error(), "line 2") || n != 1 {
+		t.Errorf("ImportNDJSON = %d, %v; want 1 and a line 2 error", n, err)
+	}
+
+	_ = provider.Close()
+	if _, err := provider.ImportNDJSON(strings.NewReader(`{"msg":"late"}`)); !errors.Is(err, ErrClosed) {
+		t.Errorf("Expected ErrClosed after Close, got %v", err)
+	}
+}
@@ -0,0 +1,112 @@
+// options.go: Functional options for the slog provider
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"log/slog"
+	"time"
+)
+
+// options holds the optional configuration of a Provider.
+//
+// Options are applied once during construction and are immutable afterwards,
+// so the hot paths (Handle and Read) can consult them without synchronization.
+type options struct {
+	journald      *JournaldConfig      // journald MESSAGE_ID and field naming conventions
+	filters       []RecordFilter       // Predicates evaluated in Handle before buffering
+	sampler       Sampler              // Admission sampling evaluated after filters
+	throttle      *ThrottleConfig      // Per-message throttling evaluated after sampling
+	traceGrouping *TraceGroupingConfig // Contiguous emission of traced records, nil when disabled
+	keyOrder      *KeyOrderConfig      // Per-key timestamp ordering, nil when disabled
+	resequence    *ResequenceConfig    // Read-side timestamp reordering, nil when disabled
+	ack           *AckConfig           // At-least-once delivery, nil when disabled
+	metricsOnly   *metricsCounter      // Records counted instead of forwarded, nil when disabled
+	dryRun        bool                 // Convert and discard records instead of buffering them
+	ttls          []levelTTL           // Per-level times to live, ascending by level
+	readLevel     slog.Leveler         // Minimum level applied at Read, nil for none
+	boost         *BoostConfig         // Error-triggered verbosity boost, nil when disabled
+	encrypt       *encrypter           // Attribute encryption before buffering, nil when disabled
+	strict        *StrictTyping        // Unconvertible value reporting, nil when disabled
+	faults        *FaultPolicy         // Injected faults for resilience testing, nil when disabled
+	chaos         *ChaosConfig         // Random drops and delays, nil when disabled
+
+	converter FieldConverter   // Attribute value conversion, nil for DefaultFieldConverter
+	namespace *NamespaceConfig // Field key namespacing, nil when disabled
+	errClosed bool             // Report ErrClosed from Read at end of stream
+
+	deadlineMargin    time.Duration         // Skip enrichment when the ctx deadline is this close
+	deadlineRemaining bool                  // Attach the time left until the ctx deadline
+	retryGrace        time.Duration         // Retry buffering this long before dropping
+	weight            RecordWeight          // Cost-aware eviction on overflow, nil for drop-newest
+	dropOldest        bool                  // Evict the oldest record on overflow
+	dropPolicy        DropPolicy            // Custom eviction on overflow, nil when disabled
+	capture           *BurstCaptureConfig   // Capture of dropped records, nil when disabled
+	watchdog          *WatchdogConfig       // Consumer stall detection, nil when disabled
+	memory            *MemoryPressureConfig // Memory pressure backoff, nil when disabled
+	backpressure      *BackpressureConfig   // Adaptive admission under Iris backpressure, nil when disabled
+	degradation       *DegradationConfig    // Staged load shedding, nil when disabled
+
+	middleware     []RecordMiddleware // Read-path rewriting applied after conversion
+	enrichers      []Enricher         // Handle-time computed fields
+	sequence       bool               // Stamp a per-provider record index
+	sizeAccounting bool               // Track estimated record sizes in Stats
+	timeline       *TimelineConfig    // Rolling per-interval statistics, nil when disabled
+	latency        *LatencyConfig     // Handle to Read latency tracking, nil when disabled
+	warmUp         bool               // Perform WithWarmUp work at New
+	warmLoggers    []string           // Logger names resolved by the warm-up
+	schema         *Schema            // Expected fields validated after conversion
+	recent         int                // Emitted records kept for LastRecords
+	crashPath      string             // File written by DumpOnPanic, "" for the default
+	banner         bool               // Buffer a configuration record before the first record
+	message        *messageTemplate   // Converted message template, nil to keep messages
+	addSource      bool               // Attach the source location of the logging call
+
+	replaceAttr func([]string, slog.Attr) slog.Attr // Attribute rewriting before buffering, nil for none
+
+	coercion map[string]slog.Kind // Kinds required for attribute values by key, see WithCoercion
+	rewriter *MessageRewriter     // Message rewriting before admission, nil when disabled
+
+	provenance *ProvenanceConfig // Key prefixes by attribute source, nil when disabled
+
+	omitTime bool // Leave the slog record time out of converted records
+
+	clock Clock // Time source of TTLs, throttling and sampling, nil for the system clock
+
+	configSampling *configSampling // Sampler created from Config.Sampling, nil for none
+
+	handleHooks []HandleHook // Called before buffering, may reject
+	emitHooks   []EmitHook   // Called with records returned by Read
+
+	minLevel       slog.Leveler            // Default minimum level, nil for none
+	levelOverrides map[string]slog.Leveler // Minimum levels keyed by group path
+	levelNames     map[slog.Level]string   // Display names of custom levels
+	slogLevel      bool                    // Attach the numeric slog level
+	levelMapper    LevelMapper             // Iris levels of specific slog levels
+	levelHook      LevelHook               // Per-record level decision, nil for none
+}
+
+// Option configures optional Provider behavior.
+//
+// Options use the functional options pattern so new features can be added
+// without breaking the New signature:
+//
+//	provider := slogprovider.New(1000,
+//	    slogprovider.WithJournald(slogprovider.JournaldConfig{UppercaseFields: true}),
+//	)
+type Option func(*options)
+
+// newOptions applies opts in order and returns the resulting configuration.
+// Nil options are ignored so callers can build option slices conditionally.
+func newOptions(opts []Option) options {
+	var o options
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&o)
+		}
+	}
+	return o
+}
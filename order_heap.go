@@ -0,0 +1,77 @@
+// order_heap.go: Timestamp-ordered holding area for buffered entries
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"container/heap"
+	"time"
+)
+
+// orderHeap holds entries ordered by record time, and by arrival among
+// records with the same time, so that releasing from the top yields a
+// stable timestamp order.
+type orderHeap struct {
+	items    []orderedEntry
+	arrivals uint64 // Arrival counter breaking timestamp ties
+	size     int    // Sum of the estimated sizes of held entries
+}
+
+// orderedEntry is an entry held by an orderHeap.
+type orderedEntry struct {
+	e       entry
+	arrival uint64
+}
+
+// add holds e.
+func (h *orderHeap) add(e entry) {
+	h.arrivals++
+	h.size += e.size
+	heap.Push((*orderItems)(&h.items), orderedEntry{e: e, arrival: h.arrivals})
+}
+
+// len returns the number of held entries.
+func (h *orderHeap) len() int {
+	return len(h.items)
+}
+
+// oldest returns the time of the oldest held entry. The heap must not be
+// empty.
+func (h *orderHeap) oldest() time.Time {
+	return h.items[0].e.record.Time
+}
+
+// pop removes and returns the oldest held entry. The heap must not be empty.
+func (h *orderHeap) pop() entry {
+	e := heap.Pop((*orderItems)(&h.items)).(orderedEntry).e
+	h.size -= e.size
+	return e
+}
+
+// orderItems implements heap.Interface for orderHeap.
+type orderItems []orderedEntry
+
+func (s orderItems) Len() int { return len(s) }
+
+func (s orderItems) Less(i, j int) bool {
+	ti, tj := s[i].e.record.Time, s[j].e.record.Time
+	if !ti.Equal(tj) {
+		return ti.Before(tj)
+	}
+	return s[i].arrival < s[j].arrival
+}
+
+func (s orderItems) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+
+func (s *orderItems) Push(x any) { *s = append(*s, x.(orderedEntry)) }
+
+func (s *orderItems) Pop() any {
+	old := *s
+	item := old[len(old)-1]
+	old[len(old)-1] = orderedEntry{} // Release references for the garbage collector
+	*s = old[:len(old)-1]
+	return item
+}
@@ -0,0 +1,29 @@
+// order_heap_test.go: Tests for the timestamp-ordered holding area
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func TestOrderHeap_StableOrder(t *testing.T) {
+	var h orderHeap
+	now := time.Now()
+	for i, offset := range []int{2, 0, 1, 0} {
+		h.add(entry{record: slog.NewRecord(now.Add(time.Duration(offset)), slog.LevelInfo, string(rune('a'+i)), 0)})
+	}
+
+	var got string
+	for h.len() > 0 {
+		got += h.pop().record.Message
+	}
+	if got != "bdca" {
+		t.Errorf("Expected timestamp order with ties by arrival, got %q", got)
+	}
+}
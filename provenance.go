@@ -0,0 +1,117 @@
+// provenance.go: Segregation of attributes by the source that added them
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"context"
+
+	"github.com/agilira/iris"
+)
+
+// ProvenanceConfig configures WithProvenance. Each field is the key prefix
+// of the attributes from one source; an empty prefix leaves those
+// attributes as they are.
+type ProvenanceConfig struct {
+	// Bound prefixes attributes bound with WithAttrs, e.g. "bound".
+	Bound string
+
+	// Context prefixes the attributes added to the record's context with
+	// AppendCtx, e.g. "ctx". When set, the provider adds them itself, and a
+	// ContextHandler wrapping it passes records through unchanged.
+	Context string
+
+	// Enriched prefixes the fields computed by WithEnricher enrichers,
+	// e.g. "meta".
+	Enriched string
+}
+
+// WithProvenance tags attributes with the source that added them, so
+// downstream consumers can tell data supplied at the call site, which keeps
+// its keys, from injected metadata:
+//
+//	provider := slogprovider.New(1000, slogprovider.WithProvenance(slogprovider.ProvenanceConfig{
+//	    Bound:    "bound",
+//	    Context:  "ctx",
+//	    Enriched: "meta",
+//	}))
+//
+// With this configuration, logger.With("service", "api") yields a
+// "bound.service" field, a request_id added with AppendCtx a
+// "ctx.request_id" field and a host enricher a "meta.host" field. Prefixes
+// come before the group path: "bound.req.id" after WithGroup("req"). Context
+// attributes are not qualified by the group path, as they do not belong to
+// the logger, and are encrypted by WithEncryption under their prefixed key.
+func WithProvenance(cfg ProvenanceConfig) Option {
+	return func(o *options) {
+		if cfg == (ProvenanceConfig{}) {
+			o.provenance = nil
+			return
+		}
+		o.provenance = &cfg
+	}
+}
+
+// contextFields returns the AppendCtx attributes of ctx converted under the
+// WithProvenance context prefix, or nil if it is not set.
+func (p *Provider) contextFields(ctx context.Context) ([]iris.Field, error) {
+	prov := p.opts.provenance
+	if prov == nil || prov.Context == "" {
+		return nil, nil
+	}
+	attrs := CtxAttrs(ctx)
+	if len(attrs) == 0 {
+		return nil, nil
+	}
+	fields := make([]iris.Field, 0, len(attrs))
+	for _, attr := range attrs {
+		if c := p.opts.encrypt; c != nil {
+			encrypted, err := c.encryptAttr(ctx, prov.Context, attr)
+			if err != nil {
+				p.stats.encryptionErrors.Add(1)
+				p.reportError(err)
+				return nil, err
+			}
+			attr = encrypted
+		}
+		p.flattenAttr(prov.Context, attr, func(f iris.Field) bool {
+			fields = append(fields, f)
+			return true
+		})
+	}
+	return fields, nil
+}
+
+// tagEnriched prefixes the keys of enricher fields with the WithProvenance
+// enriched prefix, if set.
+func (o *options) tagEnriched(fields []iris.Field) []iris.Field {
+	if o.provenance == nil || o.provenance.Enriched == "" {
+		return fields
+	}
+	for i := range fields {
+		fields[i].K = joinPath(o.provenance.Enriched, fields[i].K)
+	}
+	return fields
+}
+
+// addsContextAttrs reports whether the provider adds the AppendCtx
+// attributes itself, see ProvenanceConfig.Context.
+func (p *Provider) addsContextAttrs() bool {
+	return p.opts.provenance != nil && p.opts.provenance.Context != ""
+}
+
+// addsContextAttrs reports whether the provider adds the AppendCtx
+// attributes itself, see ProvenanceConfig.Context.
+func (h *groupHandler) addsContextAttrs() bool {
+	return h.p.addsContextAttrs()
+}
+
+// addsContextAttrs reports whether the shards add the AppendCtx attributes
+// themselves, see ProvenanceConfig.Context.
+func (h *shardedHandler) addsContextAttrs() bool {
+	adder, ok := h.handlers[0].(contextAttrsAdder)
+	return ok && adder.addsContextAttrs()
+}
@@ -0,0 +1,56 @@
+// provenance_test.go: Tests for attribute provenance tagging
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/agilira/iris"
+)
+
+func TestWithProvenance(t *testing.T) {
+	host := func(context.Context, slog.Record) []iris.Field {
+		return []iris.Field{iris.String("host", "web-1")}
+	}
+	provider := New(10, WithEnricher(host), WithProvenance(ProvenanceConfig{
+		Bound:    "bound",
+		Context:  "ctx",
+		Enriched: "meta",
+	}))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	ctx := AppendCtx(context.Background(), slog.String("request_id", "r-1"))
+	logger := slog.New(NewContextHandler(provider)).With("service", "api").WithGroup("req")
+	logger.InfoContext(ctx, "done", "id", 7)
+	record := readWithTimeout(t, provider)
+
+	for _, key := range []string{"bound.service", "req.id", "ctx.request_id", "meta.host"} {
+		if _, ok := findField(record, key); !ok {
+			t.Errorf("Expected field %q, got %v", key, fieldKeys(record))
+		}
+	}
+	if n := len(fieldKeys(record)); n != 4 {
+		t.Errorf("Expected context attributes added once, got %v", fieldKeys(record))
+	}
+}
+
+func TestWithProvenance_PartialPrefixes(t *testing.T) {
+	provider := New(10, WithProvenance(ProvenanceConfig{Bound: "bound"}))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	ctx := AppendCtx(context.Background(), slog.String("request_id", "r-1"))
+	slog.New(NewContextHandler(provider)).With("service", "api").InfoContext(ctx, "done")
+	record := readWithTimeout(t, provider)
+
+	for _, key := range []string{"bound.service", "request_id"} {
+		if _, ok := findField(record, key); !ok {
+			t.Errorf("Expected field %q, got %v", key, fieldKeys(record))
+		}
+	}
+}
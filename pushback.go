@@ -0,0 +1,76 @@
+// pushback.go: Returning undelivered records to the provider
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/agilira/iris"
+)
+
+// pushback holds records returned with Unread, most recent last.
+type pushback struct {
+	mu      sync.Mutex
+	records []*iris.Record
+	n       atomic.Int32 // len(records), for a lock-free fast path
+}
+
+// Unread returns a record obtained from Read, ReadBatch or LastRecords back
+// to the provider, so that a consumer that is cancelled after reading a
+// record but before using it does not lose it:
+//
+//	record, err := provider.Read(ctx)
+//	if err != nil || record == nil {
+//	    return err
+//	}
+//	if err := ship(ctx, record); err != nil {
+//	    provider.Unread(record)
+//	    return err
+//	}
+//
+// Unread records are returned by the next reads before any buffered record,
+// as they were returned by Read: they are not converted or passed through
+// middleware and hooks again. Records unread in reverse order of reading
+// are read again in their original order. Unread records are not counted
+// in Len; they are kept after Close until read.
+func (p *Provider) Unread(record *iris.Record) {
+	if record == nil {
+		return
+	}
+	p.pushback.mu.Lock()
+	p.pushback.records = append(p.pushback.records, record)
+	p.pushback.n.Store(int32(len(p.pushback.records))) // #nosec G115 -- bounded by the number of reads
+	p.pushback.mu.Unlock()
+	p.queue.signal()
+}
+
+// take removes and returns the most recently unread record, or nil.
+func (b *pushback) take() *iris.Record {
+	if b.n.Load() == 0 {
+		return nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	last := len(b.records) - 1
+	if last < 0 {
+		return nil
+	}
+	record := b.records[last]
+	b.records[last] = nil
+	b.records = b.records[:last]
+	b.n.Store(int32(last)) // #nosec G115 -- bounded by the number of reads
+	return record
+}
+
+// drained reports whether the provider is closed and no record, buffered,
+// unread, held for resequencing or due for redelivery, remains to be read.
+func (p *Provider) drained() bool {
+	return p.pushback.n.Load() == 0 && p.queue.drained() &&
+		(p.resequence == nil || p.resequence.len() == 0) &&
+		(p.acks == nil || !p.acks.redeliverable())
+}
@@ -0,0 +1,91 @@
+// pushback_test.go: Tests for returning undelivered records
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func TestRead_CancelledContextDoesNotConsume(t *testing.T) {
+	provider := New(10)
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+	slog.New(provider).Info("kept")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if record, err := provider.Read(ctx); !errors.Is(err, context.Canceled) || record != nil {
+		t.Fatalf("Read with cancelled context = %v, %v; want nil, context.Canceled", record, err)
+	}
+	if provider.Len() != 1 {
+		t.Error("Expected the record to stay buffered")
+	}
+}
+
+func TestUnread_ReturnsRecordsInOrder(t *testing.T) {
+	provider := New(10)
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	logger := slog.New(provider)
+	for _, msg := range []string{"a", "b", "c"} {
+		logger.Info(msg)
+	}
+	first := readWithTimeout(t, provider)
+	second := readWithTimeout(t, provider)
+	provider.Unread(second)
+	provider.Unread(first)
+	provider.Unread(nil)
+
+	var got string
+	for i := 0; i < 3; i++ {
+		got += readWithTimeout(t, provider).Msg
+	}
+	if got != "abc" {
+		t.Errorf("Expected original order after Unread, got %q", got)
+	}
+	if err := provider.Verify(); err != nil {
+		t.Errorf("Verify failed after Unread: %v", err)
+	}
+}
+
+func TestUnread_WakesBlockedReaderAndSurvivesClose(t *testing.T) {
+	provider := New(10)
+	slog.New(provider).Info("pending")
+	record := readWithTimeout(t, provider)
+
+	done := make(chan string, 1)
+	go func() {
+		r, _ := provider.Read(context.Background())
+		if r == nil {
+			done <- ""
+			return
+		}
+		done <- r.Msg
+	}()
+	time.Sleep(10 * time.Millisecond)
+	provider.Unread(record)
+	select {
+	case msg := <-done:
+		if msg != "pending" {
+			t.Fatalf("Expected the unread record, got %q", msg)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Unread did not wake the blocked reader")
+	}
+
+	provider.Unread(record)
+	_ = provider.Close()
+	if r := readWithTimeout(t, provider); r == nil || r.Msg != "pending" {
+		t.Fatal("Expected unread record to be readable after Close")
+	}
+	if r := readWithTimeout(t, provider); r != nil {
+		t.Errorf("Expected end of stream, got %q", r.Msg)
+	}
+}
@@ -0,0 +1,336 @@
+// queue.go: Bounded record queue with non-destructive inspection
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// queue is the bounded FIFO buffer between Handle and Read.
+//
+// Pushes are lock-free: a push reserves a slot in count and sends the entry
+// on a buffered channel, which the reservation keeps from blocking. Unlike a
+// bare channel the queue can be inspected without consuming entries (see
+// Provider.Range): operations that need to see or rearrange the buffered
+// entries take mu and move the channel contents to a ring buffer, which pop
+// empties before it receives from the channel again. Readers wait on notify,
+// which holds at most one pending wake-up.
+type queue struct {
+	ch     chan entry
+	count  atomic.Int64 // Buffered entries plus reserved slots, and closedFlag
+	limit  atomic.Int64 // Usable capacity, at most cap(ch)
+	size   atomic.Int64 // Sum of the estimated sizes of buffered entries
+	notify chan struct{}
+
+	mu   sync.Mutex
+	buf  []entry // Entries older than those in ch, allocated on first use
+	head int     // Index of the oldest entry in buf
+	n    int     // Number of entries in buf
+}
+
+// pushResult is the outcome of queue.push.
+type pushResult int
+
+const (
+	pushed     pushResult = iota // Entry buffered
+	pushFull                     // Queue full, entry not buffered
+	pushClosed                   // Queue closed, entry not buffered
+)
+
+// closedFlag is set in queue.count once no more pushes are accepted, so that
+// reserving a slot and checking for close is a single atomic operation.
+const closedFlag = 1 << 62
+
+// newQueue returns an empty queue holding up to capacity entries. A
+// non-positive capacity yields a queue that rejects every push.
+func newQueue(capacity int) *queue {
+	if capacity < 0 {
+		capacity = 0
+	}
+	q := &queue{
+		ch:     make(chan entry, capacity),
+		notify: make(chan struct{}, 1),
+	}
+	q.limit.Store(int64(capacity))
+	return q
+}
+
+// push appends e unless the queue is full or closed.
+func (q *queue) push(e entry) pushResult {
+	if result := q.claim(1); result != pushed {
+		return result
+	}
+	q.send(e)
+	return pushed
+}
+
+// pushAll appends all of es, or none of them when the queue lacks room for
+// the whole batch or is closed. Entries pushed concurrently are not
+// interleaved with the batch.
+func (q *queue) pushAll(es []entry) pushResult {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if result := q.claim(len(es)); result != pushed {
+		return result
+	}
+	q.migrate()
+	for _, e := range es {
+		q.size.Add(int64(e.size))
+		q.put(e)
+	}
+	q.signal() // Non-blocking, safe with the lock held
+	return pushed
+}
+
+// pushDroppingOldest appends e, removing the oldest entries to make room
+// when the queue is full, and reports how many were removed. It fails with
+// pushFull for a queue without capacity, or when the only buffered entries
+// are still being pushed by other goroutines.
+func (q *queue) pushDroppingOldest(e entry) (pushResult, int) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.migrate()
+	dropped := 0
+	for {
+		switch q.claim(1) {
+		case pushClosed:
+			return pushClosed, dropped
+		case pushed:
+			q.send(e)
+			return pushed, dropped
+		}
+		if q.n == 0 {
+			return pushFull, dropped
+		}
+		q.release(q.shift())
+		dropped++
+	}
+}
+
+// pushEvicting appends e to a full queue by removing the buffered entry with
+// the lowest weight, among those weigh reports as evictable; the oldest
+// entry wins ties. It fails with pushFull when no entry is evictable.
+func (q *queue) pushEvicting(e entry, weigh func(buffered *entry) (float64, bool)) pushResult {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closing() {
+		return pushClosed
+	}
+	q.migrate()
+	victim := -1
+	var lightest float64
+	for i := 0; i < q.n; i++ {
+		if w, ok := weigh(q.at(i)); ok && (victim < 0 || w < lightest) {
+			victim, lightest = i, w
+		}
+	}
+	if victim < 0 {
+		return pushFull
+	}
+	q.replace(victim, e)
+	return pushed
+}
+
+// pushChoosing appends e to a full queue by removing the entry at the index
+// returned by choose, oldest first; it fails with pushFull when the index is
+// out of range. choose is called with the queue locked, and sees the
+// buffered entries through n and at.
+func (q *queue) pushChoosing(e entry, choose func(q *queue) int) pushResult {
+	q.mu.Lock()
+	defer q.mu.Unlock() // Unlocked even when choose panics
+	if q.closing() {
+		return pushClosed
+	}
+	q.migrate()
+	victim := -1
+	if q.n > 0 {
+		victim = choose(q)
+	}
+	if victim < 0 || victim >= q.n {
+		return pushFull
+	}
+	q.replace(victim, e)
+	return pushed
+}
+
+// claim reserves k slots unless the queue is closed or lacks room for them.
+func (q *queue) claim(k int) pushResult {
+	for {
+		n := q.count.Load()
+		switch {
+		case n&closedFlag != 0:
+			return pushClosed
+		case n+int64(k) > q.limit.Load():
+			return pushFull
+		}
+		if q.count.CompareAndSwap(n, n+int64(k)) {
+			return pushed
+		}
+	}
+}
+
+// send buffers e in a slot reserved with claim and wakes a reader. Every
+// entry in ch holds a reservation, so the send never blocks.
+func (q *queue) send(e entry) {
+	if e.size != 0 {
+		q.size.Add(int64(e.size))
+	}
+	q.ch <- e
+	q.signal()
+}
+
+// release gives back the slot of an entry removed from the queue.
+func (q *queue) release(e entry) {
+	if e.size != 0 {
+		q.size.Add(-int64(e.size))
+	}
+	q.count.Add(-1)
+}
+
+// migrate moves the entries in ch to buf, so that buf holds every buffered
+// entry except those pushed since. The queue must be locked.
+func (q *queue) migrate() {
+	for {
+		select {
+		case e := <-q.ch:
+			q.put(e)
+		default:
+			return
+		}
+	}
+}
+
+// put appends e to buf. The queue must be locked.
+func (q *queue) put(e entry) {
+	if q.buf == nil {
+		q.buf = make([]entry, cap(q.ch))
+	}
+	q.buf[(q.head+q.n)%len(q.buf)] = e
+	q.n++
+}
+
+// shift removes and returns the oldest entry in buf. The queue must be
+// locked.
+func (q *queue) shift() entry {
+	e := q.buf[q.head]
+	q.buf[q.head] = entry{} // Release references for the garbage collector
+	q.head = (q.head + 1) % len(q.buf)
+	q.n--
+	return e
+}
+
+// replace removes the i-th entry in buf, oldest first, and appends e, which
+// takes over its slot. The queue must be locked.
+func (q *queue) replace(i int, e entry) {
+	q.size.Add(-int64(q.at(i).size))
+	for ; i < q.n-1; i++ {
+		*q.at(i) = *q.at(i + 1)
+	}
+	*q.at(q.n - 1) = entry{}
+	q.n--
+	q.send(e) // Non-blocking, safe with the lock held
+}
+
+// at returns the i-th entry in buf, oldest first. The queue must be locked.
+func (q *queue) at(i int) *entry {
+	return &q.buf[(q.head+i)%len(q.buf)]
+}
+
+// close rejects further pushes. Buffered entries remain available to pop.
+func (q *queue) close() {
+	q.count.Or(closedFlag)
+}
+
+// closing reports whether close was called.
+func (q *queue) closing() bool {
+	return q.count.Load()&closedFlag != 0
+}
+
+// drained reports whether the queue is closed and empty, so no entry will
+// ever be available again.
+func (q *queue) drained() bool {
+	return q.count.Load() == closedFlag
+}
+
+// pop removes and returns the oldest entry.
+func (q *queue) pop() (entry, bool) {
+	q.mu.Lock()
+	var e entry
+	if q.n > 0 {
+		e = q.shift()
+	} else {
+		select {
+		case e = <-q.ch:
+		default:
+			q.mu.Unlock()
+			return entry{}, false
+		}
+	}
+	q.mu.Unlock()
+
+	q.release(e)
+	if q.len() > 0 {
+		q.signal() // Pass the wake-up on to other waiting readers
+	}
+	return e, true
+}
+
+// signal wakes one waiting reader without blocking.
+func (q *queue) signal() {
+	select {
+	case q.notify <- struct{}{}:
+	default:
+	}
+}
+
+// len returns the number of buffered entries, including those being pushed.
+func (q *queue) len() int {
+	return int(q.count.Load() &^ closedFlag)
+}
+
+// setLimit changes the usable capacity, clamped to the allocated capacity.
+// Entries beyond a lowered limit stay buffered; pushes fail until the queue
+// drains below it.
+func (q *queue) setLimit(limit int) {
+	q.limit.Store(int64(min(max(limit, 0), cap(q.ch))))
+}
+
+// touch writes every slot of the channel buffer so that its memory is
+// committed. It must be called before the queue is used.
+func (q *queue) touch() {
+	for range cap(q.ch) {
+		q.ch <- entry{}
+	}
+	for range cap(q.ch) {
+		<-q.ch
+	}
+}
+
+// bytes returns the estimated size of the buffered entries.
+func (q *queue) bytes() int {
+	return int(q.size.Load())
+}
+
+// cap returns the queue capacity.
+func (q *queue) cap() int {
+	return cap(q.ch)
+}
+
+// each calls fn for the buffered entries from oldest to newest until fn
+// returns false. The queue is locked during the iteration, and entries
+// pushed meanwhile are not visited.
+func (q *queue) each(fn func(e *entry) bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.migrate()
+	for i := 0; i < q.n; i++ {
+		if !fn(q.at(i)) {
+			return
+		}
+	}
+}
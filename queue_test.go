@@ -0,0 +1,191 @@
+// queue_test.go: Tests for the bounded record queue
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"log/slog"
+	"sync"
+	"testing"
+)
+
+func TestQueue_FIFOAcrossWrapAround(t *testing.T) {
+	q := newQueue(3)
+	push := func(msg string) bool { return q.push(entry{record: slog.Record{Message: msg}}) == pushed }
+
+	push("a")
+	push("b")
+	push("c")
+	if push("d") {
+		t.Fatal("push succeeded on a full queue")
+	}
+	if e, _ := q.pop(); e.record.Message != "a" {
+		t.Fatalf("pop = %q, want a", e.record.Message)
+	}
+	push("d")
+
+	for _, want := range []string{"b", "c", "d"} {
+		e, ok := q.pop()
+		if !ok || e.record.Message != want {
+			t.Fatalf("pop = %q, %v; want %q", e.record.Message, ok, want)
+		}
+	}
+	if _, ok := q.pop(); ok || q.len() != 0 {
+		t.Error("pop succeeded on an empty queue")
+	}
+}
+
+func TestQueue_ZeroCapacityRejects(t *testing.T) {
+	q := newQueue(0)
+	if q.push(entry{}) != pushFull || q.cap() != 0 {
+		t.Error("zero-capacity queue accepted an entry")
+	}
+}
+
+func TestQueue_CloseKeepsBufferedEntries(t *testing.T) {
+	q := newQueue(2)
+	q.push(entry{})
+	q.close()
+
+	if got := q.push(entry{}); got != pushClosed {
+		t.Errorf("push after close = %v, want pushClosed", got)
+	}
+	if q.drained() {
+		t.Error("drained with an entry still buffered")
+	}
+	if _, ok := q.pop(); !ok || !q.drained() {
+		t.Error("queue not drained after popping the last entry")
+	}
+}
+
+func TestQueue_SetLimit(t *testing.T) {
+	q := newQueue(4)
+	q.setLimit(2)
+	for i := 0; i < 2; i++ {
+		if q.push(entry{}) != pushed {
+			t.Fatalf("push %d failed below limit", i)
+		}
+	}
+	if q.push(entry{}) != pushFull {
+		t.Error("Expected push beyond limit to fail")
+	}
+	q.setLimit(100)
+	if q.push(entry{}) != pushed {
+		t.Error("Expected push after raising limit to succeed")
+	}
+	if q.cap() != 4 {
+		t.Errorf("Expected cap to stay 4, got %d", q.cap())
+	}
+}
+
+func TestQueue_PushEvictingAcrossWrapAround(t *testing.T) {
+	q := newQueue(3)
+	push := func(msg string) { q.push(entry{record: slog.Record{Message: msg}}) }
+	push("a")
+	q.pop()
+	push("b")
+	push("c")
+	push("d") // Wraps around
+
+	weigh := func(e *entry) (float64, bool) { return 0, e.record.Message != "b" }
+	if q.pushEvicting(entry{record: slog.Record{Message: "e"}}, weigh) != pushed {
+		t.Fatal("pushEvicting failed with an evictable entry")
+	}
+	for _, want := range []string{"b", "d", "e"} {
+		if e, _ := q.pop(); e.record.Message != want {
+			t.Fatalf("pop = %q, want %q", e.record.Message, want)
+		}
+	}
+
+	push("f")
+	none := func(*entry) (float64, bool) { return 0, false }
+	q.setLimit(1)
+	if q.pushEvicting(entry{record: slog.Record{Message: "g"}}, none) != pushFull {
+		t.Error("pushEvicting succeeded without an evictable entry")
+	}
+}
+
+func TestQueue_PushDroppingOldestBelowLoweredLimit(t *testing.T) {
+	q := newQueue(4)
+	for _, msg := range []string{"a", "b", "c", "d"} {
+		q.push(entry{record: slog.Record{Message: msg}})
+	}
+	q.setLimit(2)
+
+	result, dropped := q.pushDroppingOldest(entry{record: slog.Record{Message: "e"}})
+	if result != pushed || dropped != 3 {
+		t.Fatalf("pushDroppingOldest = %v, %d; want pushed, 3", result, dropped)
+	}
+	for _, want := range []string{"d", "e"} {
+		if e, ok := q.pop(); !ok || e.record.Message != want {
+			t.Fatalf("pop = %q, %v; want %q", e.record.Message, ok, want)
+		}
+	}
+	if result, _ := newQueue(0).pushDroppingOldest(entry{}); result != pushFull {
+		t.Errorf("zero-capacity queue accepted an entry")
+	}
+}
+
+func TestQueue_InspectionKeepsFIFOWithLaterPushes(t *testing.T) {
+	q := newQueue(4)
+	push := func(msg string) { q.push(entry{record: slog.Record{Message: msg}}) }
+	push("a")
+	push("b")
+
+	var seen []string
+	q.each(func(e *entry) bool {
+		seen = append(seen, e.record.Message)
+		return true
+	})
+	if len(seen) != 2 || seen[0] != "a" || seen[1] != "b" {
+		t.Fatalf("each visited %v, want [a b]", seen)
+	}
+
+	push("c")
+	push("d")
+	if q.push(entry{}) != pushFull || q.len() != 4 {
+		t.Fatalf("push succeeded beyond capacity, len = %d", q.len())
+	}
+	for _, want := range []string{"a", "b", "c", "d"} {
+		if e, ok := q.pop(); !ok || e.record.Message != want {
+			t.Fatalf("pop = %q, %v; want %q", e.record.Message, ok, want)
+		}
+	}
+}
+
+func TestQueue_ConcurrentPushesDuringInspection(t *testing.T) {
+	const producers, perProducer = 4, 200
+	q := newQueue(producers * perProducer)
+
+	var wg sync.WaitGroup
+	for range producers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range perProducer {
+				if q.push(entry{size: 1}) != pushed {
+					t.Error("push failed below capacity")
+					return
+				}
+			}
+		}()
+	}
+	for i := 0; i < 50; i++ {
+		q.each(func(*entry) bool { return true })
+	}
+	wg.Wait()
+	q.close()
+
+	popped := 0
+	for !q.drained() {
+		if _, ok := q.pop(); ok {
+			popped++
+		}
+	}
+	if popped != producers*perProducer || q.bytes() != 0 {
+		t.Errorf("popped %d entries with %d bytes left, want %d and 0", popped, q.bytes(), producers*perProducer)
+	}
+}
@@ -0,0 +1,37 @@
+// read_level.go: Read-side minimum level applied when draining the buffer
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import "log/slog"
+
+// WithReadLevel sets a minimum level applied at Read, independently of the
+// level checks performed by Enabled and Handle.
+//
+// Producers keep logging and buffering as usual; records below the read
+// level are discarded when drained instead of reaching Iris and its
+// writers. Passing a *slog.LevelVar lets operators raise the floor during
+// an outage of the log backend and lower it again afterwards, without
+// touching producers or losing the records already above the floor:
+//
+//	floor := new(slog.LevelVar)
+//	provider := slogprovider.New(10000, slogprovider.WithReadLevel(floor))
+//	...
+//	floor.Set(slog.LevelError) // Backend degraded: only forward errors
+//
+// Rules.ReadLevel, when set, takes precedence. Discarded records are
+// counted in Stats().ReadFiltered. A nil level disables the floor.
+func WithReadLevel(level slog.Leveler) Option {
+	return func(o *options) { o.readLevel = level }
+}
+
+// belowReadLevel reports whether level is below the read-side floor.
+func (p *Provider) belowReadLevel(level slog.Level) bool {
+	if r := p.rules.Load(); r != nil && r.readLevel != nil {
+		return level < *r.readLevel
+	}
+	return p.opts.readLevel != nil && level < p.opts.readLevel.Level()
+}
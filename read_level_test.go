@@ -0,0 +1,66 @@
+// read_level_test.go: Tests for the Read-side level floor
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+)
+
+func TestWithReadLevel_DiscardsAtRead(t *testing.T) {
+	floor := new(slog.LevelVar)
+	provider := New(100, WithReadLevel(floor))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+	logger := slog.New(provider)
+
+	logger.Info("before outage")
+	floor.Set(slog.LevelError)
+	logger.Info("during outage")
+	logger.Error("failure during outage")
+
+	if !provider.Enabled(context.Background(), slog.LevelInfo) {
+		t.Error("Read level must not affect Enabled")
+	}
+	msgs := readMessages(t, provider, 1)
+	if msgs[0] != "failure during outage" {
+		t.Errorf("Unexpected records %v", msgs)
+	}
+
+	floor.Set(slog.LevelInfo)
+	logger.Info("after outage")
+	if msgs := readMessages(t, provider, 1); msgs[0] != "after outage" {
+		t.Errorf("Unexpected records %v", msgs)
+	}
+
+	if stats := provider.Stats(); stats.ReadFiltered != 2 {
+		t.Errorf("Expected 2 read-filtered records, got %+v", stats)
+	}
+	if err := provider.Verify(); err != nil {
+		t.Errorf("Verify failed: %v", err)
+	}
+}
+
+func TestRules_ReadLevel(t *testing.T) {
+	provider := New(100, WithReadLevel(slog.LevelError))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	rules, err := ParseRules(map[string]any{"read_level": "warn"})
+	if err != nil {
+		t.Fatalf("ParseRules() error = %v", err)
+	}
+	if err := provider.SetRules(rules); err != nil {
+		t.Fatalf("SetRules() error = %v", err)
+	}
+
+	logger := slog.New(provider)
+	logger.Info("filtered")
+	logger.Warn("kept by rule")
+	if msgs := readMessages(t, provider, 1); msgs[0] != "kept by rule" {
+		t.Errorf("Unexpected records %v", msgs)
+	}
+}
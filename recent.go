@@ -0,0 +1,91 @@
+// recent.go: Ring of the most recently emitted records
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"sync"
+	"time"
+
+	"github.com/agilira/iris"
+)
+
+// WithRecentRecords keeps a copy of the last n records returned by Read, so a
+// debug endpoint or panic handler can show the final log lines even when
+// stdout has scrolled away or shipping lags. See LastRecords.
+//
+// Records are kept as emitted, after middleware, so redaction applied by
+// middleware also applies to the ring. Keeping records costs a copy per
+// record on the Read path and about 4 KiB of memory per slot.
+func WithRecentRecords(n int) Option {
+	return func(o *options) { o.recent = n }
+}
+
+// recentRecord is a record kept by the recent ring with its slog time.
+type recentRecord struct {
+	time   time.Time
+	record iris.Record
+}
+
+// recentRing is a fixed-size ring of the most recently emitted records.
+type recentRing struct {
+	mu   sync.Mutex
+	buf  []recentRecord
+	next int  // Slot written next
+	full bool // All slots hold records
+}
+
+// newRecentRing returns a ring of n slots, or nil when n is not positive.
+func newRecentRing(n int) *recentRing {
+	if n <= 0 {
+		return nil
+	}
+	return &recentRing{buf: make([]recentRecord, n)}
+}
+
+// add stores a copy of record, emitted for a slog record with time ts.
+func (r *recentRing) add(ts time.Time, record *iris.Record) {
+	r.mu.Lock()
+	r.buf[r.next] = recentRecord{time: ts, record: *record}
+	r.next = (r.next + 1) % len(r.buf)
+	r.full = r.full || r.next == 0
+	r.mu.Unlock()
+}
+
+// last returns copies of the n most recent records, oldest first. A
+// non-positive n returns all kept records.
+func (r *recentRing) last(n int) []recentRecord {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	size := r.next
+	if r.full {
+		size = len(r.buf)
+	}
+	if n <= 0 || n > size {
+		n = size
+	}
+	out := make([]recentRecord, 0, n)
+	for i := n; i > 0; i-- {
+		out = append(out, r.buf[(r.next-i+len(r.buf))%len(r.buf)])
+	}
+	return out
+}
+
+// LastRecords returns copies of the last n records returned by Read, oldest
+// first, or all kept records when n is not positive. It returns nil unless
+// the provider was created with WithRecentRecords.
+func (p *Provider) LastRecords(n int) []*iris.Record {
+	if p.recent == nil {
+		return nil
+	}
+	kept := p.recent.last(n)
+	records := make([]*iris.Record, len(kept))
+	for i := range kept {
+		records[i] = &kept[i].record
+	}
+	return records
+}
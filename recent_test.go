@@ -0,0 +1,80 @@
+// recent_test.go: Tests for the recent record ring
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"fmt"
+	"log/slog"
+	"testing"
+
+	"github.com/agilira/iris"
+)
+
+func TestLastRecords_KeepsMostRecent(t *testing.T) {
+	provider := New(10, WithRecentRecords(3))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	logger := slog.New(provider)
+	for i := 0; i < 5; i++ {
+		logger.Info(fmt.Sprintf("m%d", i))
+		readWithTimeout(t, provider)
+	}
+
+	messages := func(records []*iris.Record) string {
+		var s string
+		for _, r := range records {
+			s += r.Msg + " "
+		}
+		return s
+	}
+	if got := messages(provider.LastRecords(0)); got != "m2 m3 m4 " {
+		t.Errorf("LastRecords(0) = %q, want the last 3 oldest first", got)
+	}
+	if got := messages(provider.LastRecords(2)); got != "m3 m4 " {
+		t.Errorf("LastRecords(2) = %q", got)
+	}
+	if got := messages(provider.LastRecords(10)); got != "m2 m3 m4 " {
+		t.Errorf("LastRecords(10) = %q", got)
+	}
+}
+
+func TestLastRecords_PartialAndCopies(t *testing.T) {
+	provider := New(10, WithRecentRecords(5))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	slog.New(provider).Info("only")
+	emitted := readWithTimeout(t, provider)
+	emitted.Msg = "mutated"
+
+	records := provider.LastRecords(0)
+	if len(records) != 1 || records[0].Msg != "only" {
+		t.Fatalf("Expected an independent copy of the emitted record, got %v", records)
+	}
+}
+
+func TestLastRecords_AfterMiddleware(t *testing.T) {
+	redact := func(r *iris.Record) *iris.Record {
+		r.Msg = "[redacted]"
+		return r
+	}
+	provider := New(10, WithRecentRecords(5), WithRecordMiddleware(redact))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	slog.New(provider).Info("secret")
+	readWithTimeout(t, provider)
+	if records := provider.LastRecords(1); len(records) != 1 || records[0].Msg != "[redacted]" {
+		t.Errorf("Expected the ring to hold the redacted record, got %v", records)
+	}
+}
+
+func TestLastRecords_Disabled(t *testing.T) {
+	provider := New(10)
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+	if provider.LastRecords(5) != nil {
+		t.Error("Expected nil without WithRecentRecords")
+	}
+}
@@ -0,0 +1,36 @@
+// record_time.go: Preservation of the slog record time in Iris records
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"log/slog"
+
+	"github.com/agilira/iris"
+)
+
+// WithoutRecordTime stops carrying the slog record time into converted
+// records.
+//
+// Iris records have no timestamp, and Iris encoders stamp records when they
+// are written, after Read; under buffering delay that time can lag the
+// logging call by a whole drain cycle. The provider therefore keeps the time
+// of the slog record, unless zero, as an iris.Time field under slog.TimeKey
+// ("time"). Use WithoutRecordTime when the write time is enough and the
+// extra field is not wanted, e.g. to save a slot of the Iris field limit.
+// ToSlogRecord then stamps converted records with the current time.
+func WithoutRecordTime() Option {
+	return func(o *options) { o.omitTime = true }
+}
+
+// addRecordTime adds the time of slogRec to record, unless zero or omitted
+// with WithoutRecordTime.
+func (o *options) addRecordTime(record *iris.Record, slogRec slog.Record) {
+	if o.omitTime || slogRec.Time.IsZero() {
+		return
+	}
+	record.AddField(iris.Time(slog.TimeKey, slogRec.Time))
+}
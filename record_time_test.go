@@ -0,0 +1,62 @@
+// record_time_test.go: Tests for slog record time preservation
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func TestProvider_PreservesRecordTime(t *testing.T) {
+	provider := New(10)
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	at := time.Date(2025, 3, 4, 5, 6, 7, 8, time.UTC)
+	if err := provider.Handle(context.Background(), slog.NewRecord(at, slog.LevelInfo, "stamped", 0)); err != nil {
+		t.Fatalf("Handle failed: %v", err)
+	}
+	if err := provider.Handle(context.Background(), slog.NewRecord(time.Time{}, slog.LevelInfo, "unstamped", 0)); err != nil {
+		t.Fatalf("Handle failed: %v", err)
+	}
+
+	record := readWithTimeout(t, provider)
+	if f, ok := findField(record, slog.TimeKey); !ok || !f.TimeValue().Equal(at) {
+		t.Errorf("Expected %s=%v, got %v", slog.TimeKey, at, f)
+	}
+	if got := ToSlogRecord(record); !got.Time.Equal(at) {
+		t.Errorf("ToSlogRecord time = %v, want %v", got.Time, at)
+	}
+	if _, ok := findField(readWithTimeout(t, provider), slog.TimeKey); ok {
+		t.Error("Expected no time field for a zero record time")
+	}
+}
+
+func TestWithoutRecordTime(t *testing.T) {
+	provider := New(10, WithoutRecordTime())
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	record := readRecord(t, provider, func(l *slog.Logger) { l.Info("event", "k", 1) })
+	if _, ok := findField(record, slog.TimeKey); ok || record.FieldCount() != 1 {
+		t.Errorf("Expected only the record attributes, got %v", fieldKeys(record))
+	}
+
+	converted := ConvertRecord(slog.NewRecord(time.Now(), slog.LevelInfo, "m", 0), WithoutRecordTime())
+	if converted.FieldCount() != 0 {
+		t.Errorf("Expected ConvertRecord to honor WithoutRecordTime, got %d fields", converted.FieldCount())
+	}
+
+	configured, err := NewWithConfig(Config{BufferSize: 10, OmitTime: true})
+	if err != nil {
+		t.Fatalf("NewWithConfig failed: %v", err)
+	}
+	defer func() { _ = configured.Close() }() // Ignore error in test cleanup
+	if !configured.opts.omitTime {
+		t.Error("Expected Config.OmitTime to apply WithoutRecordTime")
+	}
+}
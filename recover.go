@@ -0,0 +1,41 @@
+// recover.go: Panic recovery during record conversion
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"fmt"
+
+	"github.com/agilira/iris"
+)
+
+// ConversionPanicKey is the field key carrying the panic value of a record
+// whose conversion panicked.
+const ConversionPanicKey = "conversion_panic"
+
+// safeConvert converts e, recovering from panics raised by user code invoked
+// during conversion (custom converters, FieldConverter implementations,
+// Stringers). A panicking conversion yields a degraded record with the
+// original level and message, the record index if any, and a
+// ConversionPanicKey field, so the Iris reader goroutine survives and the
+// event stays visible. Recovered panics are counted in
+// Stats().ConversionPanics.
+func (p *Provider) safeConvert(e entry) (record *iris.Record) {
+	defer func() {
+		if r := recover(); r != nil {
+			p.stats.conversionPanics.Add(1)
+			record = p.region.newRecord(p.convertLevel(e.record.Level), e.record.Message)
+			if e.seq != 0 {
+				record.AddField(iris.Uint64(SequenceKey, e.seq))
+			}
+			record.AddField(iris.String(ConversionPanicKey, fmt.Sprint(r)))
+			if p.opts.namespace != nil {
+				p.opts.namespace.apply(record)
+			}
+		}
+	}()
+	return p.convertEntry(e)
+}
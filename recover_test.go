@@ -0,0 +1,45 @@
+// recover_test.go: Tests for panic recovery during conversion
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"log/slog"
+	"testing"
+
+	"github.com/agilira/iris"
+)
+
+func TestConversionPanic_DeliversDegradedRecord(t *testing.T) {
+	panicky := FieldConverterFunc(func(key string, v slog.Value) iris.Field {
+		if key == "bad" {
+			panic("converter bug")
+		}
+		return DefaultFieldConverter.ConvertField(key, v)
+	})
+	provider := New(10, WithFieldConverter(panicky), WithSequence())
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	record := readRecord(t, provider, func(l *slog.Logger) { l.Error("payment failed", "ok", 1, "bad", 2) })
+
+	if record.Msg != "payment failed" || record.Level != iris.Error {
+		t.Errorf("degraded record = %v %q, want original level and message", record.Level, record.Msg)
+	}
+	if f, ok := findField(record, ConversionPanicKey); !ok || f.StringValue() != "converter bug" {
+		t.Errorf("%s = %q, %v", ConversionPanicKey, f.StringValue(), ok)
+	}
+	if _, ok := findField(record, SequenceKey); !ok {
+		t.Error("degraded record lost its sequence index")
+	}
+	if got := provider.Stats().ConversionPanics; got != 1 {
+		t.Errorf("Stats().ConversionPanics = %d, want 1", got)
+	}
+
+	next := readRecord(t, provider, func(l *slog.Logger) { l.Info("still working", "ok", 1) })
+	if _, ok := findField(next, ConversionPanicKey); ok {
+		t.Error("panic field on a healthy record")
+	}
+}
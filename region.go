@@ -0,0 +1,50 @@
+// region.go: Region allocation of converted records (slogprovider_arena builds)
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+//go:build slogprovider_arena
+
+package slogprovider
+
+import (
+	"sync"
+
+	"github.com/agilira/iris"
+)
+
+// regionAllocation reports whether records are allocated from regions.
+const regionAllocation = true
+
+// regionSize is the number of records carved from one region.
+const regionSize = 64
+
+// recordRegion hands out records carved from bulk-allocated regions.
+//
+// This experimental mode, enabled with the slogprovider_arena build tag,
+// replaces one heap allocation per converted record with one allocation per
+// regionSize records, reducing allocation rate and GC object count for
+// very-high-throughput deployments. Records are handed to Iris as usual; a
+// region is reclaimed by the garbage collector once none of its records is
+// referenced any more, so retaining a single record (for example with Unread)
+// keeps its whole region alive.
+type recordRegion struct {
+	mu   sync.Mutex
+	free []iris.Record // Unused part of the current region
+}
+
+// newRecord returns a record for level and msg from the current region.
+func (r *recordRegion) newRecord(level iris.Level, msg string) *iris.Record {
+	r.mu.Lock()
+	if len(r.free) == 0 {
+		r.free = make([]iris.Record, regionSize)
+	}
+	record := &r.free[0]
+	r.free = r.free[1:]
+	r.mu.Unlock()
+
+	record.Level = level
+	record.Msg = msg
+	return record
+}
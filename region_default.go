@@ -0,0 +1,23 @@
+// region_default.go: Per-record allocation of converted records
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+//go:build !slogprovider_arena
+
+package slogprovider
+
+import "github.com/agilira/iris"
+
+// regionAllocation reports whether records are allocated from regions; see
+// region.go for the slogprovider_arena build mode.
+const regionAllocation = false
+
+// recordRegion allocates every record individually.
+type recordRegion struct{}
+
+// newRecord returns a new record for level and msg.
+func (*recordRegion) newRecord(level iris.Level, msg string) *iris.Record {
+	return iris.NewRecord(level, msg)
+}
@@ -0,0 +1,55 @@
+// region_test.go: Tests for record allocation in both build modes
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"log/slog"
+	"testing"
+
+	"github.com/agilira/iris"
+)
+
+func TestRecordRegion_NewRecord(t *testing.T) {
+	var region recordRegion
+
+	seen := make(map[*iris.Record]bool)
+	for i := 0; i < 200; i++ {
+		record := region.newRecord(iris.Warn, "msg")
+		if record.Level != iris.Warn || record.Msg != "msg" || record.FieldCount() != 0 {
+			t.Fatalf("Unexpected record %d: level=%v msg=%q fields=%d", i, record.Level, record.Msg, record.FieldCount())
+		}
+		if seen[record] {
+			t.Fatalf("Record %d was handed out twice", i)
+		}
+		seen[record] = true
+		record.AddField(iris.Int("i", i))
+	}
+}
+
+func TestRecordRegion_RecordsStayIndependent(t *testing.T) {
+	provider := New(100)
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	first := readRecord(t, provider, func(logger *slog.Logger) { logger.Info("first", "n", 1) })
+	second := readRecord(t, provider, func(logger *slog.Logger) { logger.Info("second", "n", 2) })
+
+	if first.Msg != "first" || second.Msg != "second" {
+		t.Fatalf("Unexpected messages %q and %q", first.Msg, second.Msg)
+	}
+	if f, ok := findField(first, "n"); !ok || f.IntValue() != 1 {
+		t.Errorf("First record changed after second conversion: %+v", f)
+	}
+}
+
+func TestDumpJSON_RegionAllocation(t *testing.T) {
+	provider := New(100)
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	if got := provider.configSnapshot().RegionAlloc; got != regionAllocation {
+		t.Errorf("Expected region_allocation=%v, got %v", regionAllocation, got)
+	}
+}
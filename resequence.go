@@ -0,0 +1,148 @@
+// resequence.go: Read-side timestamp resequencing window
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"sync"
+	"time"
+
+	"github.com/agilira/iris"
+)
+
+// ResequenceConfig configures the Read-side resequencing window.
+type ResequenceConfig struct {
+	// Window is how long a record is held after its timestamp so that
+	// records with earlier timestamps can overtake it; 5ms when zero.
+	Window time.Duration
+
+	// Size bounds the number of held records; the oldest record is released
+	// early when the window holds this many. 256 when zero.
+	Size int
+}
+
+// WithResequencing makes Read release records in timestamp order within a
+// bounded window, smoothing out the ordering jitter of concurrent producers
+// and sharded queues.
+//
+// Read moves buffered records into a reordering window of cfg.Size records
+// and returns the oldest one once cfg.Window has passed since its timestamp
+// (immediately when the window is full or the provider is closed). Records
+// whose timestamps are further apart than the window, or that arrive later
+// than it, can still be out of order; the window bounds the added latency.
+//
+// Held records have left the buffer: they still count as buffered in Stats,
+// but are not visible to Range or ExportNDJSON.
+func WithResequencing(cfg ResequenceConfig) Option {
+	if cfg.Window <= 0 {
+		cfg.Window = 5 * time.Millisecond
+	}
+	if cfg.Size <= 0 {
+		cfg.Size = 256
+	}
+	return func(o *options) { o.resequence = &cfg }
+}
+
+// resequencer is the reordering window of Read.
+type resequencer struct {
+	cfg  ResequenceConfig
+	mu   sync.Mutex
+	held orderHeap
+}
+
+// newResequencer creates a resequencer for cfg, or nil if cfg is nil.
+func newResequencer(cfg *ResequenceConfig) *resequencer {
+	if cfg == nil {
+		return nil
+	}
+	return &resequencer{cfg: *cfg}
+}
+
+// pollResequenced returns the next due record without blocking, or nil when
+// no held record is due yet.
+func (p *Provider) pollResequenced() *iris.Record {
+	r := p.resequence
+	for {
+		r.mu.Lock()
+		for r.held.len() < r.cfg.Size {
+			e, ok := p.queue.pop()
+			if !ok {
+				break
+			}
+			if p.watchdog != nil {
+				p.watchdog.touch()
+			}
+			r.held.add(e)
+		}
+		if r.held.len() == 0 || !r.releasable(p) {
+			r.mu.Unlock()
+			return nil
+		}
+		e := r.held.pop()
+		r.mu.Unlock()
+
+		if converted := p.process(e); converted != nil {
+			return converted
+		}
+	}
+}
+
+// releasable reports whether the oldest held record may be released. r.mu
+// must be held.
+func (r *resequencer) releasable(p *Provider) bool {
+	return r.held.len() >= r.cfg.Size ||
+		time.Since(r.held.oldest()) >= r.cfg.Window ||
+		p.queue.drained()
+}
+
+// len returns the number of held records.
+func (r *resequencer) len() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.held.len()
+}
+
+// bytes returns the estimated size of the held records.
+func (r *resequencer) bytes() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.held.size
+}
+
+// buffered returns the number of records not yet converted: buffered ones
+// and those held for resequencing.
+func (p *Provider) buffered() int {
+	n := p.queue.len()
+	if p.resequence != nil {
+		n += p.resequence.len()
+	}
+	return n
+}
+
+// bufferedBytes returns the estimated size of the records counted by
+// buffered.
+func (p *Provider) bufferedBytes() int {
+	n := p.queue.bytes()
+	if p.resequence != nil {
+		n += p.resequence.bytes()
+	}
+	return n
+}
+
+// resequenceDue returns a channel that fires when the oldest held record
+// becomes due, or nil when no record is held.
+func (p *Provider) resequenceDue() <-chan time.Time {
+	r := p.resequence
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.held.len() == 0 {
+		return nil
+	}
+	return time.After(r.cfg.Window - time.Since(r.held.oldest()))
+}
@@ -0,0 +1,117 @@
+// resequence_test.go: Tests for the Read-side resequencing window
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+// handleAt buffers a record with the given message and timestamp.
+func handleAt(t *testing.T, provider *Provider, msg string, at time.Time) {
+	t.Helper()
+	if err := provider.Handle(context.Background(), slog.NewRecord(at, slog.LevelInfo, msg, 0)); err != nil {
+		t.Fatalf("Handle failed: %v", err)
+	}
+}
+
+func TestWithResequencing_ReleasesInTimestampOrder(t *testing.T) {
+	provider := New(100, WithResequencing(ResequenceConfig{Window: 20 * time.Millisecond}))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	now := time.Now()
+	handleAt(t, provider, "c", now.Add(2*time.Millisecond))
+	handleAt(t, provider, "a", now)
+	handleAt(t, provider, "b", now.Add(time.Millisecond))
+
+	start := time.Now()
+	msgs := readMessages(t, provider, 3)
+	if msgs[0] != "a" || msgs[1] != "b" || msgs[2] != "c" {
+		t.Errorf("Expected timestamp order, got %v", msgs)
+	}
+	if waited := time.Since(start); waited > time.Second {
+		t.Errorf("Read waited %v for due records", waited)
+	}
+}
+
+func TestWithResequencing_FullWindowReleasesOldest(t *testing.T) {
+	provider := New(100, WithResequencing(ResequenceConfig{Window: time.Hour, Size: 2}))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	now := time.Now()
+	handleAt(t, provider, "b", now.Add(time.Millisecond))
+	handleAt(t, provider, "a", now)
+
+	if msgs := readMessages(t, provider, 1); msgs[0] != "a" {
+		t.Errorf("Expected the oldest record from a full window, got %v", msgs)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if record, err := provider.Read(ctx); record != nil || err == nil {
+		t.Errorf("Expected the remaining record held, got %v (err=%v)", record, err)
+	}
+}
+
+func TestWithResequencing_DrainsOnClose(t *testing.T) {
+	provider := New(100, WithResequencing(ResequenceConfig{Window: time.Hour}))
+
+	now := time.Now()
+	handleAt(t, provider, "b", now.Add(time.Millisecond))
+	handleAt(t, provider, "a", now)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if record, _ := provider.Read(ctx); record != nil {
+		t.Fatalf("Expected records held before Close, got %q", record.Msg)
+	}
+
+	_ = provider.Close()
+	if msgs := readMessages(t, provider, 2); msgs[0] != "a" || msgs[1] != "b" {
+		t.Errorf("Expected held records in order after Close, got %v", msgs)
+	}
+	if record, err := provider.Read(context.Background()); record != nil || err != nil {
+		t.Errorf("Expected end of stream, got %v (err=%v)", record, err)
+	}
+}
+
+func TestWithResequencing_Aggregator(t *testing.T) {
+	provider := New(100, WithResequencing(ResequenceConfig{Window: 10 * time.Millisecond}))
+	agg := NewAggregator(provider)
+	defer func() { _ = agg.Close() }() // Ignore error in test cleanup
+
+	handleAt(t, provider, "held", time.Now())
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	record, err := agg.Read(ctx)
+	if err != nil || record == nil || record.Msg != "held" {
+		t.Errorf("Expected the held record once due, got %v (err=%v)", record, err)
+	}
+}
+
+func TestWithResequencing_HeldRecordsCountAsBuffered(t *testing.T) {
+	provider := New(100, WithResequencing(ResequenceConfig{Window: time.Hour}), WithSizeAccounting())
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	handleAt(t, provider, "held", time.Now())
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if record, _ := provider.Read(ctx); record != nil {
+		t.Fatalf("Expected the record held, got %q", record.Msg)
+	}
+
+	stats := provider.Stats()
+	if stats.Buffered != 1 || stats.BufferedBytes == 0 || provider.Len() != 0 {
+		t.Errorf("Expected the held record counted as buffered, got %+v (len %d)", stats, provider.Len())
+	}
+	if err := provider.Verify(); err != nil {
+		t.Errorf("Verify failed with a held record: %v", err)
+	}
+}
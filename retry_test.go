@@ -0,0 +1,52 @@
+// retry_test.go: Tests for the retry-before-drop grace period
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func TestWithRetryGrace_SavesRecordWhenReaderCatchesUp(t *testing.T) {
+	provider := New(1, WithRetryGrace(time.Second))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	logger := slog.New(provider)
+	logger.Info("fills the buffer")
+
+	go func() {
+		time.Sleep(5 * time.Millisecond)
+		_, _ = provider.Read(context.Background()) // Frees the slot during the grace period
+	}()
+	logger.Info("retried")
+
+	if provider.Dropped() != 0 || provider.Stats().RetrySaved != 1 {
+		t.Fatalf("Dropped = %d, RetrySaved = %d; want 0 and 1", provider.Dropped(), provider.Stats().RetrySaved)
+	}
+	if record := readRecord(t, provider, func(*slog.Logger) {}); record.Msg != "retried" {
+		t.Errorf("record = %q, want retried", record.Msg)
+	}
+}
+
+func TestWithRetryGrace_DropsAfterGrace(t *testing.T) {
+	provider := New(1, WithRetryGrace(100*time.Microsecond))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	logger := slog.New(provider)
+	logger.Info("fills the buffer")
+
+	start := time.Now()
+	logger.Info("dropped")
+	if elapsed := time.Since(start); elapsed > 50*time.Millisecond {
+		t.Errorf("Handle blocked for %v, want about the grace period", elapsed)
+	}
+	if provider.Dropped() != 1 {
+		t.Errorf("Dropped = %d, want 1", provider.Dropped())
+	}
+}
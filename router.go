@@ -0,0 +1,275 @@
+// router.go: Attribute-based routing of records to multiple Iris loggers
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/agilira/iris"
+)
+
+// DefaultRoute is the name of the route receiving records that match no rule.
+const DefaultRoute = ""
+
+// Retry backoff bounds of a record pump whose source keeps failing.
+const (
+	minPumpBackoff = time.Millisecond
+	maxPumpBackoff = time.Second
+)
+
+// RecordPredicate reports whether a converted record matches a rule.
+type RecordPredicate func(record *iris.Record) bool
+
+// Route directs records matching Match to a dedicated iris.SyncReader.
+type Route struct {
+	// Name identifies the route for Router.Reader and Router.Dropped.
+	Name string
+
+	// Match selects the records delivered to this route.
+	Match RecordPredicate
+}
+
+// Router splits the record stream of a SyncReader across several readers.
+//
+// Router runs on the Read side: a single background goroutine reads converted
+// records from the source and delivers each one to the first route whose
+// predicate matches, or to DefaultRoute when none does. Each route is exposed
+// as its own iris.SyncReader, so records can feed separate Iris loggers:
+//
+//	router := slogprovider.NewRouter(provider, 1000,
+//	    slogprovider.Route{Name: "audit", Match: slogprovider.MatchBool("audit", true)},
+//	)
+//	defer router.Close()
+//
+//	audit, _ := iris.NewReaderLogger(durableConfig, []iris.SyncReader{router.Reader("audit")})
+//	fast, _ := iris.NewReaderLogger(fastConfig, []iris.SyncReader{router.Reader(slogprovider.DefaultRoute)})
+//
+// Delivery to a route never blocks the other routes: when a route's buffer is
+// full the record is dropped and counted in Dropped.
+type Router struct {
+	routes []*routeReader
+	byName map[string]*routeReader
+	pump   *recordPump
+}
+
+// recordPump moves the records of a source to a delivery function on a
+// background goroutine, until the source reports end of stream.
+type recordPump struct {
+	source  iris.SyncReader
+	closing chan struct{} // closed when Close starts
+	stopped chan struct{} // closed when the pump has stopped
+	once    sync.Once
+}
+
+// routeReader is the iris.SyncReader view of a single route.
+type routeReader struct {
+	match   RecordPredicate
+	records chan *iris.Record
+	done    <-chan struct{} // closed when the router stops pumping
+	closed  chan struct{}   // closed when this route is closed
+	once    sync.Once
+	dropped atomic.Uint64
+}
+
+// NewRouter creates a Router reading from source and starts routing.
+//
+// bufferSize is the per-route buffer size. Routes are evaluated in order and
+// the first match wins; routes with a nil Match never match. Closing the
+// Router closes source and routes the records still buffered in it.
+func NewRouter(source iris.SyncReader, bufferSize int, routes ...Route) *Router {
+	r := &Router{
+		byName: make(map[string]*routeReader, len(routes)+1),
+		pump:   newRecordPump(source),
+	}
+
+	for _, route := range routes {
+		rr := newRouteReader(route.Match, bufferSize, r.pump.stopped)
+		r.routes = append(r.routes, rr)
+		r.byName[route.Name] = rr
+	}
+	if _, ok := r.byName[DefaultRoute]; !ok {
+		r.byName[DefaultRoute] = newRouteReader(nil, bufferSize, r.pump.stopped)
+	}
+
+	go r.pump.run(r.dispatch)
+	return r
+}
+
+// newRecordPump creates a pump for source; run starts it.
+func newRecordPump(source iris.SyncReader) *recordPump {
+	return &recordPump{
+		source:  source,
+		closing: make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+}
+
+// newRouteReader allocates a route reader that drains its buffer and reports
+// end of stream once done is closed.
+func newRouteReader(match RecordPredicate, bufferSize int, done <-chan struct{}) *routeReader {
+	return &routeReader{
+		match:   match,
+		records: make(chan *iris.Record, bufferSize),
+		done:    done,
+		closed:  make(chan struct{}),
+	}
+}
+
+// Reader returns the iris.SyncReader for the named route, or nil if no such
+// route exists. DefaultRoute is always available.
+func (r *Router) Reader(name string) iris.SyncReader {
+	if rr, ok := r.byName[name]; ok {
+		return rr
+	}
+	return nil
+}
+
+// Dropped returns the number of records dropped for the named route because
+// its buffer was full or the route was closed.
+func (r *Router) Dropped(name string) uint64 {
+	if rr, ok := r.byName[name]; ok {
+		return rr.dropped.Load()
+	}
+	return 0
+}
+
+// Close closes the source reader, routes the records still buffered in it
+// and stops routing, so no record accepted by the source is lost, e.g. by
+// the audit route. The source must report end of stream once closed and
+// drained, as Provider does.
+//
+// Route readers return the records already routed to them and then report
+// end of stream. Close is idempotent.
+func (r *Router) Close() error {
+	return r.pump.close()
+}
+
+// close closes the source and waits until the pump has drained it.
+func (p *recordPump) close() error {
+	var err error
+	p.once.Do(func() {
+		close(p.closing)
+		err = p.source.Close()
+		<-p.stopped
+	})
+	return err
+}
+
+// run reads records from the source and passes them to deliver until the
+// source reports end of stream, nil or ErrClosed. Read errors are retried
+// with exponential backoff, and end the pump once it is closing.
+func (p *recordPump) run(deliver func(*iris.Record)) {
+	defer close(p.stopped)
+	backoff := minPumpBackoff
+	for {
+		record, err := p.source.Read(context.Background())
+		if errors.Is(err, ErrClosed) {
+			return
+		}
+		if err != nil {
+			select {
+			case <-p.closing:
+				return
+			case <-time.After(backoff):
+			}
+			backoff = min(2*backoff, maxPumpBackoff)
+			continue
+		}
+		if record == nil {
+			return
+		}
+		backoff = minPumpBackoff
+		deliver(record)
+	}
+}
+
+// dispatch delivers record to the first matching route.
+func (r *Router) dispatch(record *iris.Record) {
+	for _, rr := range r.routes {
+		if rr.match != nil && rr.match(record) {
+			rr.deliver(record)
+			return
+		}
+	}
+	r.byName[DefaultRoute].deliver(record)
+}
+
+// deliver enqueues record without blocking, counting drops.
+func (rr *routeReader) deliver(record *iris.Record) {
+	select {
+	case <-rr.closed:
+		rr.dropped.Add(1)
+		return
+	default:
+	}
+
+	select {
+	case rr.records <- record:
+	default:
+		rr.dropped.Add(1)
+	}
+}
+
+// Read implements iris.SyncReader for a single route.
+func (rr *routeReader) Read(ctx context.Context) (*iris.Record, error) {
+	select {
+	case record := <-rr.records:
+		return record, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-rr.closed:
+		return nil, nil
+	case <-rr.done:
+		// Drain records routed before the router stopped.
+		select {
+		case record := <-rr.records:
+			return record, nil
+		default:
+			return nil, nil
+		}
+	}
+}
+
+// Close implements io.Closer for a single route. Records routed to a closed
+// route are dropped; other routes are unaffected.
+func (rr *routeReader) Close() error {
+	rr.once.Do(func() { close(rr.closed) })
+	return nil
+}
+
+// MatchField returns a predicate matching records that carry a field named
+// key for which match returns true.
+func MatchField(key string, match func(iris.Field) bool) RecordPredicate {
+	return func(record *iris.Record) bool {
+		for i := 0; i < record.FieldCount(); i++ {
+			if f := record.GetField(i); f.Key() == key {
+				return match(f)
+			}
+		}
+		return false
+	}
+}
+
+// MatchBool returns a predicate matching records whose boolean field key
+// equals want, e.g. MatchBool("audit", true).
+func MatchBool(key string, want bool) RecordPredicate {
+	return MatchField(key, func(f iris.Field) bool {
+		return f.IsBool() && f.BoolValue() == want
+	})
+}
+
+// MatchString returns a predicate matching records whose string field key
+// equals want.
+func MatchString(key, want string) RecordPredicate {
+	return MatchField(key, func(f iris.Field) bool {
+		return f.IsString() && f.StringValue() == want
+	})
+}
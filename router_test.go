@@ -0,0 +1,136 @@
+// router_test.go: Tests for attribute-based record routing
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/agilira/iris"
+)
+
+func readWithTimeout(t *testing.T, reader iris.SyncReader) *iris.Record {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	record, err := reader.Read(ctx)
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	return record
+}
+
+func TestRouter_SplitsByField(t *testing.T) {
+	provider := New(10)
+	router := NewRouter(provider, 10, Route{Name: "audit", Match: MatchBool("audit", true)})
+	defer func() { _ = router.Close() }() // Ignore error in test cleanup
+
+	logger := slog.New(provider)
+	logger.Info("user deleted", "audit", true)
+	logger.Info("cache warmed")
+
+	if record := readWithTimeout(t, router.Reader("audit")); record == nil || record.Msg != "user deleted" {
+		t.Errorf("audit route got %v, want 'user deleted'", record)
+	}
+	if record := readWithTimeout(t, router.Reader(DefaultRoute)); record == nil || record.Msg != "cache warmed" {
+		t.Errorf("default route got %v, want 'cache warmed'", record)
+	}
+	if router.Reader("missing") != nil {
+		t.Error("Reader() returned a reader for an unknown route")
+	}
+}
+
+func TestRouter_ClosedRouteDropsWithoutBlockingOthers(t *testing.T) {
+	provider := New(10)
+	router := NewRouter(provider, 1, Route{Name: "audit", Match: MatchString("kind", "audit")})
+	defer func() { _ = router.Close() }() // Ignore error in test cleanup
+
+	_ = router.Reader("audit").Close()
+
+	logger := slog.New(provider)
+	logger.Info("audit event", "kind", "audit")
+	logger.Info("regular event")
+
+	if record := readWithTimeout(t, router.Reader(DefaultRoute)); record == nil || record.Msg != "regular event" {
+		t.Fatalf("default route got %v, want 'regular event'", record)
+	}
+	if got := router.Dropped("audit"); got != 1 {
+		t.Errorf("Dropped(audit) = %d, want 1", got)
+	}
+}
+
+func TestRouter_CloseEndsRoutes(t *testing.T) {
+	provider := New(10)
+	router := NewRouter(provider, 10)
+
+	if err := router.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if record := readWithTimeout(t, router.Reader(DefaultRoute)); record != nil {
+		t.Errorf("Read() after Close = %v, want nil", record)
+	}
+}
+
+func TestRouter_CloseRoutesBufferedRecords(t *testing.T) {
+	provider := New(10)
+	router := NewRouter(provider, 10, Route{Name: "audit", Match: MatchBool("audit", true)})
+
+	logger := slog.New(provider)
+	for i := 0; i < 5; i++ {
+		logger.Info("user deleted", "audit", true)
+	}
+	if err := router.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	routed := 0
+	for readWithTimeout(t, router.Reader("audit")) != nil {
+		routed++
+	}
+	if routed != 5 {
+		t.Errorf("audit route got %d records after Close, want 5", routed)
+	}
+}
+
+// failingReader is a SyncReader whose Read always fails until closed.
+type failingReader struct {
+	reads  atomic.Int64
+	closed chan struct{}
+}
+
+func (f *failingReader) Read(ctx context.Context) (*iris.Record, error) {
+	f.reads.Add(1)
+	select {
+	case <-f.closed:
+		return nil, nil
+	default:
+		return nil, errors.New("transient")
+	}
+}
+
+func (f *failingReader) Close() error {
+	close(f.closed)
+	return nil
+}
+
+func TestRouter_BacksOffOnReadErrors(t *testing.T) {
+	source := &failingReader{closed: make(chan struct{})}
+	router := NewRouter(source, 10)
+
+	time.Sleep(50 * time.Millisecond)
+	if err := router.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	// Backoff from 1ms allows about 6 reads in 50ms; a busy loop makes millions.
+	if reads := source.reads.Load(); reads > 20 {
+		t.Errorf("Source read %d times in 50ms, want the pump to back off", reads)
+	}
+}
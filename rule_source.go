@@ -0,0 +1,181 @@
+// rule_source.go: Remote rule sources for fleet-wide configuration
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrRulesNotModified is returned by a RuleSource when the rules have not
+// changed since the previous successful fetch.
+var ErrRulesNotModified = errors.New("slog provider rules not modified")
+
+// maxRulesSize bounds the size of a remote rules document.
+const maxRulesSize = 1 << 20
+
+// defaultRulesTimeout bounds an HTTPRuleSource fetch without a Timeout.
+const defaultRulesTimeout = 10 * time.Second
+
+// RuleSource provides Rules from an external system, such as a central
+// configuration service pushing sampling, filter and level rules to a fleet.
+//
+// Implementations must be safe for use by a single polling goroutine and
+// may return ErrRulesNotModified to signal that the previous rules still
+// apply.
+type RuleSource interface {
+	Rules(ctx context.Context) (*Rules, error)
+}
+
+// PollRules fetches rules from src every interval and installs them with
+// SetRules until ctx is done, returning ctx.Err().
+//
+// Each wait is randomized by up to ±10% so that thousands of instances
+// started together do not poll in lockstep. Fetch and validation errors are
+// reported to onError, if non-nil, and leave the current rules in place.
+//
+//	src := slogprovider.NewHTTPRuleSource("https://config.internal/logging/rules.json")
+//	go provider.PollRules(ctx, src, 30*time.Second, func(err error) {
+//	    fmt.Fprintln(os.Stderr, "log rules:", err)
+//	})
+func (p *Provider) PollRules(ctx context.Context, src RuleSource, interval time.Duration, onError func(error)) error {
+	if interval <= 0 {
+		interval = 30 * time.Second
+	}
+
+	for {
+		rules, err := src.Rules(ctx)
+		switch {
+		case errors.Is(err, ErrRulesNotModified):
+		case err != nil:
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if onError != nil {
+				onError(err)
+			}
+		default:
+			if err := p.SetRules(rules); err != nil && onError != nil {
+				onError(err)
+			}
+		}
+
+		timer := time.NewTimer(jitter(interval))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// jitter randomizes d by up to ±10%.
+func jitter(d time.Duration) time.Duration {
+	spread := int64(d) / 5
+	if spread <= 0 {
+		return d
+	}
+	return d - time.Duration(spread/2) + time.Duration(rand.Int64N(spread)) // #nosec G404 -- jitter needs no crypto randomness
+}
+
+// HTTPRuleSource fetches rules as a JSON document over HTTP.
+//
+// The document uses the schema described in LoadRules. Conditional requests
+// (ETag / If-None-Match) keep polling cheap: unchanged documents are
+// answered with 304 Not Modified and reported as ErrRulesNotModified.
+//
+// Every fetch is bounded by Timeout and by the deadline of the context
+// passed to Rules, whichever comes first, so a hung endpoint cannot stall
+// PollRules.
+type HTTPRuleSource struct {
+	// URL of the rules document.
+	URL string
+
+	// Client performs the requests; http.DefaultClient when nil. Its own
+	// Timeout, if any, applies as well.
+	Client *http.Client
+
+	// Timeout bounds each fetch, including reading the document; 10s when
+	// zero or negative.
+	Timeout time.Duration
+
+	// Header is added to every request, e.g. for authentication.
+	Header http.Header
+
+	mu   sync.Mutex
+	etag string
+}
+
+// NewHTTPRuleSource creates a RuleSource polling url.
+func NewHTTPRuleSource(url string) *HTTPRuleSource {
+	return &HTTPRuleSource{URL: url}
+}
+
+// Rules implements RuleSource.
+func (s *HTTPRuleSource) Rules(ctx context.Context) (*Rules, error) {
+	timeout := s.Timeout
+	if timeout <= 0 {
+		timeout = defaultRulesTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.URL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("rules request: %w", err)
+	}
+	for key, values := range s.Header {
+		req.Header[key] = values
+	}
+	req.Header.Set("Accept", "application/json")
+
+	s.mu.Lock()
+	if s.etag != "" {
+		req.Header.Set("If-None-Match", s.etag)
+	}
+	s.mu.Unlock()
+
+	client := s.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("rules request: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotModified:
+		return nil, ErrRulesNotModified
+	default:
+		return nil, fmt.Errorf("rules request: unexpected status %s", resp.Status)
+	}
+
+	var settings map[string]any
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxRulesSize)).Decode(&settings); err != nil {
+		return nil, fmt.Errorf("rules document: %w", err)
+	}
+	rules, err := ParseRules(settings)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	s.etag = resp.Header.Get("ETag")
+	s.mu.Unlock()
+	return rules, nil
+}
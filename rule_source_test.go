@@ -0,0 +1,125 @@
+// rule_source_test.go: Tests for remote rule sources
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestHTTPRuleSource_ConditionalRequests(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = w.Write([]byte(`{"min_level":"warn"}`))
+	}))
+	defer server.Close()
+
+	src := NewHTTPRuleSource(server.URL)
+	ctx := context.Background()
+
+	rules, err := src.Rules(ctx)
+	if err != nil {
+		t.Fatalf("Rules() error = %v", err)
+	}
+	if rules.MinLevel == nil || *rules.MinLevel != slog.LevelWarn {
+		t.Errorf("MinLevel = %v, want WARN", rules.MinLevel)
+	}
+	if _, err := src.Rules(ctx); !errors.Is(err, ErrRulesNotModified) {
+		t.Errorf("second Rules() error = %v, want ErrRulesNotModified", err)
+	}
+}
+
+func TestHTTPRuleSource_Errors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "boom", http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	if _, err := NewHTTPRuleSource(server.URL).Rules(context.Background()); err == nil {
+		t.Error("Rules() returned no error for a 500 response")
+	}
+}
+
+func TestHTTPRuleSource_Timeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	src := NewHTTPRuleSource(server.URL)
+	src.Timeout = 20 * time.Millisecond
+	start := time.Now()
+	if _, err := src.Rules(context.Background()); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Rules() error = %v, want deadline exceeded", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := NewHTTPRuleSource(server.URL).Rules(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Rules() error = %v, want the context deadline", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Rules() took %v against a hung endpoint", elapsed)
+	}
+}
+
+type staticRuleSource struct {
+	rules *Rules
+	err   error
+}
+
+func (s staticRuleSource) Rules(context.Context) (*Rules, error) { return s.rules, s.err }
+
+func TestProvider_PollRules(t *testing.T) {
+	provider := New(10)
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	errLevel := slog.LevelError
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err := provider.PollRules(ctx, staticRuleSource{rules: &Rules{MinLevel: &errLevel}}, 10*time.Millisecond, nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("PollRules() error = %v, want deadline exceeded", err)
+	}
+	if provider.Enabled(context.Background(), slog.LevelWarn) {
+		t.Error("polled rules were not applied")
+	}
+}
+
+func TestProvider_PollRulesReportsErrors(t *testing.T) {
+	provider := New(10)
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+
+	var reported atomic.Int32
+	_ = provider.PollRules(ctx, staticRuleSource{err: errors.New("unreachable")}, 10*time.Millisecond, func(error) {
+		reported.Add(1)
+	})
+	if reported.Load() == 0 {
+		t.Error("fetch errors were not reported")
+	}
+}
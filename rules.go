@@ -0,0 +1,426 @@
+// rules.go: Runtime filter, sampling and level rules with hot reload
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/agilira/argus"
+)
+
+// Rules are runtime-adjustable filter, sampling and level-override rules.
+//
+// Rules complement the options given to New: options are fixed for the
+// lifetime of the provider, while rules can be replaced at any time with
+// SetRules or reloaded from a file with WatchRules, so operators can tune
+// log volume without deploys.
+type Rules struct {
+	// MinLevel, if set, replaces the WithMinLevel default.
+	MinLevel *slog.Level
+
+	// Levels maps logger names (dotted group paths) to minimum levels with
+	// the same longest-prefix semantics as WithLevelOverrides. A matching
+	// rule takes precedence over options.
+	Levels map[string]slog.Level
+
+	// Messages are message filter rules applied after option filters.
+	// Records whose message matches no rule pass, even when every rule is a
+	// KeepMessage rule, unless DropUnmatched is set.
+	Messages []MessageRule
+
+	// DropUnmatched drops records whose message matches none of Messages,
+	// turning the keep rules into an allow-list.
+	DropUnmatched bool
+
+	// Sampling, if set, applies a TickSampler after option samplers.
+	Sampling *SamplingRule
+
+	// ReadLevel, if set, replaces the WithReadLevel floor applied at Read.
+	ReadLevel *slog.Level
+}
+
+// SamplingRule configures the TickSampler created from Rules.
+type SamplingRule struct {
+	Tick       time.Duration `json:"tick"`
+	First      int           `json:"first"`
+	Thereafter int           `json:"thereafter"`
+}
+
+// activeRules is the compiled form of Rules consulted by the hot paths.
+type activeRules struct {
+	minLevel  *slog.Level
+	readLevel *slog.Level
+	levels    map[string]slog.Level
+	filter    *MessageFilter
+	sampler   *TickSampler
+	resolved  sync.Map // Logger name -> resolvedLevel cache
+
+	replacesSampler bool // Set by UpdateConfig to replace the Config.Sampling sampler
+}
+
+// resolvedLevel caches the rule lookup for one logger name.
+type resolvedLevel struct {
+	level slog.Level
+	ok    bool
+}
+
+// compileRules validates r and builds its runtime representation.
+func compileRules(r *Rules, clock Clock) (*activeRules, error) {
+	active := &activeRules{
+		minLevel:  r.MinLevel,
+		readLevel: r.ReadLevel,
+		levels:    make(map[string]slog.Level, len(r.Levels)),
+	}
+	for name, level := range r.Levels {
+		active.levels[name] = level
+	}
+	if len(r.Messages) > 0 || r.DropUnmatched {
+		// A final catch-all rule makes the default explicit, instead of the
+		// allow-list MessageFilter infers from keep rules alone.
+		unmatched := MessageRule{Action: KeepMessage, Glob: "*"}
+		if r.DropUnmatched {
+			unmatched.Action = DropMessage
+		}
+		filter, err := NewMessageFilter(append(slices.Clip(r.Messages), unmatched)...)
+		if err != nil {
+			return nil, err
+		}
+		active.filter = filter
+	}
+	if s := r.Sampling; s != nil {
+		if s.Tick <= 0 {
+			return nil, fmt.Errorf("sampling tick must be positive, got %v", s.Tick)
+		}
+		active.sampler = NewTickSampler(s.Tick, s.First, s.Thereafter).WithClock(clock)
+	}
+	return active, nil
+}
+
+// levelFor returns the rule-defined minimum level for the logger name.
+func (r *activeRules) levelFor(name string) (slog.Level, bool) {
+	if cached, ok := r.resolved.Load(name); ok {
+		res := cached.(resolvedLevel)
+		return res.level, res.ok
+	}
+
+	var res resolvedLevel
+	if r.minLevel != nil {
+		res = resolvedLevel{level: *r.minLevel, ok: true}
+	}
+	best := -1
+	for prefix, level := range r.levels {
+		if len(prefix) > best && matchesLoggerName(name, prefix) {
+			res, best = resolvedLevel{level: level, ok: true}, len(prefix)
+		}
+	}
+	r.resolved.Store(name, res)
+	return res.level, res.ok
+}
+
+// admit applies the rule filters and sampler to record.
+func (r *activeRules) admit(record slog.Record) bool {
+	if r.filter != nil && !r.filter.Allow(record.Message) {
+		return false
+	}
+	return r.sampler == nil || r.sampler.Sample(record)
+}
+
+// replaces reports whether r replaces the sampler created from
+// Config.Sampling, see UpdateConfig. It is safe on a nil r.
+func (r *activeRules) replaces() bool {
+	return r != nil && r.replacesSampler
+}
+
+// SetRules atomically replaces the provider's runtime rules. A nil r removes
+// all rules, restoring the behavior configured by options. Buffered records
+// are not affected.
+func (p *Provider) SetRules(r *Rules) error {
+	if r == nil {
+		p.rules.Store(nil)
+		return nil
+	}
+	active, err := compileRules(r, p.opts.clock)
+	if err != nil {
+		return err
+	}
+	p.opts.warmRules(active)
+	p.rules.Store(active)
+	return nil
+}
+
+// enabledFor reports whether level passes the rules for the logger name,
+// falling back to the handler's option-derived minimum static.
+func (p *Provider) enabledFor(name string, static slog.Leveler, level slog.Level) bool {
+	if r := p.rules.Load(); r != nil {
+		if min, ok := r.levelFor(name); ok {
+			return level >= min
+		}
+	}
+	return levelEnabled(static, level)
+}
+
+// LoadRules reads rules from a configuration file.
+//
+// The format is detected from the file extension (JSON, YAML, TOML, ...)
+// using argus. Settings may be nested or written as dotted keys, which keeps
+// the format usable with flat parsers:
+//
+//	min_level: info
+//	read_level: warn
+//	level.db: warn
+//	level.http: info
+//	keep.payments: "payment*"
+//	drop.grpc: "grpc: addrConn.*"
+//	drop.retry: "re:^retrying in \d+ms$"
+//	drop_unmatched: false
+//	sampling.tick: 1s
+//	sampling.first: 100
+//	sampling.thereafter: 100
+//
+// Message patterns are globs unless prefixed with "re:". Keep rules are
+// evaluated before drop rules, so they act as exceptions; within each kind
+// rules are evaluated in key order (list order for JSON arrays). Messages
+// matching no rule pass, also in a file with keep rules only, unless
+// drop_unmatched is true, which makes the keep rules an allow-list. Unknown
+// settings are reported as errors to catch typos.
+func LoadRules(path string) (*Rules, error) {
+	format := argus.DetectFormat(path)
+	if format == argus.FormatUnknown {
+		return nil, fmt.Errorf("unsupported rules format for file: %s", path)
+	}
+	data, err := os.ReadFile(path) // #nosec G304 -- path is provided by the application
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rules file: %w", err)
+	}
+	settings, err := argus.ParseConfig(data, format)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse rules file %s: %w", path, err)
+	}
+	return ParseRules(settings)
+}
+
+// ParseRules builds Rules from decoded configuration settings, as produced
+// by encoding/json or a configuration library. See LoadRules for the schema.
+func ParseRules(settings map[string]any) (*Rules, error) {
+	flat := make(map[string]any)
+	flattenSettings("", settings, flat)
+
+	keys := make([]string, 0, len(flat))
+	for key := range flat {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	r := &Rules{}
+	var keep, drop []MessageRule
+	for _, key := range keys {
+		value := flat[key]
+		section, name, _ := strings.Cut(key, ".")
+
+		var err error
+		switch {
+		case key == "min_level":
+			var level slog.Level
+			level, err = parseLevelSetting(value)
+			r.MinLevel = &level
+		case key == "read_level":
+			var level slog.Level
+			level, err = parseLevelSetting(value)
+			r.ReadLevel = &level
+		case key == "drop_unmatched":
+			r.DropUnmatched, err = settingBool(value)
+		case section == "level" && name != "":
+			if r.Levels == nil {
+				r.Levels = make(map[string]slog.Level)
+			}
+			r.Levels[name], err = parseLevelSetting(value)
+		case (section == "keep" || section == "drop") && name != "":
+			rule := messageRuleSetting(settingString(value))
+			if section == "keep" {
+				rule.Action = KeepMessage
+				keep = append(keep, rule)
+			} else {
+				drop = append(drop, rule)
+			}
+		case section == "sampling":
+			if r.Sampling == nil {
+				r.Sampling = &SamplingRule{}
+			}
+			err = r.Sampling.set(name, value)
+		default:
+			err = fmt.Errorf("unknown setting")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("rules setting %q: %w", key, err)
+		}
+	}
+	r.Messages = append(keep, drop...)
+
+	if _, err := compileRules(r, nil); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// WatchRules loads rules from path, applies them, and reloads them whenever
+// the file changes, polling every interval (5s when non-positive).
+//
+// Invalid files are reported to onError, if non-nil, and leave the current
+// rules in place. The returned Closer stops watching; it does not remove the
+// applied rules.
+func (p *Provider) WatchRules(path string, interval time.Duration, onError func(error)) (io.Closer, error) {
+	if onError == nil {
+		onError = func(error) {}
+	}
+
+	r, err := LoadRules(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.SetRules(r); err != nil {
+		return nil, err
+	}
+
+	watcher := argus.New(argus.Config{
+		PollInterval: interval,
+		// Audit trail disabled: rule reloads are not security relevant here.
+		Audit: argus.AuditConfig{Enabled: false, MinLevel: argus.AuditCritical},
+		ErrorHandler: func(err error, path string) {
+			onError(fmt.Errorf("rules watcher error for %s: %w", path, err))
+		},
+	})
+	err = watcher.Watch(path, func(event argus.ChangeEvent) {
+		if event.IsDelete {
+			return
+		}
+		r, err := LoadRules(event.Path)
+		if err == nil {
+			err = p.SetRules(r)
+		}
+		if err != nil {
+			onError(err)
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to watch rules file: %w", err)
+	}
+	if err := watcher.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start rules watcher: %w", err)
+	}
+	return watcher, nil
+}
+
+// set assigns a sampling setting.
+func (s *SamplingRule) set(name string, value any) error {
+	var err error
+	switch name {
+	case "tick":
+		s.Tick, err = settingDuration(value)
+	case "first":
+		s.First, err = settingInt(value)
+	case "thereafter":
+		s.Thereafter, err = settingInt(value)
+	default:
+		err = fmt.Errorf("unknown setting")
+	}
+	return err
+}
+
+// flattenSettings converts nested maps and lists into dotted keys. List
+// indexes are zero-padded so that key order preserves list order.
+func flattenSettings(prefix string, value any, out map[string]any) {
+	join := func(key string) string {
+		if prefix == "" {
+			return key
+		}
+		return prefix + "." + key
+	}
+
+	switch v := value.(type) {
+	case map[string]any:
+		for key, nested := range v {
+			flattenSettings(join(key), nested, out)
+		}
+	case []any:
+		for i, nested := range v {
+			flattenSettings(join(fmt.Sprintf("%06d", i)), nested, out)
+		}
+	default:
+		out[prefix] = v
+	}
+}
+
+// settingString renders a setting as a string, removing the quotes that
+// flat parsers may leave around values.
+func settingString(value any) string {
+	s := strings.TrimSpace(fmt.Sprint(value))
+	if len(s) >= 2 && (s[0] == '"' || s[0] == '\'') && s[len(s)-1] == s[0] {
+		s = s[1 : len(s)-1]
+	}
+	return s
+}
+
+// settingInt parses an integer setting.
+func settingInt(value any) (int, error) {
+	switch v := value.(type) {
+	case int:
+		return v, nil
+	case int64:
+		return int(v), nil
+	case float64:
+		return int(v), nil
+	default:
+		return strconv.Atoi(settingString(value))
+	}
+}
+
+// settingDuration parses a duration setting, either a Go duration string or
+// a number of nanoseconds as encoded by encoding/json.
+func settingDuration(value any) (time.Duration, error) {
+	switch v := value.(type) {
+	case int:
+		return time.Duration(v), nil
+	case int64:
+		return time.Duration(v), nil
+	case float64:
+		return time.Duration(v), nil
+	default:
+		return time.ParseDuration(settingString(value))
+	}
+}
+
+// settingBool parses a boolean setting.
+func settingBool(value any) (bool, error) {
+	if b, ok := value.(bool); ok {
+		return b, nil
+	}
+	return strconv.ParseBool(settingString(value))
+}
+
+// parseLevelSetting parses a slog level name such as "info" or "DEBUG-4".
+func parseLevelSetting(value any) (slog.Level, error) {
+	var level slog.Level
+	err := level.UnmarshalText([]byte(settingString(value)))
+	return level, err
+}
+
+// messageRuleSetting parses a glob or "re:"-prefixed regexp pattern.
+func messageRuleSetting(pattern string) MessageRule {
+	if expr, ok := strings.CutPrefix(pattern, "re:"); ok {
+		return MessageRule{Regexp: expr}
+	}
+	return MessageRule{Glob: pattern}
+}
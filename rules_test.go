@@ -0,0 +1,161 @@
+// rules_test.go: Tests for runtime rules and rule file hot reload
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestParseRules_NestedJSON(t *testing.T) {
+	var settings map[string]any
+	doc := `{
+		"min_level": "info",
+		"level": {"db": "warn", "db.pool": "debug"},
+		"keep": ["grpc: important*"],
+		"drop": ["grpc: *", "re:^retrying"],
+		"sampling": {"tick": "1s", "first": 10, "thereafter": 5}
+	}`
+	if err := json.Unmarshal([]byte(doc), &settings); err != nil {
+		t.Fatal(err)
+	}
+
+	rules, err := ParseRules(settings)
+	if err != nil {
+		t.Fatalf("ParseRules() error = %v", err)
+	}
+	if rules.MinLevel == nil || *rules.MinLevel != slog.LevelInfo {
+		t.Errorf("MinLevel = %v, want INFO", rules.MinLevel)
+	}
+	if rules.Levels["db.pool"] != slog.LevelDebug || rules.Levels["db"] != slog.LevelWarn {
+		t.Errorf("Levels = %v", rules.Levels)
+	}
+	if len(rules.Messages) != 3 || rules.Messages[0].Action != KeepMessage || rules.Messages[2].Regexp != "^retrying" {
+		t.Errorf("Messages = %+v, want keep rule first and drop rules in list order", rules.Messages)
+	}
+	if s := rules.Sampling; s == nil || s.Tick != time.Second || s.First != 10 || s.Thereafter != 5 {
+		t.Errorf("Sampling = %+v", rules.Sampling)
+	}
+}
+
+func TestParseRules_Errors(t *testing.T) {
+	invalid := []map[string]any{
+		{"min_level": "loud"},
+		{"levle": map[string]any{"db": "warn"}},
+		{"drop": []any{"re:("}},
+		{"sampling": map[string]any{"first": 1}},
+	}
+	for _, settings := range invalid {
+		if _, err := ParseRules(settings); err == nil {
+			t.Errorf("ParseRules(%v) returned no error", settings)
+		}
+	}
+}
+
+func TestProvider_SetRules(t *testing.T) {
+	provider := New(10, WithMinLevel(slog.LevelDebug))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	warn := slog.LevelWarn
+	err := provider.SetRules(&Rules{
+		MinLevel: &warn,
+		Levels:   map[string]slog.Level{"http": slog.LevelInfo},
+		Messages: []MessageRule{{Action: DropMessage, Glob: "noisy*"}},
+	})
+	if err != nil {
+		t.Fatalf("SetRules() error = %v", err)
+	}
+
+	ctx := context.Background()
+	root := slog.New(provider)
+	if root.Enabled(ctx, slog.LevelInfo) {
+		t.Error("rule MinLevel must override the option default")
+	}
+	if !root.WithGroup("http").Enabled(ctx, slog.LevelInfo) {
+		t.Error("rule level override for http not applied")
+	}
+
+	root.Error("noisy failure")
+	root.Error("real failure")
+	if got := provider.queue.len(); got != 1 {
+		t.Errorf("buffered %d records, want 1", got)
+	}
+
+	_ = provider.SetRules(nil)
+	if !root.Enabled(ctx, slog.LevelDebug) {
+		t.Error("clearing rules must restore option levels")
+	}
+}
+
+func TestProvider_SetRules_KeepOnlyRules(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		settings map[string]any
+		want     int
+	}{
+		{"unmatched pass", map[string]any{"keep": []any{"payment*"}}, 2},
+		{"allow-list", map[string]any{"keep": []any{"payment*"}, "drop_unmatched": true}, 1},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			rules, err := ParseRules(tc.settings)
+			if err != nil {
+				t.Fatalf("ParseRules() error = %v", err)
+			}
+			provider := New(10)
+			defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+			if err := provider.SetRules(rules); err != nil {
+				t.Fatalf("SetRules() error = %v", err)
+			}
+
+			logger := slog.New(provider)
+			logger.Info("payment accepted")
+			logger.Info("cache warmed")
+			if got := provider.queue.len(); got != tc.want {
+				t.Errorf("buffered %d records, want %d", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestProvider_WatchRules(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.yaml")
+	if err := os.WriteFile(path, []byte("min_level: warn\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	provider := New(10)
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	watcher, err := provider.WatchRules(path, 20*time.Millisecond, nil)
+	if err != nil {
+		t.Fatalf("WatchRules() error = %v", err)
+	}
+	defer func() { _ = watcher.Close() }() // Ignore error in test cleanup
+
+	ctx := context.Background()
+	if provider.Enabled(ctx, slog.LevelInfo) {
+		t.Fatal("initial rules not applied")
+	}
+
+	time.Sleep(50 * time.Millisecond) // Ensure a distinct modification time
+	if err := os.WriteFile(path, []byte("min_level: debug\nlevel.db: error\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for !provider.Enabled(ctx, slog.LevelInfo) {
+		if time.Now().After(deadline) {
+			t.Fatal("rules were not reloaded")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,145 @@
+// sampler.go: Record sampling evaluated before buffering
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"log/slog"
+	"sync/atomic"
+	"time"
+)
+
+// Sampler decides whether a record is admitted into the provider buffer.
+//
+// Samplers run in Handle after level checks and filters, on the caller's
+// goroutine, and must be safe for concurrent use.
+type Sampler interface {
+	Sample(record slog.Record) bool
+}
+
+// WithSampler admits records into the buffer only when s samples them.
+func WithSampler(s Sampler) Option {
+	return func(o *options) { o.sampler = s }
+}
+
+// sampled reports whether record passes the configured sampler, if any.
+func (o *options) sampled(record slog.Record) bool {
+	return o.sampler == nil || o.sampler.Sample(record)
+}
+
+// tickSamplerSlots is the number of counters used by TickSampler. Messages
+// are hashed onto slots, so distinct messages may occasionally share one.
+const tickSamplerSlots = 4096
+
+// SamplerStats reports how many records a sampler admitted and rejected.
+type SamplerStats struct {
+	Sampled uint64 // Records admitted
+	Dropped uint64 // Records sampled away
+}
+
+// TickSampler implements zap-style "first N then every Mth" sampling.
+//
+// Within each tick interval, for every unique level and message, the first
+// `first` records are admitted and after that only every `thereafter`-th
+// record. Counters reset at the start of each interval. This keeps the
+// beginning of every burst intact while bounding the volume of hot loops.
+//
+// Counting is lock-free: counters live in a fixed array indexed by a hash of
+// level and message, so the memory cost is constant regardless of the
+// number of distinct messages.
+type TickSampler struct {
+	tick       int64 // Interval length in nanoseconds
+	first      uint64
+	thereafter uint64
+	clock      Clock // Time source of the ticks, nil for the system clock
+	counters   [tickSamplerSlots]tickCounter
+	sampled    atomic.Uint64
+	dropped    atomic.Uint64
+}
+
+// tickCounter counts occurrences of one slot within the current interval.
+type tickCounter struct {
+	resetAt atomic.Int64
+	count   atomic.Uint64
+}
+
+// NewTickSampler creates a sampler admitting, per message and tick, the first
+// `first` records and every `thereafter`-th record after that. A thereafter
+// of 0 drops every record beyond the first `first` in the interval.
+//
+//	sampler := slogprovider.NewTickSampler(time.Second, 100, 100)
+//	provider := slogprovider.New(1000, slogprovider.WithSampler(sampler))
+func NewTickSampler(tick time.Duration, first, thereafter int) *TickSampler {
+	if first < 0 {
+		first = 0
+	}
+	if thereafter < 0 {
+		thereafter = 0
+	}
+	return &TickSampler{
+		tick:       int64(tick),
+		first:      uint64(first),      // #nosec G115 -- clamped to non-negative above
+		thereafter: uint64(thereafter), // #nosec G115 -- clamped to non-negative above
+	}
+}
+
+// WithClock sets the clock that times the ticks of s, nil for the system
+// clock, and returns s. Call it before s is in use.
+func (s *TickSampler) WithClock(c Clock) *TickSampler {
+	s.clock = c
+	return s
+}
+
+// Sample implements Sampler.
+func (s *TickSampler) Sample(record slog.Record) bool {
+	slot := hashLevelMessage(record.Level, record.Message) % tickSamplerSlots
+	n := s.counters[slot].inc(clockNow(s.clock).UnixNano(), s.tick)
+
+	if n <= s.first || (s.thereafter > 0 && (n-s.first)%s.thereafter == 0) {
+		s.sampled.Add(1)
+		return true
+	}
+	s.dropped.Add(1)
+	return false
+}
+
+// Stats returns the number of admitted and sampled-away records.
+func (s *TickSampler) Stats() SamplerStats {
+	return SamplerStats{
+		Sampled: s.sampled.Load(),
+		Dropped: s.dropped.Load(),
+	}
+}
+
+// inc increments the counter, resetting it when the interval has elapsed,
+// and returns the count within the current interval.
+func (c *tickCounter) inc(now, tick int64) uint64 {
+	resetAt := c.resetAt.Load()
+	if resetAt > now {
+		return c.count.Add(1)
+	}
+
+	c.count.Store(1)
+	if !c.resetAt.CompareAndSwap(resetAt, now+tick) {
+		// Another goroutine started the new interval first.
+		return c.count.Add(1)
+	}
+	return 1
+}
+
+// hashLevelMessage computes an FNV-1a hash of level and message.
+func hashLevelMessage(level slog.Level, msg string) uint32 {
+	const (
+		offset32 = 2166136261
+		prime32  = 16777619
+	)
+	h := uint32(offset32)
+	h = (h ^ uint32(level)) * prime32 // #nosec G115 -- wraparound is fine for hashing
+	for i := 0; i < len(msg); i++ {
+		h = (h ^ uint32(msg[i])) * prime32
+	}
+	return h
+}
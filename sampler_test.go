@@ -0,0 +1,71 @@
+// sampler_test.go: Tests for record sampling
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func TestTickSampler_FirstThenEveryMth(t *testing.T) {
+	sampler := NewTickSampler(time.Hour, 3, 5)
+	record := slog.NewRecord(time.Now(), slog.LevelInfo, "retrying", 0)
+
+	var admitted []int
+	for i := 1; i <= 20; i++ {
+		if sampler.Sample(record) {
+			admitted = append(admitted, i)
+		}
+	}
+
+	want := []int{1, 2, 3, 8, 13, 18}
+	if len(admitted) != len(want) {
+		t.Fatalf("admitted %v, want %v", admitted, want)
+	}
+	for i := range want {
+		if admitted[i] != want[i] {
+			t.Fatalf("admitted %v, want %v", admitted, want)
+		}
+	}
+
+	stats := sampler.Stats()
+	if stats.Sampled != 6 || stats.Dropped != 14 {
+		t.Errorf("Stats() = %+v, want 6 sampled and 14 dropped", stats)
+	}
+}
+
+func TestTickSampler_CountsPerMessageAndResetsPerTick(t *testing.T) {
+	sampler := NewTickSampler(20*time.Millisecond, 1, 0)
+	a := slog.NewRecord(time.Now(), slog.LevelInfo, "a", 0)
+	b := slog.NewRecord(time.Now(), slog.LevelInfo, "b", 0)
+
+	if !sampler.Sample(a) || !sampler.Sample(b) {
+		t.Fatal("first record of each message must be admitted")
+	}
+	if sampler.Sample(a) {
+		t.Fatal("second record within the tick must be dropped")
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	if !sampler.Sample(a) {
+		t.Error("first record of a new tick must be admitted")
+	}
+}
+
+func TestWithSampler(t *testing.T) {
+	provider := New(100, WithSampler(NewTickSampler(time.Hour, 2, 0)))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	logger := slog.New(provider)
+	for i := 0; i < 10; i++ {
+		logger.Info("poll")
+	}
+	if got := provider.queue.len(); got != 2 {
+		t.Errorf("buffered %d records, want 2", got)
+	}
+}
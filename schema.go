@@ -0,0 +1,175 @@
+// schema.go: Declared field schema and validation of converted records
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+
+	"github.com/agilira/iris"
+)
+
+// SchemaViolationKey is the field key used to mark records that violate the
+// declared schema.
+const SchemaViolationKey = "schema_violation"
+
+// builtinKeys are the keys of the fields added by the provider, which
+// strict schemas accept without declaration.
+var builtinKeys = map[string]bool{
+	slog.TimeKey:       true,
+	SequenceKey:        true,
+	LevelNameKey:       true,
+	SlogLevelKey:       true,
+	SourceFunctionKey:  true,
+	SourceFileKey:      true,
+	SourceLineKey:      true,
+	SchemaViolationKey: true,
+}
+
+// FieldSpec declares the expected type of a field and whether it is required.
+type FieldSpec struct {
+	// Kind is the expected kind of the converted field. slog.KindAny accepts
+	// any kind. Kinds follow the conversion rules: slog.KindInt64 for signed
+	// integers, slog.KindString for values converted through their String
+	// method, and so on.
+	Kind slog.Kind
+
+	// Required reports a violation when the field is missing.
+	Required bool
+}
+
+// Schema declares the fields records are expected to carry, catching drift
+// between logging code and the dashboards and alerts that consume the logs.
+type Schema struct {
+	// Fields maps field keys, as they appear in converted records, to their
+	// specification.
+	Fields map[string]FieldSpec
+
+	// Strict also reports fields that are not declared in Fields, except the
+	// fields the provider adds itself: slog.TimeKey, SequenceKey,
+	// LevelNameKey, SlogLevelKey, the WithAddSource keys and
+	// SchemaViolationKey. Declaring them in Fields still checks them.
+	Strict bool
+
+	// OnViolation, if set, is called on the reader goroutine with each
+	// violating record and its violations. When OnViolation is nil the
+	// violations are recorded in a SchemaViolationKey string field instead.
+	OnViolation func(record *iris.Record, violations []SchemaViolation)
+}
+
+// SchemaViolation describes a single mismatch between a record and its
+// schema.
+type SchemaViolation struct {
+	Key    string // Field key
+	Reason string // e.g. "missing" or "want Int64, got String"
+}
+
+// String returns the violation as "key: reason".
+func (v SchemaViolation) String() string {
+	return v.Key + ": " + v.Reason
+}
+
+// WithSchema validates converted records against s on the Read path, before
+// RecordMiddleware runs. Records are delivered whether or not they conform;
+// validation only reports the mismatches.
+//
+//	schema := slogprovider.Schema{Fields: map[string]slogprovider.FieldSpec{
+//	    "user_id":  {Kind: slog.KindInt64, Required: true},
+//	    "duration": {Kind: slog.KindDuration},
+//	}}
+//	provider := slogprovider.New(1000, slogprovider.WithSchema(schema))
+//
+// The field map is copied, so later modifications do not affect the provider.
+func WithSchema(s Schema) Option {
+	fields := make(map[string]FieldSpec, len(s.Fields))
+	for key, spec := range s.Fields {
+		fields[key] = spec
+	}
+	s.Fields = fields
+	return func(o *options) { o.schema = &s }
+}
+
+// Validate returns the violations of record against the schema, or nil when
+// the record conforms.
+func (s *Schema) Validate(record *iris.Record) []SchemaViolation {
+	var violations []SchemaViolation
+	seen := make(map[string]bool, record.FieldCount())
+	for i := 0; i < record.FieldCount(); i++ {
+		field := record.GetField(i)
+		key := field.Key()
+		seen[key] = true
+
+		spec, declared := s.Fields[key]
+		switch {
+		case !declared:
+			if s.Strict && !builtinKeys[key] {
+				violations = append(violations, SchemaViolation{Key: key, Reason: "undeclared"})
+			}
+		case spec.Kind != slog.KindAny:
+			if got := fieldKind(field); got != spec.Kind {
+				violations = append(violations, SchemaViolation{
+					Key:    key,
+					Reason: fmt.Sprintf("want %v, got %v", spec.Kind, got),
+				})
+			}
+		}
+	}
+
+	var missing []string
+	for key, spec := range s.Fields {
+		if spec.Required && !seen[key] {
+			missing = append(missing, key)
+		}
+	}
+	sort.Strings(missing)
+	for _, key := range missing {
+		violations = append(violations, SchemaViolation{Key: key, Reason: "missing"})
+	}
+	return violations
+}
+
+// validate reports the violations of record, if any.
+func (s *Schema) validate(record *iris.Record) {
+	violations := s.Validate(record)
+	if len(violations) == 0 {
+		return
+	}
+	if s.OnViolation != nil {
+		s.OnViolation(record, violations)
+		return
+	}
+
+	parts := make([]string, len(violations))
+	for i, v := range violations {
+		parts[i] = v.String()
+	}
+	record.AddField(iris.String(SchemaViolationKey, strings.Join(parts, "; ")))
+}
+
+// fieldKind maps the type of a converted field to the corresponding slog.Kind.
+func fieldKind(f iris.Field) slog.Kind {
+	switch {
+	case f.IsString():
+		return slog.KindString
+	case f.IsInt():
+		return slog.KindInt64
+	case f.IsUint():
+		return slog.KindUint64
+	case f.IsFloat():
+		return slog.KindFloat64
+	case f.IsBool():
+		return slog.KindBool
+	case f.IsDuration():
+		return slog.KindDuration
+	case f.IsTime():
+		return slog.KindTime
+	default:
+		return slog.KindAny
+	}
+}
@@ -0,0 +1,118 @@
+// schema_test.go: Tests for field schema validation
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/agilira/iris"
+)
+
+var testSchema = map[string]FieldSpec{
+	"user_id":  {Kind: slog.KindInt64, Required: true},
+	"duration": {Kind: slog.KindDuration},
+	"payload":  {Kind: slog.KindAny},
+}
+
+func TestSchema_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		strict bool
+		fields []iris.Field
+		want   []string
+	}{
+		{"conforming", false, []iris.Field{iris.Int64("user_id", 1), iris.Dur("duration", time.Second)}, nil},
+		{"any kind", false, []iris.Field{iris.Int64("user_id", 1), iris.String("payload", "x")}, nil},
+		{"missing required", false, []iris.Field{iris.Dur("duration", time.Second)}, []string{"user_id: missing"}},
+		{"wrong kind", false, []iris.Field{iris.String("user_id", "42")}, []string{"user_id: want Int64, got String"}},
+		{"undeclared lenient", false, []iris.Field{iris.Int64("user_id", 1), iris.String("extra", "x")}, nil},
+		{"undeclared strict", true, []iris.Field{iris.Int64("user_id", 1), iris.String("extra", "x")}, []string{"extra: undeclared"}},
+		{"builtin keys strict", true, []iris.Field{iris.Time(slog.TimeKey, time.Now()), iris.Uint64(SequenceKey, 1), iris.Int64("user_id", 1)}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Schema{Fields: testSchema, Strict: tt.strict}
+			record := iris.NewRecord(iris.Info, "msg")
+			for _, f := range tt.fields {
+				record.AddField(f)
+			}
+
+			violations := s.Validate(record)
+			if len(violations) != len(tt.want) {
+				t.Fatalf("violations = %v, want %v", violations, tt.want)
+			}
+			for i, v := range violations {
+				if v.String() != tt.want[i] {
+					t.Errorf("violation[%d] = %q, want %q", i, v, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestWithSchema_MarksViolatingRecords(t *testing.T) {
+	provider := New(10, WithSchema(Schema{Fields: testSchema}))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	record := readRecord(t, provider, func(l *slog.Logger) {
+		l.Info("login", "user_id", "alice", "duration", time.Second)
+	})
+	field, ok := findField(record, SchemaViolationKey)
+	if !ok || field.StringValue() != "user_id: want Int64, got String" {
+		t.Errorf("%s = %q, %v", SchemaViolationKey, field.StringValue(), ok)
+	}
+
+	record = readRecord(t, provider, func(l *slog.Logger) { l.Info("login", "user_id", 7) })
+	if _, ok := findField(record, SchemaViolationKey); ok {
+		t.Error("conforming record marked as violating")
+	}
+}
+
+func TestWithSchema_StrictAcceptsBuiltinFields(t *testing.T) {
+	var reported []SchemaViolation
+	provider := New(10, WithSequence(), WithSlogLevel(), WithSchema(Schema{
+		Fields: testSchema,
+		Strict: true,
+		OnViolation: func(record *iris.Record, violations []SchemaViolation) {
+			reported = append(reported, violations...)
+		},
+	}))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	readRecord(t, provider, func(l *slog.Logger) { l.Info("login", "user_id", 7) })
+	if len(reported) != 0 {
+		t.Errorf("reported = %v, want the provider's own fields accepted", reported)
+	}
+
+	plain := New(10, WithSchema(Schema{Fields: testSchema, Strict: true}))
+	defer func() { _ = plain.Close() }() // Ignore error in test cleanup
+	record := readRecord(t, plain, func(l *slog.Logger) { l.Info("login", "user_id", 7) })
+	if field, ok := findField(record, SchemaViolationKey); ok {
+		t.Errorf("Default options marked as violating: %q", field.StringValue())
+	}
+}
+
+func TestWithSchema_ReportsToCallback(t *testing.T) {
+	var reported []SchemaViolation
+	provider := New(10, WithSchema(Schema{
+		Fields: testSchema,
+		OnViolation: func(record *iris.Record, violations []SchemaViolation) {
+			reported = append(reported, violations...)
+		},
+	}))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	record := readRecord(t, provider, func(l *slog.Logger) { l.Info("anonymous") })
+	if len(reported) != 1 || reported[0].Key != "user_id" {
+		t.Errorf("reported = %v, want missing user_id", reported)
+	}
+	if _, ok := findField(record, SchemaViolationKey); ok {
+		t.Error("record marked although OnViolation is set")
+	}
+}
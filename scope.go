@@ -0,0 +1,50 @@
+// scope.go: Per-scope record quotas for child handlers
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"log/slog"
+	"sync/atomic"
+)
+
+// scope is the record quota shared by a NewScoped handler and the handlers
+// derived from it.
+type scope struct {
+	quota int64
+	used  atomic.Int64
+}
+
+// admit consumes one record of the quota, reporting false once it is spent.
+func (s *scope) admit() bool {
+	return s.used.Add(1) <= s.quota
+}
+
+// NewScoped returns a lightweight child handler of parent that shares its
+// buffer but accepts at most quota records, so a single pathological
+// request cannot monopolize the pipeline:
+//
+//	func middleware(next http.Handler) http.Handler {
+//	    return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+//	        logger := slog.New(slogprovider.NewScoped(provider, 500))
+//	        next.ServeHTTP(w, r.WithContext(withLogger(r.Context(), logger)))
+//	    })
+//	}
+//
+// Handlers derived with WithAttrs and WithGroup share the quota of their
+// scope. Every record handled through the scope consumes the quota, before
+// level rules, filters or sampling apply; records beyond it are discarded
+// and counted in Stats().QuotaDropped. Creating a scope costs a single
+// allocation besides the handler. NewScoped panics if quota is not
+// positive.
+func NewScoped(parent *Provider, quota int) slog.Handler {
+	if quota <= 0 {
+		panic("slogprovider: NewScoped requires a positive quota")
+	}
+	h := parent.newGroupHandler("", nil)
+	h.scope = &scope{quota: int64(quota)}
+	return h
+}
@@ -0,0 +1,83 @@
+// scope_test.go: Tests for per-scope record quotas
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"log/slog"
+	"testing"
+)
+
+func TestNewScoped_EnforcesQuota(t *testing.T) {
+	provider := New(10)
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	logger := slog.New(NewScoped(provider, 2))
+	for _, msg := range []string{"one", "two", "three", "four"} {
+		logger.Info(msg)
+	}
+
+	if msgs := readMessages(t, provider, 2); msgs[0] != "one" || msgs[1] != "two" {
+		t.Errorf("Unexpected records %v", msgs)
+	}
+	if n := provider.Len(); n != 0 {
+		t.Errorf("Expected records beyond the quota to be discarded, %d buffered", n)
+	}
+	if stats := provider.Stats(); stats.QuotaDropped != 2 || stats.Dropped != 0 {
+		t.Errorf("Expected 2 records dropped by quota, got %+v", stats)
+	}
+}
+
+func TestNewScoped_DerivedHandlersShareQuota(t *testing.T) {
+	provider := New(10)
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	logger := slog.New(NewScoped(provider, 3))
+	logger.Info("root")
+	logger.With("id", 1).Info("bound")
+	logger.WithGroup("db").Info("grouped")
+	logger.WithGroup("db").With("id", 2).Info("over quota")
+
+	if stats := provider.Stats(); stats.Handled != 3 || stats.QuotaDropped != 1 {
+		t.Errorf("Expected the derived handlers to share the quota, got %+v", stats)
+	}
+}
+
+func TestNewScoped_ScopesAreIndependent(t *testing.T) {
+	provider := New(10)
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	noisy := slog.New(NewScoped(provider, 1))
+	quiet := slog.New(NewScoped(provider, 1))
+	noisy.Info("noisy 1")
+	noisy.Info("noisy 2")
+	quiet.Info("quiet")
+
+	if msgs := readMessages(t, provider, 2); msgs[0] != "noisy 1" || msgs[1] != "quiet" {
+		t.Errorf("Unexpected records %v", msgs)
+	}
+}
+
+func TestNewScoped_IsLightweight(t *testing.T) {
+	provider := New(10)
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	if allocs := testing.AllocsPerRun(100, func() { _ = NewScoped(provider, 500) }); allocs > 2 {
+		t.Errorf("NewScoped allocated %.0f times, want at most 2", allocs)
+	}
+}
+
+func TestNewScoped_PanicsWithoutQuota(t *testing.T) {
+	provider := New(10)
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected NewScoped to panic for a zero quota")
+		}
+	}()
+	NewScoped(provider, 0)
+}
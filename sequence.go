@@ -0,0 +1,34 @@
+// sequence.go: Per-record monotonic index for resequencing and loss detection
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+// SequenceKey is the field key used for the per-provider record index.
+const SequenceKey = "seq"
+
+// WithSequence stamps every buffered record with a per-provider index under
+// SequenceKey, starting at 1 and increasing by one for each record accepted
+// into the buffer, including throttling summaries.
+//
+// The provider guarantees emit order: the index is assigned and the record
+// buffered atomically, so Read and ReadBatch deliver records in ascending
+// index order when a single goroutine reads the provider. Records put back in
+// front on the Read side are the exception: records returned with Unread or
+// redelivered by WithAcknowledgement are read again with their original,
+// lower index, and WithResequencing releases records in timestamp order
+// instead. Downstream systems can resequence records after transports that
+// reorder them, and detect loss from gaps in the sequence:
+//
+//   - Records dropped because the buffer was full still consume an index.
+//   - Records dropped by RecordMiddleware on the Read path leave a gap too.
+//   - Records rejected before buffering (levels, filters, sampling,
+//     throttling) never receive an index and leave no gap.
+//
+// Index assignment serializes Handle callers on a mutex around the
+// non-blocking buffer send.
+func WithSequence() Option {
+	return func(o *options) { o.sequence = true }
+}
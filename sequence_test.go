@@ -0,0 +1,90 @@
+// sequence_test.go: Tests for the per-record monotonic index
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"testing"
+
+	"github.com/agilira/iris"
+)
+
+// sequenceOf returns the record index of record.
+func sequenceOf(t *testing.T, record *iris.Record) uint64 {
+	t.Helper()
+	field, ok := findField(record, SequenceKey)
+	if !ok || !field.IsUint() {
+		t.Fatalf("record %q has no %q field", record.Msg, SequenceKey)
+	}
+	return field.UintValue()
+}
+
+func TestWithSequence_StampsIndexFirst(t *testing.T) {
+	provider := New(10, WithSequence())
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	for want := uint64(1); want <= 3; want++ {
+		record := readRecord(t, provider, func(l *slog.Logger) { l.Info("tick", "k", "v") })
+		if got := sequenceOf(t, record); got != want {
+			t.Errorf("seq = %d, want %d", got, want)
+		}
+		if key := record.GetField(0).Key(); key != SequenceKey {
+			t.Errorf("first field = %q, want %q", key, SequenceKey)
+		}
+	}
+}
+
+func TestWithSequence_GapsRevealDrops(t *testing.T) {
+	provider := New(1, WithSequence())
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	logger := slog.New(provider)
+	logger.Info("kept")
+	logger.Info("dropped: buffer full")
+
+	first := readRecord(t, provider, func(*slog.Logger) {})
+	second := readRecord(t, provider, func(l *slog.Logger) { l.Info("after drop") })
+	if a, b := sequenceOf(t, first), sequenceOf(t, second); a != 1 || b != 3 {
+		t.Errorf("seqs = %d, %d; want 1, 3", a, b)
+	}
+}
+
+func TestWithSequence_ConcurrentHandleDeliversInOrder(t *testing.T) {
+	const goroutines, perGoroutine = 8, 100
+	provider := New(goroutines*perGoroutine, WithSequence())
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	logger := slog.New(provider)
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < perGoroutine; i++ {
+				logger.Info("concurrent")
+			}
+		}()
+	}
+	wg.Wait()
+
+	var last uint64
+	for last < goroutines*perGoroutine {
+		batch, err := provider.ReadBatch(context.Background(), 64)
+		if err != nil || len(batch) == 0 {
+			t.Fatalf("ReadBatch = %d records, %v", len(batch), err)
+		}
+		for _, record := range batch {
+			seq := sequenceOf(t, record)
+			if seq != last+1 {
+				t.Fatalf("seq %d delivered after %d", seq, last)
+			}
+			last = seq
+		}
+	}
+}
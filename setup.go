@@ -0,0 +1,96 @@
+// setup.go: One-call wiring of provider, Iris logger and slog.Logger
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"sync"
+
+	"github.com/agilira/iris"
+)
+
+// Setup creates a provider configured by opts, with a buffer of
+// DefaultBufferSize records, and starts an Iris logger writing its records
+// to w as JSON, replacing the provider, reader and logger boilerplate:
+//
+//	logger, shutdown, err := slogprovider.Setup(os.Stdout)
+//	if err != nil {
+//	    return err
+//	}
+//	defer shutdown()
+//
+//	slog.SetDefault(logger)
+//
+// shutdown closes the provider, waits until Iris has read every buffered
+// record and then closes the Iris logger, flushing w, so records logged
+// before it are not lost. It is safe to call more than once. Use
+// iris.NewReaderLogger directly for other encoders, levels or several
+// outputs.
+func Setup(w io.Writer, opts ...Option) (*slog.Logger, func() error, error) {
+	if w == nil {
+		return nil, nil, errors.New("slog provider: Setup requires a writer")
+	}
+	provider := New(DefaultBufferSize, opts...)
+	shutdown, err := start(provider, w)
+	if err != nil {
+		return nil, nil, err
+	}
+	return slog.New(provider), shutdown, nil
+}
+
+// start starts an Iris logger writing the records of provider to w as JSON
+// and returns its shutdown function, see Setup. It closes provider if the
+// logger cannot be created.
+func start(provider *Provider, w io.Writer) (func() error, error) {
+	reader := &drainReader{Provider: provider, drained: make(chan struct{})}
+	logger, err := iris.NewReaderLogger(iris.Config{
+		Output:  iris.WrapWriter(w),
+		Encoder: iris.NewJSONEncoder(),
+		Level:   iris.Debug, // Filtering is left to the provider options
+	}, []iris.SyncReader{reader})
+	if err != nil {
+		_ = provider.Close() // Nothing was logged yet
+		return nil, fmt.Errorf("slog provider: creating Iris logger: %w", err)
+	}
+	logger.Start()
+
+	var (
+		once    sync.Once
+		closing error
+	)
+	return func() error {
+		once.Do(func() {
+			closeErr := provider.Close()
+			<-reader.drained
+			closing = errors.Join(closeErr, logger.Close()) // Close flushes and syncs w
+		})
+		return closing
+	}, nil
+}
+
+// drainReader reports when Iris has read a provider to its end of stream,
+// which is also reported as a nil record with WithErrClosed, as Iris only
+// stops a reader on nil records.
+type drainReader struct {
+	*Provider
+	drained chan struct{}
+	once    sync.Once
+}
+
+// Read implements iris.SyncReader.
+func (r *drainReader) Read(ctx context.Context) (*iris.Record, error) {
+	record, err := r.Provider.Read(ctx)
+	if record == nil && (err == nil || errors.Is(err, ErrClosed)) {
+		r.once.Do(func() { close(r.drained) })
+		return nil, nil
+	}
+	return record, err
+}
@@ -0,0 +1,79 @@
+// setup_test.go: Tests for the Setup helper
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// lockedWriter is a bufferedWriter safe for the Iris writer goroutine.
+type lockedWriter struct {
+	mu  sync.Mutex
+	buf bufferedWriter
+}
+
+func (w *lockedWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.buf.Write(p)
+}
+
+func (w *lockedWriter) String() string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.buf.String()
+}
+
+func TestSetupWritesRecords(t *testing.T) {
+	out := &lockedWriter{}
+	logger, shutdown, err := Setup(out, WithMinLevel(slog.LevelInfo))
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+
+	logger.Debug("hidden")
+	for i := 0; i < 100; i++ {
+		logger.Info("user login", "user_id", i)
+	}
+	if err := shutdown(); err != nil {
+		t.Fatalf("shutdown failed: %v", err)
+	}
+
+	output := out.String()
+	if got := strings.Count(output, "user login"); got != 100 {
+		t.Errorf("Expected 100 records written before shutdown returned, got %d:\n%s", got, output)
+	}
+	if !strings.Contains(output, `"user_id":99`) {
+		t.Errorf("Expected attributes in output, got:\n%s", output)
+	}
+	if strings.Contains(output, "hidden") {
+		t.Errorf("Expected debug record filtered by WithMinLevel, got:\n%s", output)
+	}
+}
+
+func TestSetupShutdownIdempotent(t *testing.T) {
+	logger, shutdown, err := Setup(&lockedWriter{}, WithErrClosed())
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+	logger.Info("once")
+	if err := shutdown(); err != nil {
+		t.Fatalf("shutdown failed: %v", err)
+	}
+	if err := shutdown(); err != nil {
+		t.Errorf("Expected second shutdown to succeed, got %v", err)
+	}
+}
+
+func TestSetupNilWriter(t *testing.T) {
+	if _, _, err := Setup(nil); err == nil {
+		t.Error("Expected error for nil writer")
+	}
+}
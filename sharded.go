@@ -0,0 +1,146 @@
+// sharded.go: Horizontal scaling across several providers
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync/atomic"
+
+	"github.com/agilira/iris"
+)
+
+// Sharded spreads records over several providers behind one slog.Handler,
+// so deployments with hundreds of producer goroutines can drain the bridge
+// with one Iris reader goroutine per shard instead of contending on a single
+// buffer:
+//
+//	sharded := slogprovider.NewSharded(4, 1000)
+//	defer sharded.Close()
+//
+//	logger, _ := iris.NewReaderLogger(config, sharded.Readers())
+//	slog.SetDefault(slog.New(sharded))
+//
+// Records are assigned to shards round-robin. Ordering is only preserved
+// within a shard: two records logged in sequence, even by the same
+// goroutine, may be read in either order, so consumers that need a total
+// order must sort by record time or use a single provider. Each shard has
+// its own buffer, drop accounting and WithSequence indexes.
+type Sharded struct {
+	shardedHandler
+	shards []*Provider
+}
+
+// shardedHandler distributes records over one handler per shard.
+type shardedHandler struct {
+	handlers []slog.Handler
+	next     *atomic.Uint64 // Round-robin counter shared by derived handlers
+}
+
+// NewSharded creates n providers, each with a buffer of bufferSize records
+// and configured by opts, behind one slog.Handler. It panics if n is not
+// positive.
+//
+// The options are applied to every shard, so stateful values, such as a
+// Sampler instance, are shared by the shards, and options writing files,
+// such as WithBurstCapture, should not be used.
+func NewSharded(n, bufferSize int, opts ...Option) *Sharded {
+	if n <= 0 {
+		panic("slogprovider: NewSharded requires a positive shard count")
+	}
+	s := &Sharded{
+		shardedHandler: shardedHandler{handlers: make([]slog.Handler, n), next: new(atomic.Uint64)},
+		shards:         make([]*Provider, n),
+	}
+	for i := range s.shards {
+		s.shards[i] = New(bufferSize, opts...)
+		s.handlers[i] = s.shards[i]
+	}
+	return s
+}
+
+// Shards returns the providers, e.g. to read their Stats or install rules
+// with SetRules on each of them.
+func (s *Sharded) Shards() []*Provider {
+	return append([]*Provider(nil), s.shards...)
+}
+
+// Readers returns the providers as iris.SyncReaders, one per shard, for
+// iris.NewReaderLogger.
+func (s *Sharded) Readers() []iris.SyncReader {
+	readers := make([]iris.SyncReader, len(s.shards))
+	for i, shard := range s.shards {
+		readers[i] = shard
+	}
+	return readers
+}
+
+// Len returns the number of records waiting in all shards.
+func (s *Sharded) Len() int {
+	n := 0
+	for _, shard := range s.shards {
+		n += shard.Len()
+	}
+	return n
+}
+
+// Dropped returns the number of records dropped by all shards, see
+// Provider.Dropped.
+func (s *Sharded) Dropped() uint64 {
+	var n uint64
+	for _, shard := range s.shards {
+		n += shard.Dropped()
+	}
+	return n
+}
+
+// Close closes every shard, see Provider.Close.
+func (s *Sharded) Close() error {
+	errs := make([]error, len(s.shards))
+	for i, shard := range s.shards {
+		errs[i] = shard.Close()
+	}
+	return errors.Join(errs...)
+}
+
+// Enabled implements slog.Handler. The shards share their configuration,
+// so the first one answers for all.
+func (h *shardedHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.handlers[0].Enabled(ctx, level)
+}
+
+// Handle implements slog.Handler by passing record to the next shard.
+func (h *shardedHandler) Handle(ctx context.Context, record slog.Record) error {
+	i := h.next.Add(1) % uint64(len(h.handlers)) // #nosec G115 -- len is positive
+	return h.handlers[i].Handle(ctx, record)
+}
+
+// WithAttrs implements slog.Handler by binding attrs on every shard.
+func (h *shardedHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	return h.derive(func(handler slog.Handler) slog.Handler { return handler.WithAttrs(attrs) })
+}
+
+// WithGroup implements slog.Handler by opening the group on every shard.
+func (h *shardedHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return h.derive(func(handler slog.Handler) slog.Handler { return handler.WithGroup(name) })
+}
+
+// derive returns a handler whose shards are derived with fn.
+func (h *shardedHandler) derive(fn func(slog.Handler) slog.Handler) *shardedHandler {
+	derived := &shardedHandler{handlers: make([]slog.Handler, len(h.handlers)), next: h.next}
+	for i, handler := range h.handlers {
+		derived.handlers[i] = fn(handler)
+	}
+	return derived
+}
@@ -0,0 +1,82 @@
+// sharded_test.go: Tests for horizontal scaling across providers
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"sync"
+	"testing"
+)
+
+func TestNewSharded_SpreadsRecords(t *testing.T) {
+	sharded := NewSharded(4, 100)
+	defer func() { _ = sharded.Close() }() // Ignore error in test cleanup
+
+	logger := slog.New(sharded)
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 10; i++ {
+				logger.Info("record")
+			}
+		}()
+	}
+	wg.Wait()
+
+	if sharded.Len() != 80 || sharded.Dropped() != 0 {
+		t.Fatalf("Expected 80 buffered records, got %d (%d dropped)", sharded.Len(), sharded.Dropped())
+	}
+	for i, shard := range sharded.Shards() {
+		if shard.Len() != 20 {
+			t.Errorf("Shard %d holds %d records, want 20", i, shard.Len())
+		}
+	}
+	if readers := sharded.Readers(); len(readers) != 4 {
+		t.Errorf("Expected one reader per shard, got %d", len(readers))
+	}
+}
+
+func TestNewSharded_DerivedHandlers(t *testing.T) {
+	sharded := NewSharded(2, 10, WithMinLevel(slog.LevelInfo))
+	defer func() { _ = sharded.Close() }() // Ignore error in test cleanup
+
+	logger := slog.New(sharded).With("service", "api").WithGroup("req")
+	if logger.Enabled(context.Background(), slog.LevelDebug) {
+		t.Error("Expected the shard options to apply")
+	}
+	logger.Info("first", "id", 1)
+	logger.Info("second", "id", 2)
+
+	var msgs []string
+	for _, shard := range sharded.Shards() {
+		record := readWithTimeout(t, shard)
+		if _, ok := findField(record, "service"); !ok {
+			t.Errorf("Expected the bound attribute on %q", record.Msg)
+		}
+		if _, ok := findField(record, "req.id"); !ok {
+			t.Errorf("Expected the record attribute on %q", record.Msg)
+		}
+		msgs = append(msgs, record.Msg)
+	}
+	sort.Strings(msgs)
+	if msgs[0] != "first" || msgs[1] != "second" {
+		t.Errorf("Expected one record per shard, got %v", msgs)
+	}
+}
+
+func TestNewSharded_PanicsWithoutShards(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected NewSharded(0, ...) to panic")
+		}
+	}()
+	NewSharded(0, 10)
+}
@@ -0,0 +1,83 @@
+// signal.go: Dump of provider state on a signal
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"io"
+	"os"
+	"os/signal"
+	"sync"
+)
+
+// DumpOnSignal writes the provider state to w whenever the process receives
+// one of sigs, mirroring the Go runtime's SIGQUIT goroutine dump for log
+// state. Without sigs it listens for SIGUSR1; on systems without SIGUSR1,
+// such as Windows, signals must be given explicitly. A nil w writes to os.Stderr.
+//
+//	stop := provider.DumpOnSignal(nil)
+//	defer stop()
+//
+//	$ kill -USR1 <pid>
+//
+// Each dump is the DumpJSON document followed by the records kept by
+// WithRecentRecords as NDJSON lines. Listening ends when stop is called or the
+// provider is closed.
+func (p *Provider) DumpOnSignal(w io.Writer, sigs ...os.Signal) (stop func()) {
+	if len(sigs) == 0 {
+		sigs = defaultDumpSignals
+	}
+	if len(sigs) == 0 {
+		return func() {}
+	}
+	if w == nil {
+		w = os.Stderr
+	}
+
+	received := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	signal.Notify(received, sigs...)
+	p.supervise("signal dump", func() {
+		for {
+			select {
+			case <-received:
+				if err := p.writeStateDump(w); err != nil {
+					p.reportError(err)
+				}
+			case <-done:
+				return // Unregistered by stop
+			case <-p.closed:
+				signal.Stop(received)
+				return
+			}
+		}
+	})
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			signal.Stop(received)
+			close(done)
+		})
+	}
+}
+
+// writeStateDump writes the DumpJSON document and the recent records to w.
+func (p *Provider) writeStateDump(w io.Writer) error {
+	if err := p.DumpJSON(w); err != nil {
+		return err
+	}
+	if p.recent == nil {
+		return nil
+	}
+	out := newNDJSONWriter(w)
+	for _, r := range p.recent.last(0) {
+		if err := out.write(r.time, &r.record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,14 @@
+// signal_other.go: Default dump signal on systems without SIGUSR1
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+//go:build !unix
+
+package slogprovider
+
+import "os"
+
+// defaultDumpSignals is empty: Windows, Plan 9 and js/wasm have no SIGUSR1.
+var defaultDumpSignals []os.Signal
@@ -0,0 +1,107 @@
+// signal_test.go: Tests for the signal-triggered state dump
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+//go:build unix
+
+package slogprovider
+
+import (
+	"log/slog"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestDumpOnSignal_WritesStateOnSIGUSR1(t *testing.T) {
+	var out lockedBuffer
+	provider := New(10, WithRecentRecords(5))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	slog.New(provider).Info("recent line")
+	readWithTimeout(t, provider)
+
+	stop := provider.DumpOnSignal(&out)
+	defer stop()
+	if err := syscall.Kill(syscall.Getpid(), syscall.SIGUSR1); err != nil {
+		t.Fatalf("Failed to send SIGUSR1: %v", err)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for !strings.Contains(out.String(), "recent line") {
+		if time.Now().After(deadline) {
+			t.Fatalf("No state dump written, got %q", out.String())
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if !strings.Contains(out.String(), `"stats"`) {
+		t.Error("Expected the dump to contain the provider stats")
+	}
+}
+
+func TestDumpOnSignal_Stop(t *testing.T) {
+	var out lockedBuffer
+	provider := New(10)
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	stop := provider.DumpOnSignal(&out, syscall.SIGUSR2)
+	stop()
+	stop() // Idempotent
+
+	// With the handler stopped, SIGUSR2 would terminate the process, so
+	// keep another listener registered while checking.
+	keep := provider.DumpOnSignal(&lockedBuffer{}, syscall.SIGUSR2)
+	defer keep()
+	if err := syscall.Kill(syscall.Getpid(), syscall.SIGUSR2); err != nil {
+		t.Fatalf("Failed to send SIGUSR2: %v", err)
+	}
+	time.Sleep(20 * time.Millisecond)
+	if out.String() != "" {
+		t.Errorf("Expected no dump after stop, got %q", out.String())
+	}
+}
+
+// panickingWriter panics on its first write and then writes to buf.
+type panickingWriter struct {
+	buf      lockedBuffer
+	panicked atomic.Bool
+}
+
+func (w *panickingWriter) Write(p []byte) (int, error) {
+	if w.panicked.CompareAndSwap(false, true) {
+		panic("write failed")
+	}
+	return w.buf.Write(p)
+}
+
+func TestDumpOnSignal_KeepsListeningAfterPanic(t *testing.T) {
+	var out panickingWriter
+	provider := New(10)
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	stop := provider.DumpOnSignal(&out, syscall.SIGUSR2)
+	defer stop()
+	// Keep SIGUSR2 caught even if the listener under test lost it.
+	other := New(10)
+	defer func() { _ = other.Close() }() // Ignore error in test cleanup
+	keep := other.DumpOnSignal(&lockedBuffer{}, syscall.SIGUSR2)
+	defer keep()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for !strings.Contains(out.buf.String(), `"stats"`) {
+		if time.Now().After(deadline) {
+			t.Fatalf("No state dump written after the restart, got %q", out.buf.String())
+		}
+		if err := syscall.Kill(syscall.Getpid(), syscall.SIGUSR2); err != nil {
+			t.Fatalf("Failed to send SIGUSR2: %v", err)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if provider.Stats().InternalPanics != 1 {
+		t.Errorf("Expected 1 internal panic, got %d", provider.Stats().InternalPanics)
+	}
+}
@@ -0,0 +1,17 @@
+// signal_unix.go: Default dump signal on Unix systems
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+//go:build unix
+
+package slogprovider
+
+import (
+	"os"
+	"syscall"
+)
+
+// defaultDumpSignals are the signals DumpOnSignal listens for by default.
+var defaultDumpSignals = []os.Signal{syscall.SIGUSR1}
@@ -0,0 +1,78 @@
+// size.go: Record size estimation and byte accounting
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import "log/slog"
+
+// scalarSize is the estimated size of non-string attribute values.
+const scalarSize = 8
+
+// EstimateSize approximates the payload size of record in bytes: the message
+// length plus, for every attribute, the key length and the value length for
+// strings and byte slices, or 8 bytes for other values. Groups count their
+// members. The estimate ignores Go object overhead, so it is meant for
+// comparing traffic and sizing buffers rather than exact memory accounting.
+func EstimateSize(record slog.Record) int {
+	size := len(record.Message)
+	record.Attrs(func(attr slog.Attr) bool {
+		size += attrSize(attr)
+		return true
+	})
+	return size
+}
+
+// attrSize estimates the size of attr.
+func attrSize(attr slog.Attr) int {
+	size := len(attr.Key)
+	switch attr.Value.Kind() {
+	case slog.KindString:
+		size += len(attr.Value.String())
+	case slog.KindGroup:
+		for _, member := range attr.Value.Group() {
+			size += attrSize(member)
+		}
+	case slog.KindAny:
+		switch v := attr.Value.Any().(type) {
+		case []byte:
+			size += len(v)
+		case *irisValue:
+			if n := len(v.field.Str) + len(v.field.B); n > 0 {
+				size += n
+			} else {
+				size += scalarSize
+			}
+		default:
+			size += scalarSize
+		}
+	default:
+		size += scalarSize
+	}
+	return size
+}
+
+// entrySize estimates the size of e, including its enriched fields.
+func entrySize(e *entry) int {
+	size := EstimateSize(e.record)
+	for _, field := range e.fields {
+		size += len(field.Key())
+		if field.IsString() {
+			size += len(field.StringValue())
+		} else {
+			size += scalarSize
+		}
+	}
+	return size
+}
+
+// WithSizeAccounting tracks the estimated size of records, see EstimateSize,
+// in Stats().HandledBytes and Stats().BufferedBytes, so buffers can be sized
+// in bytes from real traffic: HandledBytes / Handled is the average record
+// size, and BufferedBytes the current buffer payload. Without this option
+// both are zero. Accounting costs one pass over the attributes per record.
+func WithSizeAccounting() Option {
+	return func(o *options) { o.sizeAccounting = true }
+}
@@ -0,0 +1,66 @@
+// size_test.go: Tests for record size estimation and byte accounting
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func TestEstimateSize(t *testing.T) {
+	record := slog.NewRecord(time.Time{}, slog.LevelInfo, "message", 0)
+	record.AddAttrs(
+		slog.String("user", "alice"),
+		slog.Int("n", 1),
+		slog.Group("req", slog.String("path", "/x"), slog.Bool("ok", true)),
+		slog.Any("raw", []byte("abcd")),
+	)
+	want := len("message") +
+		len("user") + len("alice") +
+		len("n") + 8 +
+		len("req") + len("path") + len("/x") + len("ok") + 8 +
+		len("raw") + 4
+	if got := EstimateSize(record); got != want {
+		t.Errorf("EstimateSize = %d, want %d", got, want)
+	}
+}
+
+func TestWithSizeAccounting(t *testing.T) {
+	provider := New(10, WithSizeAccounting())
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	logger := slog.New(provider)
+	logger.Info("aaaa", "k", "vv")
+	logger.Info("bb")
+	per := len("aaaa") + len("k") + len("vv")
+
+	stats := provider.Stats()
+	if stats.HandledBytes != uint64(per+2) || stats.BufferedBytes != uint64(per+2) {
+		t.Errorf("Expected %d handled and buffered bytes, got %d and %d", per+2, stats.HandledBytes, stats.BufferedBytes)
+	}
+
+	readWithTimeout(t, provider)
+	if got := provider.Stats().BufferedBytes; got != 2 {
+		t.Errorf("Expected 2 buffered bytes after a read, got %d", got)
+	}
+
+	provider.ResetCounters()
+	if got := provider.Stats().HandledBytes; got != 2 {
+		t.Errorf("Expected buffered bytes carried over as handled after reset, got %d", got)
+	}
+}
+
+func TestSizeAccounting_DisabledByDefault(t *testing.T) {
+	provider := New(10)
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+	slog.New(provider).Info("message")
+
+	if stats := provider.Stats(); stats.HandledBytes != 0 || stats.BufferedBytes != 0 {
+		t.Errorf("Expected no byte accounting by default, got %+v", stats)
+	}
+}
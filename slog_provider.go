@@ -8,9 +8,10 @@ package slogprovider
 
 import (
 	"context"
-	"fmt"
 	"log/slog"
+	"slices"
 	"sync"
+	"sync/atomic"
 
 	"github.com/agilira/iris"
 )
@@ -36,14 +37,49 @@ import (
 //	slogger := slog.New(provider)
 //	slogger.Info("Message", "key", "value")
 type Provider struct {
-	records chan slog.Record // Buffered channel for slog records
-	closed  chan struct{}    // Signal channel for shutdown coordination
-	once    sync.Once        // Ensures Close() is idempotent
+	queue  *queue        // Bounded buffer of captured records
+	closed chan struct{} // Signal channel for shutdown coordination
+	once   sync.Once     // Ensures Close() is idempotent
+	opts   options       // Optional behavior configured at construction
+	level  slog.Leveler  // Minimum level for the root logger, nil for none
+
+	settings []Option // Options passed to New, see WithOptions
+
+	throttle   *throttler                  // Per-message throttling state, nil when disabled
+	boost      *booster                    // Error-triggered verbosity boosts, nil when disabled
+	traces     *traceGrouper               // Pending trace groups, nil when disabled
+	keyOrder   *keyOrderer                 // Records held for per-key ordering, nil when disabled
+	resequence *resequencer                // Read-side reordering window, nil when disabled
+	acks       *ackTracker                 // Unacknowledged deliveries, nil when disabled
+	rules      atomic.Pointer[activeRules] // Runtime rules installed with SetRules
+
+	seqMu   sync.Mutex // Orders index assignment with buffering when WithSequence is set
+	seq     uint64     // Last assigned record index
+	seqBase uint64     // Indexes assigned before the last ResetCounters, for Verify
+
+	stats    counters        // Operational counters reported by Stats
+	timeline *timeline       // Rolling per-interval statistics, nil when disabled
+	latency  *latencyTracker // Handle to Read latency histogram, nil when disabled
+	coercion *coercer        // Per-key value coercion, nil when disabled
+	errs     chan error      // Asynchronous problem reports, see Errors
+	watchdog *watchdog       // Consumer stall detection, nil when disabled
+
+	memory       *memoryMonitor // Memory pressure backoff, nil when disabled
+	backpressure *backpressure  // Reported Iris backpressure, nil when disabled
+	degrade      *degradation   // Degradation ladder, nil when disabled
+	recent       *recentRing    // Most recently emitted records, nil when disabled
+	capture      *burstCapture  // Capture of dropped records, nil when disabled
+	banner       sync.Once      // Emits the WithStartupBanner record once
+
+	pushback pushback     // Records returned with Unread
+	region   recordRegion // Allocation of converted records
+
+	direct bool // Created without options, see handleDirect
 }
 
 // New creates a new Provider that captures slog records for processing by Iris.
 //
-// The bufferSize parameter controls the internal channel buffer size. A larger
+// The bufferSize parameter controls the internal buffer size. A larger
 // buffer provides better performance under burst loads but uses more memory.
 // Recommended values:
 //   - 100-500: Low to moderate logging volume applications
@@ -54,15 +90,61 @@ type Provider struct {
 // behavior. Monitor your application's logging patterns to choose an appropriate
 // buffer size.
 //
+// New accepts any settings: with a buffer size of zero or less every record is
+// dropped, which only suits counting or capture setups. Use NewChecked to
+// reject invalid or conflicting settings with a *ValidationError instead.
+//
+// Optional behavior is enabled through functional options, which keeps the
+// common case a one-liner while allowing advanced tuning:
+//
+//	provider := New(1000, WithJournald(JournaldConfig{UppercaseFields: true}))
+//
 // The returned Provider must be closed when no longer needed to free resources:
 //
 //	provider := New(1000)
 //	defer provider.Close()
-func New(bufferSize int) *Provider {
-	return &Provider{
-		records: make(chan slog.Record, bufferSize),
-		closed:  make(chan struct{}),
+func New(bufferSize int, opts ...Option) *Provider {
+	p := &Provider{
+		queue:  newQueue(bufferSize),
+		closed: make(chan struct{}),
+		opts:   newOptions(opts),
+		errs:   make(chan error, errorsBuffer),
+	}
+	p.settings = slices.Clone(opts)
+	p.direct = len(opts) == 0
+	if p.opts.chaos != nil {
+		p.opts.faults = chaosFaults(*p.opts.chaos, p.opts.faults)
+	}
+	p.level = p.opts.levelFor("")
+	p.recent = newRecentRing(p.opts.recent)
+	if p.opts.warmUp {
+		p.warmUp()
+	}
+	p.throttle = newThrottler(p.opts.throttle, p.opts.clock)
+	p.boost = newBooster(p.opts.boost)
+	if p.watchdog = newWatchdog(p.opts.watchdog); p.watchdog != nil {
+		p.supervise("watchdog", func() { p.watchdog.run(p) })
+	}
+	p.traces = newTraceGrouper(p.opts.traceGrouping, p.queue.cap())
+	p.keyOrder = newKeyOrderer(p.opts.keyOrder, p.queue.cap())
+	p.resequence = newResequencer(p.opts.resequence)
+	p.acks = newAckTracker(p.opts.ack)
+	p.backpressure = newBackpressure(p.opts.backpressure)
+	if p.degrade = newDegradation(p.opts.degradation); p.degrade != nil {
+		p.supervise("degradation", func() { p.degrade.run(p) })
 	}
+	p.latency = newLatencyTracker(p.opts.latency)
+	p.coercion = newCoercer(p.opts.coercion)
+	if p.timeline = newTimeline(p.opts.timeline); p.timeline != nil {
+		p.supervise("stats timeline", func() { p.timeline.run(p) })
+	}
+	if p.memory = newMemoryMonitor(p.opts.memory); p.memory != nil {
+		p.supervise("memory monitor", func() { p.memory.run(p) })
+	}
+	if p.capture = newBurstCapture(p.opts.capture); p.capture != nil {
+		p.supervise("burst capture", func() { p.capture.run(p) })
+	}
+	return p
 }
 
 // Handle implements slog.Handler to capture slog records for processing by Iris.
@@ -70,9 +152,30 @@ func New(bufferSize int) *Provider {
 // This method is called by the slog library for each log record. It attempts to
 // store the record in the internal buffer for later processing by Iris. The
 // operation is non-blocking:
+//   - With WithStartupBanner, the first call buffers the configuration record
+//   - If the record is below the configured minimum level, it is dropped unless a
+//     WithErrorBoost boost is active
+//   - If a filter configured with WithFilter or SetRules rejects the record, it is dropped
+//   - If a sampler configured with WithSampler or SetRules rejects the record, it is dropped
+//   - If WithMetricsOnly selects the record, it is counted and discarded
+//   - If WithMemoryPressure sampling rejects the record under pressure, it is dropped
+//   - If the WithBackpressure stage for the reported pressure rejects the record, it is dropped
+//   - If the current WithDegradation stage rejects the record, it is dropped
+//   - If WithStrictTyping rejects an attribute, the record is dropped and an error returned
+//   - If the record exceeds the WithThrottle limit for its message, it is dropped
+//   - If a HandleHook registered with WithHooks rejects the record, it is dropped
+//   - With WithAddSource and WithReplaceAttr, the source location is attached and
+//     attributes are rewritten
+//   - With WithEncryption, selected attributes are encrypted; on failure the record
+//     is dropped and an error returned
+//   - With WithDryRun, the record is converted and discarded
+//   - If ctx carries an open Transaction (see Begin), the record is held until Commit
+//   - If WithTraceGrouping is configured, traced records are held for their group
+//   - If WithKeyOrdering is configured, keyed records are held until they are due
 //   - If buffer space is available, the record is stored successfully
-//   - If the provider is closed, an error is returned
-//   - If the buffer is full, the record is dropped silently (returns nil)
+//   - If the provider is closed, ErrClosed is returned
+//   - If the buffer is full, the record is dropped silently (returns nil),
+//     after the WithRetryGrace period if configured
 //
 // The non-blocking behavior ensures that logging never blocks the application,
 // even under high load conditions. Applications should monitor buffer sizes
@@ -80,52 +183,256 @@ func New(bufferSize int) *Provider {
 //
 // Thread Safety: Safe for concurrent access from multiple goroutines.
 func (p *Provider) Handle(ctx context.Context, record slog.Record) error {
-	select {
-	case p.records <- record:
+	return p.handle(ctx, record, "", p.level, nil)
+}
+
+// handle buffers record on behalf of the handler for the logger name, whose
+// option-derived minimum level is level and whose bound attributes are bound.
+func (p *Provider) handle(ctx context.Context, record slog.Record, name string, level slog.Leveler, bound *boundAttrs) error {
+	if p.direct && p.rules.Load() == nil {
+		return p.handleDirect(ctx, record, name, level, bound)
+	}
+	if p.opts.banner {
+		p.banner.Do(p.emitBanner)
+	}
+	if p.watchdog != nil {
+		p.watchdog.check()
+	}
+	if p.boost != nil {
+		p.boost.observe(ctx, record)
+	}
+	if !p.enabledFor(name, level, record.Level) && !p.boosted(ctx, record) {
 		return nil
-	case <-p.closed:
-		return fmt.Errorf("slog provider closed")
-	default:
+	}
+	if p.opts.rewriter != nil {
+		record.Message, _ = p.opts.rewriter.Rewrite(record.Message)
+	}
+	rules := p.rules.Load()
+	if !p.opts.keep(record) || (!rules.replaces() && !p.opts.sampled(record)) {
+		return nil
+	}
+	if rules != nil && !rules.admit(record) {
+		return nil
+	}
+	if p.opts.metricsOnly != nil && p.opts.metricsOnly.count(record) {
+		p.stats.metricsOnly.Add(1)
+		return nil
+	}
+	if p.memory != nil && !p.memory.admit(record.Level) {
+		p.stats.pressureSampled.Add(1)
+		return nil
+	}
+	if p.backpressure != nil && !p.backpressure.admit(record.Level) {
+		p.stats.backpressureDropped.Add(1)
+		return nil
+	}
+	if p.degrade != nil && !p.degrade.admit(record.Level) {
+		p.stats.degradationDropped.Add(1)
+		return nil
+	}
+	if p.opts.strict != nil {
+		if err := p.checkTypes(record); err != nil {
+			return err
+		}
+	}
+
+	if p.throttle != nil {
+		admitted, summaries := p.throttle.admit(record)
+		for _, summary := range summaries {
+			if err := p.enqueue(entry{record: summary}); err != nil {
+				return err
+			}
+		}
+		if !admitted {
+			return nil
+		}
+	}
+	if !p.opts.accept(ctx, record) {
+		return nil
+	}
+	var builtin []slog.Attr
+	if p.opts.addSource || p.opts.replaceAttr != nil {
+		record, builtin = p.opts.formatRecord(record, name)
+	}
+	if p.opts.encrypt != nil {
+		var err error
+		if record, err = p.encryptRecord(ctx, record, name); err != nil {
+			return err
+		}
+	}
+
+	e := entry{record: record, name: name, bound: bound}
+	if p.latency != nil {
+		e.handled = p.latency.stamp()
+	}
+	for _, attr := range builtin {
+		e.fields = append(e.fields, p.convertAttribute(attr))
+	}
+	if p.opts.provenance != nil {
+		fields, err := p.contextFields(ctx)
+		if err != nil {
+			return err
+		}
+		e.fields = append(e.fields, fields...)
+	}
+	if len(p.opts.enrichers) > 0 {
+		if p.opts.underDeadline(ctx) || p.degrade.skipEnrichment() {
+			p.stats.enrichmentsSkipped.Add(1)
+		} else {
+			e.fields = append(e.fields, p.opts.tagEnriched(p.opts.enrich(ctx, record))...)
+		}
+	}
+	if p.opts.deadlineRemaining {
+		if field, ok := deadlineField(ctx, record); ok {
+			e.fields = append(e.fields, field)
+		}
+	}
+	if p.opts.dryRun {
+		p.dryRun(e)
+		return nil
+	}
+	if tx := p.transactionFor(ctx); tx != nil && tx.add(e) {
+		return nil
+	}
+	if p.traces != nil && p.groupTrace(ctx, e) {
+		return nil
+	}
+	if p.keyOrder != nil && p.orderByKey(e) {
+		return nil
+	}
+	return p.enqueue(e)
+}
+
+// handleDirect is handle for providers created without options, while no
+// rules are installed with SetRules. Such providers only check the level and
+// the context transaction, so the per-option checks of handle are skipped to
+// keep the default configuration as cheap as a channel send.
+func (p *Provider) handleDirect(ctx context.Context, record slog.Record, name string, level slog.Leveler, bound *boundAttrs) error {
+	if !levelEnabled(level, record.Level) {
+		return nil
+	}
+	e := entry{record: record, name: name, bound: bound}
+	if tx := p.transactionFor(ctx); tx != nil && tx.add(e) {
+		return nil
+	}
+	p.stats.handled.Add(1)
+	switch p.queue.push(e) {
+	case pushClosed:
+		p.stats.dropped.Add(1)
+		return ErrClosed
+	case pushFull:
+		p.stats.dropped.Add(1)
+	}
+	return nil
+}
+
+// enqueue stores e in the buffer without blocking, dropping it when the
+// buffer is full.
+//
+// With WithSequence, the record index is assigned and the entry buffered
+// under one lock so that buffer order matches index order. Dropped entries
+// still consume an index, leaving a gap that readers can detect.
+func (p *Provider) enqueue(e entry) error {
+	if p.opts.sizeAccounting {
+		e.size = entrySize(&e)
+	}
+	if p.opts.sequence {
+		p.seqMu.Lock()
+		defer p.seqMu.Unlock()
+		p.seq++
+		e.seq = p.seq
+	}
+	p.stats.handled.Add(1)
+	p.stats.handledBytes.Add(uint64(e.size)) // #nosec G115 -- size is never negative
+	if p.opts.faults.bufferFull(e.record) {
+		p.stats.dropped.Add(1)
+		return nil // Injected fault: drop as if the buffer were full
+	}
+
+	result := p.queue.push(e)
+	if result == pushFull && p.opts.weight != nil {
+		result = p.pushWeighted(e)
+	} else if result == pushFull && p.opts.dropOldest {
+		result = p.pushDroppingOldest(e)
+	} else if result == pushFull && p.opts.dropPolicy != nil {
+		result = p.pushWithPolicy(e)
+	}
+	if result == pushFull && p.opts.retryGrace > 0 {
+		result = p.retryPush(func() pushResult { return p.queue.push(e) })
+	}
+	switch result {
+	case pushClosed:
+		p.stats.dropped.Add(1)
+		return ErrClosed
+	case pushFull:
+		p.stats.dropped.Add(1)
+		if p.capture != nil {
+			p.capture.offer(e)
+		}
 		return nil // Drop if buffer full
+	default:
+		if p.watchdog != nil {
+			p.watchdog.arm()
+		}
+		return nil
 	}
 }
 
 // Enabled implements slog.Handler to indicate whether records at the given level should be processed.
 //
-// This implementation always returns true, allowing Iris to handle level filtering
-// according to its own configuration. This approach provides more flexibility and
-// ensures that level changes in Iris are respected without requiring provider
-// reconfiguration.
+// By default this implementation always returns true, allowing Iris to handle
+// level filtering according to its own configuration. This approach provides
+// more flexibility and ensures that level changes in Iris are respected without
+// requiring provider reconfiguration.
 //
-// If you need level filtering at the slog level, consider creating a wrapper
-// handler that checks levels before delegating to this provider.
+// When WithMinLevel, WithLevelOverrides or level rules (see SetRules) are
+// configured, records below the effective minimum level are rejected here,
+// before slog builds the record.
 func (p *Provider) Enabled(ctx context.Context, level slog.Level) bool {
-	return true
+	return p.enabledFor("", p.level, level) || p.boostEnabled(level)
 }
 
 // WithAttrs implements slog.Handler to create a handler with additional attributes.
 //
-// This implementation returns the same provider instance, as attribute handling
-// is delegated to the slog library. The slog library will include the attributes
-// in each record before calling Handle(), so no special handling is needed here.
-//
-// For more sophisticated attribute handling, consider implementing a wrapper
-// handler that manages attributes before delegating to this provider.
+// The returned handler shares the provider's buffer and adds attrs to every
+// record it handles, before the record's own attributes, in binding order.
+// Attributes bound after WithGroup are qualified by the group path, so
+// logger.With("a", 1).WithGroup("req").With("id", 7) yields the fields a and
+// req.id.
+//
+// Derived handlers share the attributes of the handlers they derive from, so
+// binding costs are proportional to attrs alone, even for deep With chains.
+// Attributes are converted to iris fields once, when bound, so LogValuer
+// values are resolved at that point and every record reuses the converted
+// fields.
+// Bound attributes are not visible to Handle-time options that inspect
+// record attributes, such as filters, trace grouping or strict typing, with
+// the exception of WithEncryption, which encrypts them once when bound.
+//
+// An empty attrs returns the provider itself.
 func (p *Provider) WithAttrs(attrs []slog.Attr) slog.Handler {
-	return p
+	if len(attrs) == 0 {
+		return p
+	}
+	return p.newGroupHandler("", p.bind(nil, "", attrs))
 }
 
 // WithGroup implements slog.Handler to create a handler with a named group.
 //
-// This implementation returns the same provider instance, as group handling
-// is delegated to the slog library. The slog library will structure the
-// attributes appropriately before calling Handle(), so no special handling
-// is needed here.
+// The returned handler shares the provider's buffer. Its group path (for
+// example "db.pool" after WithGroup("db").WithGroup("pool")) names the logger
+// for per-subsystem level rules configured with WithLevelOverrides, and
+// qualifies the keys of the attributes logged through it, like the standard
+// library handlers do: logger.WithGroup("req").Info("done", "id", 7) yields a
+// "req.id" field, as do attributes bound afterwards with WithAttrs. Built-in
+// fields, such as the WithAddSource location, are not qualified.
 //
-// For more sophisticated group handling, consider implementing a wrapper
-// handler that manages groups before delegating to this provider.
+// An empty name returns the provider itself, as required by slog.Handler.
 func (p *Provider) WithGroup(name string) slog.Handler {
-	return p
+	if name == "" {
+		return p
+	}
+	return p.newGroupHandler(name, nil)
 }
 
 // Read implements iris.SyncReader to provide slog records to the Iris pipeline.
@@ -134,22 +441,104 @@ func (p *Provider) WithGroup(name string) slog.Handler {
 // processing. It blocks until:
 //   - A record becomes available (returns the converted record)
 //   - The context is cancelled (returns context error)
-//   - The provider is closed (returns nil, nil)
+//   - The provider is closed and the buffer drained (returns nil, nil, or
+//     nil, ErrClosed with WithErrClosed)
+//
+// A Read whose context is cancelled never consumes a record: the record is
+// either returned or left in the buffer. Records that were returned but
+// could not be used can be given back with Unread.
 //
 // The method converts slog records to Iris records, preserving message content,
 // level information, and all attributes with appropriate type conversion.
+// Converted records are validated against the schema configured with
+// WithSchema, if any, and then passed through the middleware configured with
+// WithRecordMiddleware; records dropped by middleware are skipped.
 //
 // Thread Safety: Safe for concurrent access, though typically called by a
 // single Iris reader goroutine.
 func (p *Provider) Read(ctx context.Context) (*iris.Record, error) {
-	select {
-	case record := <-p.records:
-		return p.convertSlogRecord(record), nil
-	case <-ctx.Done():
-		return nil, ctx.Err()
-	case <-p.closed:
+	if p.watchdog != nil {
+		p.watchdog.touch()
+	}
+	for {
+		if err := p.opts.faults.delayRead(ctx); err != nil {
+			return nil, err
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if record := p.poll(); record != nil {
+			return record, nil
+		}
+		if p.drained() {
+			return nil, p.endOfStream()
+		}
+		select {
+		case <-p.queue.notify:
+		case <-p.resequenceDue():
+		case <-p.ackDue():
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-p.closed:
+		}
+	}
+}
+
+// ReadBatch reads up to max records, blocking only until the first one is
+// available. It returns the records that were immediately available after the
+// first, which lets exporters amortize per-call overhead.
+//
+// ReadBatch follows the Read contract: it returns ctx.Err() when ctx is
+// cancelled before any record is read, and signals end of stream like Read
+// once the provider is closed and drained. Records are returned in the order
+// Read would return them; see WithSequence for when that is ascending index
+// order.
+func (p *Provider) ReadBatch(ctx context.Context, max int) ([]*iris.Record, error) {
+	if max <= 0 {
 		return nil, nil
 	}
+	first, err := p.Read(ctx)
+	if first == nil || err != nil {
+		return nil, err
+	}
+
+	batch := append(make([]*iris.Record, 0, max), first)
+	for len(batch) < max {
+		converted := p.poll()
+		if converted == nil {
+			break
+		}
+		batch = append(batch, converted)
+	}
+	return batch, nil
+}
+
+// poll returns the next unread or converted record without blocking, or nil
+// when no record is available.
+func (p *Provider) poll() *iris.Record {
+	if record := p.pushback.take(); record != nil {
+		return record
+	}
+	if p.acks != nil {
+		if record := p.redeliver(); record != nil {
+			return record
+		}
+	}
+	if p.resequence != nil {
+		return p.pollResequenced()
+	}
+	for {
+		e, ok := p.queue.pop()
+		if !ok {
+			return nil
+		}
+		if p.watchdog != nil {
+			p.watchdog.touch()
+		}
+		if converted := p.process(e); converted != nil {
+			return converted
+		}
+	}
 }
 
 // Close implements io.Closer to gracefully shut down the provider.
@@ -159,8 +548,9 @@ func (p *Provider) Read(ctx context.Context) (*iris.Record, error) {
 // times and from multiple goroutines.
 //
 // After Close() is called:
-//   - Handle() will return an error for new records
-//   - Read() will return nil, nil after processing remaining buffered records
+//   - Handle() will return ErrClosed for records it would otherwise buffer
+//   - Read() returns the remaining buffered records, then reports end of
+//     stream (nil, nil, or nil, ErrClosed with WithErrClosed)
 //   - The provider should not be used for new operations
 //
 // Close() does not wait for pending operations to complete. Use context
@@ -168,11 +558,102 @@ func (p *Provider) Read(ctx context.Context) (*iris.Record, error) {
 // are processed before shutdown.
 func (p *Provider) Close() error {
 	p.once.Do(func() {
+		if p.throttle != nil {
+			for _, summary := range p.throttle.flush() {
+				_ = p.enqueue(entry{record: summary}) // Best effort: dropped if the buffer is full
+			}
+		}
+		if p.traces != nil {
+			p.flushTraces()
+		}
+		if p.keyOrder != nil {
+			p.flushKeyOrdered()
+		}
+		p.queue.close()
 		close(p.closed)
 	})
 	return nil
 }
 
+// entry is a buffered record together with the data captured at Handle time.
+type entry struct {
+	record  slog.Record
+	fields  []iris.Field // Fields computed at Handle time, e.g. by enrichers
+	seq     uint64       // Record index assigned with WithSequence, 0 if none
+	name    string       // Logger name (group path) the record was handled for
+	bound   *boundAttrs  // Attributes bound to the handler, nil for none
+	size    int          // Estimated size with WithSizeAccounting, 0 otherwise
+	handled int64        // Handle time with WithLatencyTracking, 0 if not measured
+}
+
+// process runs the Read path for a buffered entry: conversion, schema
+// validation, middleware and emit hooks. It returns nil when middleware drops
+// the record.
+func (p *Provider) process(e entry) *iris.Record {
+	if len(p.opts.ttls) > 0 && p.opts.expired(&e) {
+		p.stats.expired.Add(1)
+		return nil
+	}
+	if p.belowReadLevel(e.record.Level) {
+		p.stats.readFiltered.Add(1)
+		return nil
+	}
+	if p.latency != nil {
+		p.latency.observe(&e)
+	}
+	p.stats.converted.Add(1)
+	return p.deliver(e, 1)
+}
+
+// deliver converts e for its attempts-th delivery, without counting it as
+// converted.
+func (p *Provider) deliver(e entry, attempts int) *iris.Record {
+	record := p.safeConvert(e)
+	if p.opts.schema != nil {
+		p.opts.schema.validate(record)
+	}
+	if record = p.opts.applyMiddleware(record); record != nil {
+		if p.acks != nil {
+			p.acks.track(e, attempts, record)
+		}
+		p.opts.emit(record)
+		if p.recent != nil {
+			p.recent.add(e.record.Time, record)
+		}
+	}
+	return record
+}
+
+// convertEntry converts a buffered entry. The record index comes first so it
+// survives the Iris field limit; Handle-time fields follow the record
+// attributes.
+func (p *Provider) convertEntry(e entry) *iris.Record {
+	record := p.region.newRecord(p.recordLevel(e.record), p.formatMessage(e.name, e.record))
+	if e.seq != 0 {
+		record.AddField(iris.Uint64(SequenceKey, e.seq))
+	}
+	p.addSlogFields(record, e.record, e.name, e.bound)
+
+	uppercase := p.opts.journald != nil && p.opts.journald.UppercaseFields
+	for _, field := range e.fields {
+		if uppercase {
+			field.K = journaldFieldName(field.K)
+		}
+		if !record.AddField(field) {
+			break
+		}
+	}
+	if p.latency != nil {
+		if field, ok := p.latency.field(&e); ok {
+			record.AddField(field)
+		}
+	}
+	if p.opts.namespace != nil {
+		p.opts.namespace.apply(record)
+	}
+	return record
+}
+
 // convertSlogRecord converts a slog.Record to an iris.Record with full fidelity.
 //
 // This function preserves the message, level, and all attributes from the slog
@@ -188,14 +669,63 @@ func (p *Provider) Close() error {
 // If the record has more fields than Iris can handle (32 fields), excess
 // fields are silently dropped. This should be rare in typical applications.
 func (p *Provider) convertSlogRecord(slogRec slog.Record) *iris.Record {
-	record := iris.NewRecord(p.convertLevel(slogRec.Level), slogRec.Message)
+	record := iris.NewRecord(p.recordLevel(slogRec), slogRec.Message)
+	p.addSlogFields(record, slogRec, "", nil)
+	if p.opts.namespace != nil {
+		p.opts.namespace.apply(record)
+	}
+	return record
+}
+
+// addSlogFields adds the record time, see WithoutRecordTime, the level name,
+// numeric slog level and MESSAGE_ID stamp, if configured, the bound attributes
+// and the converted attributes of slogRec to record. Like bound attributes, the
+// attributes of records handled for a group path are qualified with it, e.g.
+// "req.id" after WithGroup("req").
+func (p *Provider) addSlogFields(record *iris.Record, slogRec slog.Record, name string, bound *boundAttrs) {
+	p.opts.addRecordTime(record, slogRec)
+	if name, ok := p.opts.levelNames[slogRec.Level]; ok {
+		record.AddField(iris.String(LevelNameKey, name))
+	}
+	if p.opts.slogLevel {
+		record.AddField(iris.Int64(SlogLevelKey, int64(slogRec.Level)))
+	}
+	if p.opts.journald != nil {
+		p.opts.journald.stampMessageID(record, slogRec.Message)
+	}
+	if !p.addBoundFields(record, bound) {
+		return
+	}
 
 	slogRec.Attrs(func(attr slog.Attr) bool {
-		field := p.convertAttribute(attr)
-		return record.AddField(field)
+		return p.flattenAttr(name, attr, record.AddField)
 	})
+}
 
-	return record
+// flattenAttr passes the converted fields of attr, with keys qualified by
+// prefix, to add until it reports false: one field, or one per member of a
+// group attribute, with the group key appended to the prefix, e.g. "req.id"
+// for slog.Group("req", "id", 7). As slog handlers must, it resolves values
+// and ignores empty attributes and groups, and inlines the members of groups
+// with an empty key. It reports whether add accepted every field.
+func (p *Provider) flattenAttr(prefix string, attr slog.Attr, add func(iris.Field) bool) bool {
+	attr.Value = attr.Value.Resolve()
+	if attr.Value.Kind() == slog.KindGroup {
+		if attr.Key != "" {
+			prefix = joinPath(prefix, attr.Key)
+		}
+		for _, member := range attr.Value.Group() {
+			if !p.flattenAttr(prefix, member, add) {
+				return false
+			}
+		}
+		return true
+	}
+	if attr.Equal(slog.Attr{}) {
+		return true
+	}
+	attr.Key = joinPath(prefix, attr.Key)
+	return add(p.convertAttribute(attr))
 }
 
 // convertLevel maps slog.Level values to iris.Level values.
@@ -206,10 +736,13 @@ func (p *Provider) convertSlogRecord(slogRec slog.Record) *iris.Record {
 //   - slog.LevelWarn → iris.Warn
 //   - slog.LevelError and higher → iris.Error
 //
-// Custom slog levels are mapped to the nearest standard Iris level.
-// This ensures that level-based filtering and handling work correctly
-// in the Iris pipeline.
+// Custom slog levels are mapped to the nearest standard Iris level, unless
+// WithLevelMapper maps them explicitly. This ensures that level-based
+// filtering and handling work correctly in the Iris pipeline.
 func (p *Provider) convertLevel(slogLevel slog.Level) iris.Level {
+	if level, ok := p.opts.levelMapper[slogLevel]; ok {
+		return level
+	}
 	switch {
 	case slogLevel <= slog.LevelDebug:
 		return iris.Debug
@@ -224,39 +757,28 @@ func (p *Provider) convertLevel(slogLevel slog.Level) iris.Level {
 
 // convertAttribute converts a slog.Attr to an iris.Field with type preservation.
 //
-// This function examines the slog attribute's value type and creates the
-// corresponding strongly-typed Iris field. Supported conversions:
-//   - String → iris.String
-//   - Int64 → iris.Int64
-//   - Uint64 → iris.Uint64
-//   - Float64 → iris.Float64
-//   - Bool → iris.Bool
-//   - Duration → iris.Dur
-//   - Time → iris.Time
-//   - Other types → iris.String (using String() method)
+// The key is adapted to the journald conventions when configured, and the
+// value is converted by the FieldConverter configured with WithFieldConverter,
+// or DefaultFieldConverter.
 //
 // Type preservation ensures that Iris encoders can format values appropriately
 // and that type-specific features (like duration formatting) work correctly.
 func (p *Provider) convertAttribute(attr slog.Attr) iris.Field {
+	if p.coercion != nil {
+		attr.Value = p.coercion.coerce(attr.Key, attr.Value, &p.stats)
+	}
 	key := attr.Key
-	value := attr.Value
-
-	switch value.Kind() {
-	case slog.KindString:
-		return iris.String(key, value.String())
-	case slog.KindInt64:
-		return iris.Int64(key, value.Int64())
-	case slog.KindUint64:
-		return iris.Uint64(key, value.Uint64())
-	case slog.KindFloat64:
-		return iris.Float64(key, value.Float64())
-	case slog.KindBool:
-		return iris.Bool(key, value.Bool())
-	case slog.KindDuration:
-		return iris.Dur(key, value.Duration())
-	case slog.KindTime:
-		return iris.Time(key, value.Time())
-	default:
-		return iris.String(key, value.String())
+
+	if p.opts.journald != nil && p.opts.journald.UppercaseFields {
+		key = journaldFieldName(key)
+	}
+	if p.opts.faults.failConversion(attr) {
+		p.stats.conversionErrors.Add(1)
+		return iris.String(key, FaultConversionValue)
+	}
+
+	if p.opts.converter != nil {
+		return p.opts.converter.ConvertField(key, attr.Value)
 	}
+	return DefaultFieldConverter.ConvertField(key, attr.Value)
 }
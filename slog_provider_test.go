@@ -11,6 +11,8 @@ import (
 	"log/slog"
 	"testing"
 	"time"
+
+	"github.com/agilira/iris"
 )
 
 func TestNew(t *testing.T) {
@@ -34,6 +36,28 @@ func TestProvider_Handle(t *testing.T) {
 	}
 }
 
+func TestProvider_WithGroupQualifiesKeys(t *testing.T) {
+	provider := New(10, WithAddSource())
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	record := readRecord(t, provider, func(logger *slog.Logger) {
+		logger.WithGroup("req").With("method", "GET").WithGroup("db").Info("query", "rows", 3)
+	})
+	for _, key := range []string{"req.method", "req.db.rows", SourceFileKey, SourceLineKey} {
+		if _, ok := findField(record, key); !ok {
+			t.Errorf("Expected field %q, got %v", key, fieldKeys(record))
+		}
+	}
+	if _, ok := findField(record, "rows"); ok {
+		t.Error("Expected record attributes qualified by the group path")
+	}
+
+	root := readRecord(t, provider, func(logger *slog.Logger) { logger.Info("root", "rows", 1) })
+	if _, ok := findField(root, "rows"); !ok {
+		t.Errorf("Expected root attributes unqualified, got %v", fieldKeys(root))
+	}
+}
+
 func TestProvider_Enabled(t *testing.T) {
 	provider := New(100)
 	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
@@ -63,3 +87,61 @@ func TestIntegrationWithSlog(t *testing.T) {
 		t.Errorf("Read() record.Msg = %v, want %v", record.Msg, "test integration message")
 	}
 }
+
+func TestProvider_ReadBatch(t *testing.T) {
+	provider := New(10)
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	logger := slog.New(provider)
+	for _, msg := range []string{"a", "b", "c"} {
+		logger.Info(msg)
+	}
+
+	ctx := context.Background()
+	batch, err := provider.ReadBatch(ctx, 2)
+	if err != nil || len(batch) != 2 || batch[0].Msg != "a" || batch[1].Msg != "b" {
+		t.Fatalf("ReadBatch(2) = %d records, %v; want a, b", len(batch), err)
+	}
+	batch, err = provider.ReadBatch(ctx, 10)
+	if err != nil || len(batch) != 1 || batch[0].Msg != "c" {
+		t.Fatalf("ReadBatch(10) = %d records, %v; want the remaining record", len(batch), err)
+	}
+
+	timeout, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if batch, err := provider.ReadBatch(timeout, 10); err == nil || batch != nil {
+		t.Errorf("ReadBatch on empty buffer = %v, %v; want context error", batch, err)
+	}
+
+	_ = provider.Close() // Ignore error in test cleanup
+	if batch, err := provider.ReadBatch(ctx, 10); err != nil || batch != nil {
+		t.Errorf("ReadBatch after Close = %v, %v; want nil, nil", batch, err)
+	}
+}
+
+// findField returns the field with the given key from record.
+func findField(record *iris.Record, key string) (iris.Field, bool) {
+	for i := 0; i < record.FieldCount(); i++ {
+		if f := record.GetField(i); f.Key() == key {
+			return f, true
+		}
+	}
+	return iris.Field{}, false
+}
+
+// readRecord logs through the provider and returns the converted record.
+func readRecord(t *testing.T, provider *Provider, log func(*slog.Logger)) *iris.Record {
+	t.Helper()
+	log(slog.New(provider))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	record, err := provider.Read(ctx)
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if record == nil {
+		t.Fatal("Read() returned nil record")
+	}
+	return record
+}
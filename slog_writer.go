@@ -0,0 +1,148 @@
+// slog_writer.go: Reverse bridge delivering Iris records to a slog.Handler
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"context"
+	"log/slog"
+	"sync/atomic"
+	"time"
+
+	"github.com/agilira/iris"
+)
+
+// Attribute keys used by ToSlogRecord for iris.Record metadata.
+const (
+	SlogLoggerKey = "logger"
+	SlogCallerKey = "caller"
+	SlogStackKey  = "stack"
+)
+
+// secretKind identifies iris.Secret fields, whose kind is not exported.
+var secretKind = iris.Secret("", "").Type()
+
+// SlogWriter delivers Iris records to a slog.Handler.
+//
+// It is the reverse of Provider and helps adopting Iris gradually: records
+// logged with Iris can still reach a handler that cannot be replaced yet,
+// such as a vendor's handler. Register its hook with the Iris logger:
+//
+//	writer := slogprovider.NewSlogWriter(vendorHandler)
+//	logger, _ := iris.New(config, iris.WithHook(writer.Hook()))
+type SlogWriter struct {
+	handler slog.Handler
+	failed  atomic.Uint64
+}
+
+// NewSlogWriter creates a SlogWriter delivering records to handler.
+func NewSlogWriter(handler slog.Handler) *SlogWriter {
+	return &SlogWriter{handler: handler}
+}
+
+// WriteRecord converts record with ToSlogRecord and passes it to the handler,
+// returning the handler's error. Records below the handler's level are
+// skipped.
+func (w *SlogWriter) WriteRecord(ctx context.Context, record *iris.Record) error {
+	converted := ToSlogRecord(record)
+	if !w.handler.Enabled(ctx, converted.Level) {
+		return nil
+	}
+	return w.handler.Handle(ctx, converted)
+}
+
+// Hook returns an iris.Hook calling WriteRecord for every record. Handler
+// errors are counted in Failed, as hooks cannot report them.
+func (w *SlogWriter) Hook() iris.Hook {
+	return func(record *iris.Record) {
+		if err := w.WriteRecord(context.Background(), record); err != nil {
+			w.failed.Add(1)
+		}
+	}
+}
+
+// Failed returns the number of records the handler failed to handle when
+// delivered through Hook.
+func (w *SlogWriter) Failed() uint64 {
+	return w.failed.Load()
+}
+
+// ToSlogRecord converts an iris.Record to a slog.Record.
+//
+// It mirrors ConvertRecord: levels and typed fields map back to their slog
+// counterparts with ToSlogAttr. Since Iris records carry no timestamp, the
+// record is stamped with its slog.TimeKey time field, as added by
+// ConvertRecord, or else the current time. The logger name, caller and stack
+// are added as SlogLoggerKey, SlogCallerKey and SlogStackKey attributes when
+// set.
+func ToSlogRecord(record *iris.Record) slog.Record {
+	converted := slog.NewRecord(time.Now(), toSlogLevel(record.Level), record.Msg, 0)
+	if record.Logger != "" {
+		converted.AddAttrs(slog.String(SlogLoggerKey, record.Logger))
+	}
+	for i := 0; i < record.FieldCount(); i++ {
+		field := record.GetField(i)
+		if field.Key() == slog.TimeKey && field.IsTime() {
+			converted.Time = field.TimeValue()
+			continue
+		}
+		converted.AddAttrs(ToSlogAttr(field))
+	}
+	if record.Caller != "" {
+		converted.AddAttrs(slog.String(SlogCallerKey, record.Caller))
+	}
+	if record.Stack != "" {
+		converted.AddAttrs(slog.String(SlogStackKey, record.Stack))
+	}
+	return converted
+}
+
+// ToSlogAttr converts an iris.Field to a slog.Attr, preserving its type.
+//
+// Secret fields are redacted, byte slices are kept as slog.Any values, and
+// errors, stringers and objects are passed through as slog.Any values.
+func ToSlogAttr(field iris.Field) slog.Attr {
+	key := field.Key()
+	switch {
+	case field.IsString():
+		return slog.String(key, field.StringValue())
+	case field.IsInt():
+		return slog.Int64(key, field.IntValue())
+	case field.IsUint():
+		return slog.Uint64(key, field.UintValue())
+	case field.IsFloat():
+		return slog.Float64(key, field.FloatValue())
+	case field.IsBool():
+		return slog.Bool(key, field.BoolValue())
+	case field.IsDuration():
+		return slog.Duration(key, field.DurationValue())
+	case field.IsTime():
+		return slog.Time(key, field.TimeValue())
+	case field.IsBytes():
+		return slog.Any(key, field.BytesValue())
+	case field.Type() == secretKind:
+		return slog.String(key, "[REDACTED]")
+	case field.Obj != nil:
+		return slog.Any(key, field.Obj)
+	default:
+		return slog.String(key, field.Str)
+	}
+}
+
+// toSlogLevel maps an Iris level to slog. Levels above Error (DPanic, Panic
+// and Fatal) map to increasing levels above slog.LevelError.
+func toSlogLevel(level iris.Level) slog.Level {
+	switch {
+	case level <= iris.Debug:
+		return slog.LevelDebug
+	case level == iris.Info:
+		return slog.LevelInfo
+	case level == iris.Warn:
+		return slog.LevelWarn
+	default:
+		return slog.LevelError + slog.Level(level-iris.Error)
+	}
+}
@@ -0,0 +1,123 @@
+// slog_writer_test.go: Tests for the reverse Iris to slog bridge
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agilira/iris"
+)
+
+// capturingHandler records the records it handles.
+type capturingHandler struct {
+	level   slog.Level
+	records []slog.Record
+	err     error
+}
+
+func (h *capturingHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.level
+}
+
+func (h *capturingHandler) Handle(_ context.Context, record slog.Record) error {
+	h.records = append(h.records, record)
+	return h.err
+}
+
+func (h *capturingHandler) WithAttrs([]slog.Attr) slog.Handler { return h }
+func (h *capturingHandler) WithGroup(string) slog.Handler      { return h }
+
+func TestToSlogRecord_RoundTrip(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	original := slog.NewRecord(now, slog.LevelWarn, "round trip", 0)
+	original.AddAttrs(
+		slog.String("s", "v"),
+		slog.Int64("i", -3),
+		slog.Uint64("u", 7),
+		slog.Float64("f", 1.5),
+		slog.Bool("b", true),
+		slog.Duration("d", time.Second),
+		slog.Time("t", now),
+	)
+
+	converted := ToSlogRecord(ConvertRecord(original))
+	if converted.Level != slog.LevelWarn || converted.Message != "round trip" {
+		t.Fatalf("Unexpected level or message: %v %q", converted.Level, converted.Message)
+	}
+
+	want := map[string]slog.Value{}
+	original.Attrs(func(a slog.Attr) bool { want[a.Key] = a.Value; return true })
+	converted.Attrs(func(a slog.Attr) bool {
+		if w, ok := want[a.Key]; !ok || !w.Equal(a.Value) {
+			t.Errorf("Attribute %q = %v, want %v", a.Key, a.Value, w)
+		}
+		delete(want, a.Key)
+		return true
+	})
+	if len(want) != 0 {
+		t.Errorf("Missing attributes after round trip: %v", want)
+	}
+}
+
+func TestToSlogAttr_SpecialKinds(t *testing.T) {
+	errBoom := errors.New("boom")
+	tests := []struct {
+		field iris.Field
+		want  slog.Value
+	}{
+		{iris.Secret("password", "hunter2"), slog.StringValue("[REDACTED]")},
+		{iris.ErrorField(errBoom), slog.AnyValue(errBoom)},
+		{iris.Object("obj", 42), slog.AnyValue(42)},
+	}
+	for _, tt := range tests {
+		if got := ToSlogAttr(tt.field); !got.Value.Equal(tt.want) {
+			t.Errorf("ToSlogAttr(%q) = %v, want %v", tt.field.Key(), got.Value, tt.want)
+		}
+	}
+	if got := ToSlogAttr(iris.Bytes("raw", []byte("ab"))); !bytes.Equal(got.Value.Any().([]byte), []byte("ab")) {
+		t.Errorf("Expected bytes to be preserved, got %v", got.Value)
+	}
+}
+
+func TestToSlogRecord_Metadata(t *testing.T) {
+	record := iris.NewRecord(iris.Fatal, "fatal")
+	record.Logger, record.Caller, record.Stack = "db", "main.go:1", "trace"
+
+	converted := ToSlogRecord(record)
+	if converted.Level <= slog.LevelError {
+		t.Errorf("Expected Fatal above slog.LevelError, got %v", converted.Level)
+	}
+	var keys []string
+	converted.Attrs(func(a slog.Attr) bool { keys = append(keys, a.Key); return true })
+	if got := strings.Join(keys, ","); got != "logger,caller,stack" {
+		t.Errorf("Expected metadata attributes, got %s", got)
+	}
+}
+
+func TestSlogWriter_Hook(t *testing.T) {
+	handler := &capturingHandler{level: slog.LevelInfo}
+	writer := NewSlogWriter(handler)
+	hook := writer.Hook()
+
+	hook(iris.NewRecord(iris.Debug, "skipped"))
+	hook(iris.NewRecord(iris.Info, "delivered"))
+	if len(handler.records) != 1 || handler.records[0].Message != "delivered" {
+		t.Fatalf("Expected only enabled records to be delivered, got %d", len(handler.records))
+	}
+
+	handler.err = errors.New("unavailable")
+	hook(iris.NewRecord(iris.Error, "failed"))
+	if writer.Failed() != 1 {
+		t.Errorf("Expected 1 failed record, got %d", writer.Failed())
+	}
+}
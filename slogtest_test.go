@@ -0,0 +1,78 @@
+// slogtest_test.go: slog.Handler compliance with testing/slogtest
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"log/slog"
+	"strings"
+	"testing"
+	"testing/slogtest"
+
+	"github.com/agilira/iris"
+)
+
+// recordMap returns record as slogtest expects it: the built-in keys and the
+// fields, with dotted keys nested into one map per group.
+func recordMap(record *iris.Record) map[string]any {
+	m := map[string]any{
+		slog.LevelKey:   record.Level.String(),
+		slog.MessageKey: record.Msg,
+	}
+	for i := 0; i < record.FieldCount(); i++ {
+		field := record.GetField(i)
+		path := strings.Split(field.Key(), ".")
+		group := m
+		for _, name := range path[:len(path)-1] {
+			inner, ok := group[name].(map[string]any)
+			if !ok {
+				inner = make(map[string]any)
+				group[name] = inner
+			}
+			group = inner
+		}
+		group[path[len(path)-1]] = fieldValue(field)
+	}
+	return m
+}
+
+// fieldValue returns the value of f.
+func fieldValue(f iris.Field) any {
+	switch {
+	case f.IsString():
+		return f.StringValue()
+	case f.IsInt():
+		return f.IntValue()
+	case f.IsUint():
+		return f.UintValue()
+	case f.IsFloat():
+		return f.FloatValue()
+	case f.IsBool():
+		return f.BoolValue()
+	case f.IsDuration():
+		return f.DurationValue()
+	case f.IsTime():
+		return f.TimeValue()
+	default:
+		return f.Obj
+	}
+}
+
+func TestSlogtest(t *testing.T) {
+	provider := New(100)
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	err := slogtest.TestHandler(provider, func() []map[string]any {
+		var results []map[string]any
+		for provider.Len() > 0 {
+			results = append(results, recordMap(readWithTimeout(t, provider)))
+		}
+		return results
+	})
+	if err != nil {
+		t.Error(err)
+	}
+}
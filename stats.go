@@ -0,0 +1,303 @@
+// stats.go: Provider operational counters
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Stats is a snapshot of the provider's operational counters. It encodes to
+// JSON with stable snake_case keys; see also Provider.DumpJSON.
+type Stats struct {
+	// Handled counts records offered to the buffer, after level, filter,
+	// sampling and throttling checks. Throttling summaries are included.
+	Handled uint64 `json:"handled"`
+
+	// Buffered is the number of records waiting in the buffer, including
+	// records held by WithResequencing.
+	Buffered uint64 `json:"buffered"`
+
+	// Converted counts records taken from the buffer and converted by Read
+	// or ReadBatch, including records later dropped by middleware.
+	Converted uint64 `json:"converted"`
+
+	// Dropped counts records that could not be buffered because the buffer
+	// was full or the provider was closed, or that were evicted.
+	Dropped uint64 `json:"dropped"`
+
+	// Evicted counts buffered records evicted in favor of heavier records
+	// by WithWeightedEviction, or of newer records by WithDropOldest. They
+	// are included in Dropped.
+	Evicted uint64 `json:"evicted"`
+
+	// Expired counts records discarded at Read because they outlived their
+	// WithRecordTTL time to live.
+	Expired uint64 `json:"expired"`
+
+	// ReadFiltered counts records discarded at Read because they were below
+	// the WithReadLevel or Rules.ReadLevel floor.
+	ReadFiltered uint64 `json:"read_filtered"`
+
+	// Boosted counts records that passed the level checks only because of
+	// an active WithErrorBoost boost.
+	Boosted uint64 `json:"boosted"`
+
+	// Unconvertible counts attributes without a typed conversion reported
+	// by WithStrictTyping.
+	Unconvertible uint64 `json:"unconvertible"`
+
+	// ConversionErrors counts attributes whose conversion failed.
+	ConversionErrors uint64 `json:"conversion_errors"`
+
+	// Coerced counts attribute values converted to the kind WithCoercion
+	// requires for their key.
+	Coerced uint64 `json:"coerced"`
+
+	// CoercionFailed counts attribute values that did not have the kind
+	// WithCoercion requires for their key and could not be converted.
+	CoercionFailed uint64 `json:"coercion_failed"`
+
+	// EncryptionErrors counts records dropped because WithEncryption failed
+	// to encrypt one of their attributes.
+	EncryptionErrors uint64 `json:"encryption_errors"`
+
+	// ConversionPanics counts records whose conversion panicked and that
+	// were delivered in degraded form.
+	ConversionPanics uint64 `json:"conversion_panics"`
+
+	// EnrichmentsSkipped counts records buffered without enrichment because
+	// their context deadline was within the WithDeadlineMargin margin or
+	// the WithDegradation stage skips enrichment.
+	EnrichmentsSkipped uint64 `json:"enrichments_skipped"`
+
+	// RetrySaved counts records buffered by a WithRetryGrace retry after
+	// finding the buffer full.
+	RetrySaved uint64 `json:"retry_saved"`
+
+	// InternalPanics counts panics recovered in internal goroutines, such
+	// as the watchdog, which were restarted afterwards.
+	InternalPanics uint64 `json:"internal_panics"`
+
+	// PressureSampled counts records dropped by WithMemoryPressure sampling.
+	PressureSampled uint64 `json:"pressure_sampled"`
+
+	// BackpressureDropped counts records rejected by the WithBackpressure
+	// stage in effect.
+	BackpressureDropped uint64 `json:"backpressure_dropped"`
+
+	// DegradationDropped counts records rejected by the WithDegradation
+	// stage in effect.
+	DegradationDropped uint64 `json:"degradation_dropped"`
+
+	// QuotaDropped counts records rejected because their NewScoped scope
+	// exhausted its quota.
+	QuotaDropped uint64 `json:"quota_dropped"`
+
+	// Captured counts dropped records written to the WithBurstCapture file.
+	Captured uint64 `json:"captured"`
+
+	// HandledBytes is the estimated size of the handled records, with
+	// WithSizeAccounting.
+	HandledBytes uint64 `json:"handled_bytes"`
+
+	// BufferedBytes is the estimated size of the buffered records, with
+	// WithSizeAccounting.
+	BufferedBytes uint64 `json:"buffered_bytes"`
+
+	// Redelivered counts records delivered again by WithAcknowledgement
+	// after a negative acknowledgement or timeout.
+	Redelivered uint64 `json:"redelivered"`
+
+	// AckFailed counts records given up after AckConfig.MaxAttempts
+	// unacknowledged deliveries.
+	AckFailed uint64 `json:"ack_failed"`
+
+	// Unacked is the number of delivered records awaiting acknowledgement.
+	Unacked uint64 `json:"unacked"`
+
+	// MetricsOnly counts records counted and discarded by WithMetricsOnly.
+	MetricsOnly uint64 `json:"metrics_only"`
+
+	// DryRun counts records converted and discarded by WithDryRun.
+	DryRun uint64 `json:"dry_run"`
+
+	// DryRunNanos is the total time spent converting DryRun records, in
+	// nanoseconds; DryRunNanos / DryRun is the average overhead.
+	DryRunNanos uint64 `json:"dry_run_nanos"`
+
+	// MemoryPressure reports whether the provider is currently backing off
+	// because of memory pressure.
+	MemoryPressure bool `json:"memory_pressure"`
+
+	// Backpressure is the highest pressure currently reported to
+	// WithBackpressure, between 0 and 1.
+	Backpressure float64 `json:"backpressure"`
+
+	// Degradation is the current WithDegradation stage, StageNormal
+	// without that option.
+	Degradation DegradationStage `json:"degradation"`
+
+	// Timeline is the buffer activity per interval recorded by
+	// WithStatsTimeline. It is nil without that option, which keeps Stats
+	// comparable.
+	Timeline *StatsTimeline `json:"timeline,omitempty"`
+}
+
+// counters holds the live counters behind Stats.
+type counters struct {
+	handled          atomic.Uint64
+	converted        atomic.Uint64
+	dropped          atomic.Uint64
+	evicted          atomic.Uint64
+	expired          atomic.Uint64
+	readFiltered     atomic.Uint64
+	boosted          atomic.Uint64
+	unconvertible    atomic.Uint64
+	conversionErrors atomic.Uint64
+	coerced          atomic.Uint64
+	coercionFailed   atomic.Uint64
+	encryptionErrors atomic.Uint64
+	conversionPanics atomic.Uint64
+
+	enrichmentsSkipped  atomic.Uint64
+	retrySaved          atomic.Uint64
+	internalPanics      atomic.Uint64
+	pressureSampled     atomic.Uint64
+	backpressureDropped atomic.Uint64
+	degradationDropped  atomic.Uint64
+	quotaDropped        atomic.Uint64
+	captured            atomic.Uint64
+	handledBytes        atomic.Uint64
+	redelivered         atomic.Uint64
+	ackFailed           atomic.Uint64
+	metricsOnly         atomic.Uint64
+	dryRun              atomic.Uint64
+	dryRunNanos         atomic.Uint64
+}
+
+// Stats returns a snapshot of the provider's counters. It is safe to call
+// concurrently with logging, in which case the counters may reflect
+// operations in flight.
+func (p *Provider) Stats() Stats {
+	// Load in reverse pipeline order so a record is never counted twice.
+	converted := p.stats.converted.Load()
+	expired := p.stats.expired.Load()
+	readFiltered := p.stats.readFiltered.Load()
+	buffered := uint64(p.buffered()) // #nosec G115 -- len is never negative
+	dropped := p.stats.dropped.Load()
+	unacked := 0
+	if p.acks != nil {
+		unacked = p.acks.pending()
+	}
+	var timeline *StatsTimeline
+	if p.timeline != nil {
+		timeline = p.timeline.snapshot()
+	}
+	return Stats{
+		Handled:          p.stats.handled.Load(),
+		Buffered:         buffered,
+		Converted:        converted,
+		Dropped:          dropped,
+		Evicted:          p.stats.evicted.Load(),
+		Expired:          expired,
+		ReadFiltered:     readFiltered,
+		Boosted:          p.stats.boosted.Load(),
+		Unconvertible:    p.stats.unconvertible.Load(),
+		ConversionErrors: p.stats.conversionErrors.Load(),
+		Coerced:          p.stats.coerced.Load(),
+		CoercionFailed:   p.stats.coercionFailed.Load(),
+		EncryptionErrors: p.stats.encryptionErrors.Load(),
+		ConversionPanics: p.stats.conversionPanics.Load(),
+
+		EnrichmentsSkipped:  p.stats.enrichmentsSkipped.Load(),
+		RetrySaved:          p.stats.retrySaved.Load(),
+		InternalPanics:      p.stats.internalPanics.Load(),
+		PressureSampled:     p.stats.pressureSampled.Load(),
+		BackpressureDropped: p.stats.backpressureDropped.Load(),
+		DegradationDropped:  p.stats.degradationDropped.Load(),
+		QuotaDropped:        p.stats.quotaDropped.Load(),
+		Captured:            p.stats.captured.Load(),
+		HandledBytes:        p.stats.handledBytes.Load(),
+		BufferedBytes:       uint64(p.bufferedBytes()), // #nosec G115 -- size is never negative
+		Redelivered:         p.stats.redelivered.Load(),
+		AckFailed:           p.stats.ackFailed.Load(),
+		MetricsOnly:         p.stats.metricsOnly.Load(),
+		DryRun:              p.stats.dryRun.Load(),
+		DryRunNanos:         p.stats.dryRunNanos.Load(),
+		Unacked:             uint64(unacked), // #nosec G115 -- len is never negative
+		MemoryPressure:      p.memory != nil && p.memory.pressure.Load(),
+		Backpressure:        p.backpressure.pressureOrZero(),
+		Degradation:         p.degrade.current(),
+		Timeline:            timeline,
+	}
+}
+
+// Len returns the number of records waiting in the buffer.
+func (p *Provider) Len() int {
+	return p.queue.len()
+}
+
+// Cap returns the buffer capacity given to New.
+func (p *Provider) Cap() int {
+	return p.queue.cap()
+}
+
+// Dropped returns the number of records dropped because the buffer was full
+// or the provider was closed, since creation or the last ResetCounters.
+func (p *Provider) Dropped() uint64 {
+	return p.stats.dropped.Load()
+}
+
+// ResetCounters resets the counters reported by Stats and Dropped, e.g. at
+// the start of each health-check interval. Buffered records are carried over
+// as handled, so the Verify accounting keeps holding after a reset.
+func (p *Provider) ResetCounters() {
+	if p.opts.sequence {
+		// Hold the sequence lock so no record is buffered during the reset.
+		p.seqMu.Lock()
+		defer p.seqMu.Unlock()
+	}
+	buffered := uint64(p.buffered()) // #nosec G115 -- len is never negative
+	p.stats.handled.Store(buffered)
+	p.stats.converted.Store(0)
+	p.stats.dropped.Store(0)
+	p.stats.evicted.Store(0)
+	p.stats.expired.Store(0)
+	p.stats.readFiltered.Store(0)
+	p.stats.boosted.Store(0)
+	p.stats.unconvertible.Store(0)
+	p.stats.conversionErrors.Store(0)
+	p.stats.coerced.Store(0)
+	p.stats.coercionFailed.Store(0)
+	p.stats.encryptionErrors.Store(0)
+	p.stats.conversionPanics.Store(0)
+	p.stats.enrichmentsSkipped.Store(0)
+	p.stats.retrySaved.Store(0)
+	p.stats.internalPanics.Store(0)
+	p.stats.pressureSampled.Store(0)
+	p.stats.backpressureDropped.Store(0)
+	p.stats.degradationDropped.Store(0)
+	p.stats.quotaDropped.Store(0)
+	p.stats.captured.Store(0)
+	p.stats.redelivered.Store(0)
+	p.stats.ackFailed.Store(0)
+	p.stats.metricsOnly.Store(0)
+	p.stats.dryRun.Store(0)
+	p.stats.dryRunNanos.Store(0)
+	p.stats.handledBytes.Store(uint64(p.bufferedBytes())) // #nosec G115 -- size is never negative
+	p.seqBase = p.seq - buffered
+	if p.timeline != nil {
+		p.timeline.rebase(p, time.Now())
+	}
+	if p.latency != nil {
+		p.latency.reset()
+	}
+	if p.coercion != nil {
+		p.coercion.reset()
+	}
+}
@@ -0,0 +1,68 @@
+// stats_test.go: Tests for provider counters and introspection accessors
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+)
+
+func TestProvider_LenCapDropped(t *testing.T) {
+	provider := New(2)
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	logger := slog.New(provider)
+	for i := 0; i < 5; i++ {
+		logger.Info("burst")
+	}
+	if provider.Len() != 2 || provider.Cap() != 2 || provider.Dropped() != 3 {
+		t.Errorf("Len = %d, Cap = %d, Dropped = %d; want 2, 2, 3", provider.Len(), provider.Cap(), provider.Dropped())
+	}
+}
+
+func TestProvider_ResetCounters(t *testing.T) {
+	provider := New(2, WithSequence())
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	logger := slog.New(provider)
+	for i := 0; i < 3; i++ {
+		logger.Info("before")
+	}
+	provider.ResetCounters()
+
+	stats := provider.Stats()
+	if stats.Dropped != 0 || stats.Converted != 0 || stats.Handled != 2 || stats.Buffered != 2 {
+		t.Errorf("Stats() after reset = %+v", stats)
+	}
+	if err := provider.Verify(); err != nil {
+		t.Errorf("Verify() after reset = %v", err)
+	}
+
+	if _, err := provider.Read(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	logger.Info("after")
+	logger.Info("dropped")
+	if provider.Dropped() != 1 {
+		t.Errorf("Dropped() = %d, want 1", provider.Dropped())
+	}
+	if err := provider.Verify(); err != nil {
+		t.Errorf("Verify() = %v", err)
+	}
+}
+
+func BenchmarkProvider_Len(b *testing.B) {
+	provider := New(100)
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = provider.Len() + provider.Cap()
+		_ = provider.Dropped()
+	}
+}
@@ -0,0 +1,112 @@
+// strict.go: Strict typing mode for structured-logging hygiene
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"fmt"
+	"log/slog"
+)
+
+// StrictTyping configures the handling of attribute values without a typed
+// Iris conversion, which would otherwise silently fall back to their String
+// form: slog.KindAny values of types not registered with RegisterConverter
+// (other than Field attributes). Like conversion, the check resolves
+// slog.LogValuer values and applies to the members of groups, which are
+// reported under their dotted key, e.g. "req.body". The check follows the
+// DefaultFieldConverter rules even when WithFieldConverter is configured.
+type StrictTyping struct {
+	// OnViolation, if set, is called from Handle for each unconvertible
+	// attribute of a record that passed level, filter and sampling checks.
+	OnViolation func(record slog.Record, attr slog.Attr)
+
+	// Reject drops records with unconvertible attributes and makes Handle
+	// return an *UnconvertibleValueError. Note that slog.Logger discards
+	// handler errors, so the error is only visible to callers of Handle.
+	Reject bool
+}
+
+// UnconvertibleValueError reports an attribute rejected by strict typing.
+type UnconvertibleValueError struct {
+	Key  string    // Attribute key
+	Kind slog.Kind // Attribute value kind
+}
+
+// Error implements error.
+func (e *UnconvertibleValueError) Error() string {
+	return fmt.Sprintf("slog provider: attribute %q of kind %v has no typed conversion", e.Key, e.Kind)
+}
+
+// WithStrictTyping enables strict typing: every unconvertible attribute is
+// counted in Stats().Unconvertible and reported according to cfg. It is
+// intended for development and CI builds, to keep log attributes typed:
+//
+//	provider := slogprovider.New(1000, slogprovider.WithStrictTyping(slogprovider.StrictTyping{
+//	    OnViolation: func(r slog.Record, a slog.Attr) {
+//	        panic(fmt.Sprintf("untyped log attribute %q in %q", a.Key, r.Message))
+//	    },
+//	}))
+func WithStrictTyping(cfg StrictTyping) Option {
+	return func(o *options) { o.strict = &cfg }
+}
+
+// checkTypes applies strict typing to record, returning the error for the
+// first unconvertible attribute when the configuration rejects it.
+func (p *Provider) checkTypes(record slog.Record) error {
+	var err error
+	var check func(prefix string, attr slog.Attr)
+	check = func(prefix string, attr slog.Attr) {
+		attr.Value = attr.Value.Resolve()
+		if attr.Value.Kind() == slog.KindGroup {
+			if attr.Key != "" {
+				prefix = joinPath(prefix, attr.Key)
+			}
+			for _, member := range attr.Value.Group() {
+				check(prefix, member)
+			}
+			return
+		}
+		if convertible(attr.Value) {
+			return
+		}
+		attr.Key = joinPath(prefix, attr.Key)
+		p.stats.unconvertible.Add(1)
+		if p.opts.strict.OnViolation != nil {
+			p.opts.strict.OnViolation(record, attr)
+		}
+		if p.opts.strict.Reject && err == nil {
+			err = &UnconvertibleValueError{Key: attr.Key, Kind: attr.Value.Kind()}
+		}
+	}
+	record.Attrs(func(attr slog.Attr) bool {
+		check("", attr)
+		return true
+	})
+	return err
+}
+
+// convertible reports whether value has a typed Iris conversion, resolving
+// slog.LogValuer values. Groups are convertible when all their members are.
+func convertible(value slog.Value) bool {
+	value = value.Resolve()
+	switch value.Kind() {
+	case slog.KindAny:
+		if _, ok := value.Any().(*irisValue); ok {
+			return true
+		}
+		_, ok := lookupConverter(value.Any())
+		return ok
+	case slog.KindGroup:
+		for _, member := range value.Group() {
+			if !convertible(member.Value) {
+				return false
+			}
+		}
+		return true
+	default:
+		return true
+	}
+}
@@ -0,0 +1,98 @@
+// strict_test.go: Tests for strict typing mode
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+type point struct{ X, Y int }
+
+func TestWithStrictTyping_ReportsUnconvertible(t *testing.T) {
+	var keys []string
+	provider := New(10, WithStrictTyping(StrictTyping{
+		OnViolation: func(_ slog.Record, a slog.Attr) { keys = append(keys, a.Key) },
+	}))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	slog.New(provider).Info("moved",
+		"id", 7,
+		"at", point{1, 2},
+		"meta", slog.GroupValue(slog.String("k", "v"), slog.Any("origin", point{})),
+		"elapsed", time.Second,
+	)
+
+	if len(keys) != 2 || keys[0] != "at" || keys[1] != "meta.origin" {
+		t.Errorf("violations = %v, want [at meta.origin]", keys)
+	}
+	if got := provider.Stats().Unconvertible; got != 2 {
+		t.Errorf("Stats().Unconvertible = %d, want 2", got)
+	}
+	if got := provider.queue.len(); got != 1 {
+		t.Errorf("buffered %d records, want 1 (report only)", got)
+	}
+}
+
+func TestWithStrictTyping_RejectReturnsError(t *testing.T) {
+	provider := New(10, WithStrictTyping(StrictTyping{Reject: true}))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	record := slog.NewRecord(time.Now(), slog.LevelInfo, "moved", 0)
+	record.AddAttrs(slog.Any("at", point{1, 2}))
+
+	err := provider.Handle(context.Background(), record)
+	var uerr *UnconvertibleValueError
+	if !errors.As(err, &uerr) || uerr.Key != "at" || uerr.Kind != slog.KindAny {
+		t.Fatalf("Handle() error = %v, want *UnconvertibleValueError for at", err)
+	}
+	if got := provider.queue.len(); got != 0 {
+		t.Errorf("buffered %d records, want rejected record dropped", got)
+	}
+}
+
+// userToken is a LogValuer resolving to a typed value.
+type userToken string
+
+func (userToken) LogValue() slog.Value { return slog.StringValue("redacted") }
+
+func TestWithStrictTyping_AcceptsGroupsAndLogValuers(t *testing.T) {
+	provider := New(10, WithStrictTyping(StrictTyping{Reject: true}))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	record := slog.NewRecord(time.Now(), slog.LevelInfo, "request", 0)
+	record.AddAttrs(
+		slog.Group("req", "id", 7, slog.Group("auth", "token", userToken("secret"))),
+		slog.Any("token", userToken("secret")),
+	)
+	if err := provider.Handle(context.Background(), record); err != nil {
+		t.Fatalf("Handle() error = %v, want groups and LogValuers accepted", err)
+	}
+	if got := provider.Stats().Unconvertible; got != 0 {
+		t.Errorf("Stats().Unconvertible = %d, want 0", got)
+	}
+
+	rejected := slog.NewRecord(time.Now(), slog.LevelInfo, "request", 0)
+	rejected.AddAttrs(slog.Group("req", slog.Any("at", point{1, 2})))
+	var uerr *UnconvertibleValueError
+	if err := provider.Handle(context.Background(), rejected); !errors.As(err, &uerr) || uerr.Key != "req.at" {
+		t.Errorf("Handle() error = %v, want *UnconvertibleValueError for req.at", err)
+	}
+}
+
+func TestStrictTyping_DisabledByDefault(t *testing.T) {
+	provider := New(10)
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	slog.New(provider).Info("moved", "at", point{1, 2})
+	if got := provider.Stats().Unconvertible; got != 0 {
+		t.Errorf("Stats().Unconvertible = %d, want 0 without strict typing", got)
+	}
+}
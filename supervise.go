@@ -0,0 +1,68 @@
+// supervise.go: Self-healing supervision of internal goroutines
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"fmt"
+	"time"
+)
+
+// Restart backoff bounds for supervised components.
+const (
+	minRestartBackoff = 10 * time.Millisecond
+	maxRestartBackoff = 5 * time.Second
+)
+
+// InternalPanicError reports a panic recovered in an internal provider
+// goroutine. The affected component is restarted.
+type InternalPanicError struct {
+	Component string // Internal component name, e.g. "watchdog"
+	Value     any    // Value passed to panic
+}
+
+// Error implements error.
+func (e *InternalPanicError) Error() string {
+	return fmt.Sprintf("slog provider: internal %s panicked and was restarted: %v", e.Component, e.Value)
+}
+
+// supervise runs run in a new goroutine and restarts it, with exponential
+// backoff, whenever it panics, so that logging is never what takes the
+// service down. Every incident is counted in Stats().InternalPanics and
+// reported on Errors as an *InternalPanicError. Supervision ends when run
+// returns normally or the provider is closed.
+//
+// run must rebuild any state it needs on entry, since it is called afresh
+// after each restart.
+func (p *Provider) supervise(component string, run func()) {
+	go func() {
+		backoff := minRestartBackoff
+		for {
+			if !p.runProtected(component, run) {
+				return
+			}
+			select {
+			case <-p.closed:
+				return
+			case <-time.After(backoff):
+			}
+			backoff = min(backoff*2, maxRestartBackoff)
+		}
+	}()
+}
+
+// runProtected calls run, reporting whether it panicked.
+func (p *Provider) runProtected(component string, run func()) (panicked bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			panicked = true
+			p.stats.internalPanics.Add(1)
+			p.reportError(&InternalPanicError{Component: component, Value: r})
+		}
+	}()
+	run()
+	return false
+}
@@ -0,0 +1,75 @@
+// supervise_test.go: Tests for self-healing supervision of internal goroutines
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+// panicWriter panics on every write.
+type panicWriter struct{}
+
+func (panicWriter) Write([]byte) (int, error) { panic("diagnostic sink broken") }
+
+// waitForError returns the next error of type T reported by provider.
+func waitForError[T error](t *testing.T, provider *Provider) T {
+	t.Helper()
+	deadline := time.After(2 * time.Second)
+	for {
+		select {
+		case err := <-provider.Errors():
+			var target T
+			if errors.As(err, &target) {
+				return target
+			}
+		case <-deadline:
+			var zero T
+			t.Fatalf("no %T reported", zero)
+			return zero
+		}
+	}
+}
+
+func TestSupervise_RestartsPanickedWatchdog(t *testing.T) {
+	provider := New(10, WithWatchdog(WatchdogConfig{Timeout: 10 * time.Millisecond, Output: panicWriter{}}))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	logger := slog.New(provider)
+	logger.Info("stuck")
+	incident := waitForError[*InternalPanicError](t, provider)
+	if incident.Component != "watchdog" || incident.Value != "diagnostic sink broken" {
+		t.Errorf("incident = %+v", incident)
+	}
+
+	// Consumer activity re-arms the restarted watchdog, which detects the next stall.
+	readRecord(t, provider, func(*slog.Logger) {})
+	logger.Info("stuck again")
+	waitForError[*StallError](t, provider)
+	waitForError[*InternalPanicError](t, provider)
+
+	if got := provider.Stats().InternalPanics; got != 2 {
+		t.Errorf("Stats().InternalPanics = %d, want 2", got)
+	}
+}
+
+func TestSupervise_StopsOnNormalReturn(t *testing.T) {
+	provider := New(1)
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	runs := make(chan struct{}, 4)
+	provider.supervise("test", func() { runs <- struct{}{} })
+
+	<-runs
+	select {
+	case <-runs:
+		t.Error("component restarted after returning normally")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
@@ -0,0 +1,195 @@
+// throttle.go: Per-message time-window throttling
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// Keys of the attributes carried by throttling summary records.
+const (
+	ThrottledMessageKey = "throttled_message"
+	SuppressedCountKey  = "suppressed"
+)
+
+// ThrottleConfig configures per-message throttling.
+type ThrottleConfig struct {
+	// Limit is the maximum number of records emitted per key and window.
+	Limit int
+
+	// Window is the length of the throttling window.
+	Window time.Duration
+
+	// KeyAttr optionally names an attribute whose value is combined with the
+	// message to form the throttling key, e.g. "endpoint" to throttle each
+	// endpoint's retry loop independently.
+	KeyAttr string
+}
+
+// WithThrottle limits every message (or message and KeyAttr value) to
+// cfg.Limit records per cfg.Window.
+//
+// When a window in which records were suppressed ends, the provider emits a
+// summary record at the level of the throttled message:
+//
+//	{"msg":"suppressed 42 similar records","throttled_message":"retrying","suppressed":42}
+//
+// Summaries are emitted when the key is logged again, when any record is
+// handled after the window of a quiet key has ended, and on Close, so the
+// final count of a loop that stopped is not lost. Throttling is ideal for
+// retry and poll loops; for steady high-volume traffic prefer a Sampler.
+//
+// A non-positive Limit or Window disables throttling.
+func WithThrottle(cfg ThrottleConfig) Option {
+	return func(o *options) {
+		if cfg.Limit <= 0 || cfg.Window <= 0 {
+			o.throttle = nil
+			return
+		}
+		o.throttle = &cfg
+	}
+}
+
+// throttler tracks per-key emission counts.
+type throttler struct {
+	cfg       ThrottleConfig
+	clock     Clock
+	mu        sync.Mutex
+	windows   map[string]*throttleWindow
+	nextSweep time.Time
+}
+
+// throttleWindow is the state of one key within its current window.
+type throttleWindow struct {
+	end        time.Time
+	count      int
+	suppressed int
+	level      slog.Level
+	message    string
+	keyValue   slog.Value
+}
+
+// newThrottler creates a throttler for cfg reading time from clock, or nil
+// if cfg is nil.
+func newThrottler(cfg *ThrottleConfig, clock Clock) *throttler {
+	if cfg == nil {
+		return nil
+	}
+	return &throttler{
+		cfg:     *cfg,
+		clock:   clock,
+		windows: make(map[string]*throttleWindow),
+	}
+}
+
+// admit reports whether record may be emitted and returns the summary
+// records of windows that ended since the last call.
+func (t *throttler) admit(record slog.Record) (bool, []slog.Record) {
+	now := clockNow(t.clock)
+	key, keyValue := t.key(record)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	summaries := t.sweep(now, key)
+
+	w := t.windows[key]
+	if w == nil || !now.Before(w.end) {
+		if w != nil && w.suppressed > 0 {
+			summaries = append(summaries, w.summary(now, t.cfg.KeyAttr))
+		}
+		t.windows[key] = &throttleWindow{
+			end:      now.Add(t.cfg.Window),
+			count:    1,
+			level:    record.Level,
+			message:  record.Message,
+			keyValue: keyValue,
+		}
+		return true, summaries
+	}
+
+	if w.count < t.cfg.Limit {
+		w.count++
+		return true, summaries
+	}
+	w.suppressed++
+	return false, summaries
+}
+
+// flush returns summaries for every window with suppressed records and
+// resets the throttler.
+func (t *throttler) flush() []slog.Record {
+	now := clockNow(t.clock)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var summaries []slog.Record
+	for key, w := range t.windows {
+		if w.suppressed > 0 {
+			summaries = append(summaries, w.summary(now, t.cfg.KeyAttr))
+		}
+		delete(t.windows, key)
+	}
+	return summaries
+}
+
+// sweep removes expired windows other than skip, at most once per window
+// length, returning their summaries. It bounds memory for keys that stop
+// being logged. Must be called with t.mu held.
+func (t *throttler) sweep(now time.Time, skip string) []slog.Record {
+	if now.Before(t.nextSweep) {
+		return nil
+	}
+	t.nextSweep = now.Add(t.cfg.Window)
+
+	var summaries []slog.Record
+	for key, w := range t.windows {
+		if key == skip || now.Before(w.end) {
+			continue
+		}
+		if w.suppressed > 0 {
+			summaries = append(summaries, w.summary(now, t.cfg.KeyAttr))
+		}
+		delete(t.windows, key)
+	}
+	return summaries
+}
+
+// key computes the throttling key of record.
+func (t *throttler) key(record slog.Record) (string, slog.Value) {
+	if t.cfg.KeyAttr == "" {
+		return record.Message, slog.Value{}
+	}
+
+	var value slog.Value
+	record.Attrs(func(a slog.Attr) bool {
+		if a.Key == t.cfg.KeyAttr {
+			value = a.Value
+			return false
+		}
+		return true
+	})
+	return record.Message + "\x00" + value.String(), value
+}
+
+// summary builds the record reporting the suppressed records of w.
+func (w *throttleWindow) summary(now time.Time, keyAttr string) slog.Record {
+	msg := fmt.Sprintf("suppressed %d similar records", w.suppressed)
+	record := slog.NewRecord(now, w.level, msg, 0)
+	record.AddAttrs(
+		slog.String(ThrottledMessageKey, w.message),
+		slog.Int(SuppressedCountKey, w.suppressed),
+	)
+	if keyAttr != "" {
+		record.AddAttrs(slog.Attr{Key: keyAttr, Value: w.keyValue})
+	}
+	return record
+}
@@ -0,0 +1,84 @@
+// throttle_test.go: Tests for per-message throttling
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func TestWithThrottle_LimitsAndSummarizes(t *testing.T) {
+	provider := New(100, WithThrottle(ThrottleConfig{Limit: 2, Window: 30 * time.Millisecond}))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	logger := slog.New(provider)
+	for i := 0; i < 5; i++ {
+		logger.Warn("retrying")
+	}
+	if got := provider.queue.len(); got != 2 {
+		t.Fatalf("buffered %d records within the window, want 2", got)
+	}
+
+	time.Sleep(40 * time.Millisecond)
+	logger.Warn("retrying")
+
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		_, _ = provider.Read(ctx)
+	}
+	summary, _ := provider.Read(ctx)
+	if summary.Msg != "suppressed 3 similar records" {
+		t.Fatalf("summary msg = %q", summary.Msg)
+	}
+	if f, _ := findField(summary, ThrottledMessageKey); f.StringValue() != "retrying" {
+		t.Errorf("%s = %q, want retrying", ThrottledMessageKey, f.StringValue())
+	}
+	if f, _ := findField(summary, SuppressedCountKey); f.IntValue() != 3 {
+		t.Errorf("%s = %d, want 3", SuppressedCountKey, f.IntValue())
+	}
+	if next, _ := provider.Read(ctx); next.Msg != "retrying" {
+		t.Errorf("record after summary = %q, want retrying", next.Msg)
+	}
+}
+
+func TestWithThrottle_KeyAttrSeparatesKeys(t *testing.T) {
+	provider := New(100, WithThrottle(ThrottleConfig{Limit: 1, Window: time.Hour, KeyAttr: "endpoint"}))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	logger := slog.New(provider)
+	logger.Info("poll failed", "endpoint", "a")
+	logger.Info("poll failed", "endpoint", "a")
+	logger.Info("poll failed", "endpoint", "b")
+
+	if got := provider.queue.len(); got != 2 {
+		t.Errorf("buffered %d records, want 2", got)
+	}
+}
+
+func TestWithThrottle_CloseFlushesSummaries(t *testing.T) {
+	provider := New(100, WithThrottle(ThrottleConfig{Limit: 1, Window: time.Hour}))
+
+	logger := slog.New(provider)
+	logger.Info("tick")
+	logger.Info("tick")
+	_ = provider.Close()
+
+	provider.queue.pop()
+	e, _ := provider.queue.pop()
+	summary := provider.convertEntry(e)
+	if f, _ := findField(summary, SuppressedCountKey); f.IntValue() != 1 {
+		t.Errorf("summary after Close = %q, want 1 suppressed record", summary.Msg)
+	}
+}
+
+func TestWithThrottle_Disabled(t *testing.T) {
+	if New(1, WithThrottle(ThrottleConfig{Limit: 0, Window: time.Second})).throttle != nil {
+		t.Error("zero limit must disable throttling")
+	}
+}
@@ -0,0 +1,152 @@
+// timeline.go: Time-partitioned buffer statistics for burst forensics
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"sync"
+	"time"
+)
+
+// TimelineConfig configures WithStatsTimeline.
+type TimelineConfig struct {
+	// Window is how far back the timeline reaches; 5m when zero.
+	Window time.Duration
+
+	// Resolution is the length of each interval; 1s when zero.
+	Resolution time.Duration
+}
+
+// StatsTimeline is the rolling timeline reported in Stats().Timeline.
+type StatsTimeline struct {
+	// Resolution is the length of each interval.
+	Resolution time.Duration `json:"resolution"`
+
+	// Intervals are the closed intervals within the window, oldest first.
+	Intervals []StatsInterval `json:"intervals"`
+}
+
+// StatsInterval holds the buffer activity of one timeline interval.
+type StatsInterval struct {
+	// Start is the start of the interval.
+	Start time.Time `json:"start"`
+
+	// Accepted counts records buffered during the interval.
+	Accepted uint64 `json:"accepted"`
+
+	// Dropped counts records dropped during the interval, including
+	// evictions.
+	Dropped uint64 `json:"dropped"`
+
+	// Buffered is the number of buffered records at the end of the
+	// interval.
+	Buffered uint64 `json:"buffered"`
+}
+
+// WithStatsTimeline keeps a rolling timeline of buffer activity, reported in
+// Stats().Timeline and DumpJSON, so that after an incident one can see when
+// a burst hit and how long drops lasted rather than only lifetime totals:
+//
+//	provider := slogprovider.New(1000, slogprovider.WithStatsTimeline(slogprovider.TimelineConfig{
+//	    Window: 10 * time.Minute,
+//	}))
+//
+// A supervised goroutine closes an interval every Resolution, recording the
+// counter deltas, and the timeline keeps the intervals within Window, oldest
+// first. Quiet intervals are included, so gaps in traffic are visible too.
+// ResetCounters does not clear the timeline.
+func WithStatsTimeline(cfg TimelineConfig) Option {
+	if cfg.Window <= 0 {
+		cfg.Window = 5 * time.Minute
+	}
+	if cfg.Resolution <= 0 {
+		cfg.Resolution = time.Second
+	}
+	return func(o *options) { o.timeline = &cfg }
+}
+
+// timeline is the ring of closed intervals.
+type timeline struct {
+	cfg       TimelineConfig
+	mu        sync.Mutex
+	intervals []StatsInterval // Ring buffer of closed intervals
+	head      int             // Index of the oldest interval
+	n         int             // Number of closed intervals
+	start     time.Time       // Start of the open interval
+	handled   uint64          // Handled counter at the start of the open interval
+	dropped   uint64          // Dropped counter at the start of the open interval
+	evicted   uint64          // Evicted counter at the start of the open interval
+}
+
+// newTimeline creates a timeline for cfg, or nil if cfg is nil.
+func newTimeline(cfg *TimelineConfig) *timeline {
+	if cfg == nil {
+		return nil
+	}
+	size := max(int(cfg.Window/cfg.Resolution), 1)
+	return &timeline{cfg: *cfg, intervals: make([]StatsInterval, size), start: time.Now()}
+}
+
+// run closes an interval every Resolution until the provider is closed.
+func (t *timeline) run(p *Provider) {
+	ticker := time.NewTicker(t.cfg.Resolution)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-p.closed:
+			return
+		case now := <-ticker.C:
+			t.tick(p, now)
+		}
+	}
+}
+
+// rebase starts the open interval at now from the current counters.
+func (t *timeline) rebase(p *Provider, now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.start = now
+	t.handled = p.stats.handled.Load()
+	t.dropped = p.stats.dropped.Load()
+	t.evicted = p.stats.evicted.Load()
+}
+
+// tick closes the open interval at now.
+func (t *timeline) tick(p *Provider, now time.Time) {
+	dropped := p.stats.dropped.Load()
+	evicted := p.stats.evicted.Load()
+	handled := p.stats.handled.Load()
+	buffered := uint64(p.buffered()) // #nosec G115 -- len is never negative
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	interval := StatsInterval{Start: t.start, Buffered: buffered}
+	if dropped >= t.dropped && handled >= t.handled && evicted >= t.evicted {
+		interval.Dropped = dropped - t.dropped
+		// Evicted records were accepted in an earlier interval.
+		if rejected := interval.Dropped - (evicted - t.evicted); handled-t.handled > rejected {
+			interval.Accepted = handled - t.handled - rejected
+		}
+	}
+	t.intervals[(t.head+t.n)%len(t.intervals)] = interval
+	if t.n < len(t.intervals) {
+		t.n++
+	} else {
+		t.head = (t.head + 1) % len(t.intervals)
+	}
+	t.start, t.handled, t.dropped, t.evicted = now, handled, dropped, evicted
+}
+
+// snapshot returns the closed intervals, oldest first.
+func (t *timeline) snapshot() *StatsTimeline {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	out := &StatsTimeline{Resolution: t.cfg.Resolution, Intervals: make([]StatsInterval, t.n)}
+	for i := range out.Intervals {
+		out.Intervals[i] = t.intervals[(t.head+i)%len(t.intervals)]
+	}
+	return out
+}
@@ -0,0 +1,79 @@
+// timeline_test.go: Tests for the rolling statistics timeline
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func TestTimeline_RecordsBurst(t *testing.T) {
+	provider := New(5, WithStatsTimeline(TimelineConfig{Window: 3 * time.Hour, Resolution: time.Hour}))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+	logger := slog.New(provider)
+	tl := provider.timeline
+	start := time.Now()
+	tl.rebase(provider, start)
+
+	logger.Info("quiet")
+	tl.tick(provider, start.Add(time.Hour))
+	for i := 0; i < 8; i++ {
+		logger.Info("burst")
+	}
+	tl.tick(provider, start.Add(2*time.Hour))
+	tl.tick(provider, start.Add(3*time.Hour))
+	tl.tick(provider, start.Add(4*time.Hour))
+
+	got := provider.Stats().Timeline
+	if got == nil || got.Resolution != time.Hour || len(got.Intervals) != 3 {
+		t.Fatalf("Expected 3 hourly intervals, got %+v", got)
+	}
+	want := []StatsInterval{
+		{Start: start.Add(time.Hour), Accepted: 4, Dropped: 4, Buffered: 5},
+		{Start: start.Add(2 * time.Hour), Buffered: 5},
+		{Start: start.Add(3 * time.Hour), Buffered: 5},
+	}
+	for i, interval := range got.Intervals {
+		if interval != want[i] {
+			t.Errorf("Interval %d = %+v, want %+v", i, interval, want[i])
+		}
+	}
+}
+
+func TestTimeline_Evictions(t *testing.T) {
+	provider := New(2, WithMinLevel(slog.LevelDebug), WithWeightedEviction(nil),
+		WithStatsTimeline(TimelineConfig{Resolution: time.Hour}))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+	logger := slog.New(provider)
+	tl := provider.timeline
+	start := time.Now()
+	tl.rebase(provider, start)
+
+	logger.Debug("heartbeat")
+	logger.Debug("heartbeat")
+	logger.Error("failure") // Evicts a heartbeat
+	tl.tick(provider, start.Add(time.Hour))
+
+	got := provider.Stats().Timeline.Intervals
+	if len(got) != 1 || got[0].Accepted != 3 || got[0].Dropped != 1 {
+		t.Errorf("Unexpected intervals %+v", got)
+	}
+}
+
+func TestTimeline_Runs(t *testing.T) {
+	provider := New(10, WithStatsTimeline(TimelineConfig{Window: time.Second, Resolution: 5 * time.Millisecond}))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	deadline := time.Now().Add(time.Second)
+	for provider.Stats().Timeline == nil || len(provider.Stats().Timeline.Intervals) < 2 {
+		if time.Now().After(deadline) {
+			t.Fatal("Timeline did not record intervals")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
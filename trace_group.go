@@ -0,0 +1,160 @@
+// trace_group.go: Contiguous emission of records sharing a trace ID
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// TraceGroupingConfig configures the grouping of records by trace ID.
+type TraceGroupingConfig struct {
+	// Key is the attribute holding the trace ID; "trace_id" when empty.
+	Key string
+
+	// Window is how long the first record of a trace is held while further
+	// records of the trace are collected; 100ms when zero.
+	Window time.Duration
+
+	// MaxRecords flushes a group as soon as it holds this many records;
+	// 100 when zero. It is capped to the buffer capacity.
+	MaxRecords int
+
+	// TraceID, if set, replaces the Key lookup, e.g. to read the span
+	// context of a tracing library from ctx. Records for which it returns
+	// "" are not grouped.
+	TraceID func(ctx context.Context, record slog.Record) string
+}
+
+// WithTraceGrouping holds records sharing a trace ID for a short window and
+// then buffers them as one contiguous batch, so all log lines of a request
+// appear together in the output even when many requests log concurrently:
+//
+//	provider := slogprovider.New(10000, slogprovider.WithTraceGrouping(slogprovider.TraceGroupingConfig{
+//	    Window: 50 * time.Millisecond,
+//	}))
+//
+// Records without a trace ID are buffered immediately. Grouping delays
+// traced records by up to Window, and a group is dropped as a whole when the
+// buffer has no room for it (see Transaction.Commit); pending groups are
+// flushed on Close. Records held by a Transaction are not grouped.
+func WithTraceGrouping(cfg TraceGroupingConfig) Option {
+	if cfg.Key == "" {
+		cfg.Key = "trace_id"
+	}
+	if cfg.Window <= 0 {
+		cfg.Window = 100 * time.Millisecond
+	}
+	if cfg.MaxRecords <= 0 {
+		cfg.MaxRecords = 100
+	}
+	return func(o *options) { o.traceGrouping = &cfg }
+}
+
+// traceGrouper holds the pending groups of traced records.
+type traceGrouper struct {
+	cfg    TraceGroupingConfig
+	mu     sync.Mutex
+	groups map[string]*traceGroup
+	closed bool
+}
+
+// traceGroup is the pending records of one trace.
+type traceGroup struct {
+	entries []entry
+	timer   *time.Timer
+}
+
+// newTraceGrouper creates a grouper for cfg, or nil if cfg is nil.
+func newTraceGrouper(cfg *TraceGroupingConfig, capacity int) *traceGrouper {
+	if cfg == nil {
+		return nil
+	}
+	g := &traceGrouper{cfg: *cfg, groups: make(map[string]*traceGroup)}
+	g.cfg.MaxRecords = max(min(g.cfg.MaxRecords, capacity), 1)
+	return g
+}
+
+// traceID returns the trace ID of record, or "" if it has none.
+func (g *traceGrouper) traceID(ctx context.Context, record slog.Record) string {
+	if g.cfg.TraceID != nil {
+		return g.cfg.TraceID(ctx, record)
+	}
+	var id string
+	record.Attrs(func(attr slog.Attr) bool {
+		if attr.Key == g.cfg.Key {
+			id = attr.Value.Resolve().String()
+			return false
+		}
+		return true
+	})
+	return id
+}
+
+// groupTrace holds e in the group of its trace, reporting whether it did.
+func (p *Provider) groupTrace(ctx context.Context, e entry) bool {
+	g := p.traces
+	id := g.traceID(ctx, e.record)
+	if id == "" {
+		return false
+	}
+
+	g.mu.Lock()
+	if g.closed {
+		g.mu.Unlock()
+		return false
+	}
+	group := g.groups[id]
+	if group == nil {
+		group = &traceGroup{}
+		group.timer = time.AfterFunc(g.cfg.Window, func() { p.flushTrace(id, group) })
+		g.groups[id] = group
+	}
+	group.entries = append(group.entries, e)
+	if len(group.entries) < g.cfg.MaxRecords {
+		g.mu.Unlock()
+		return true
+	}
+	group.timer.Stop()
+	delete(g.groups, id)
+	g.mu.Unlock()
+
+	_ = p.enqueueBatch(group.entries) // Drops are counted in Stats
+	return true
+}
+
+// flushTrace buffers group when its window ends, unless it was flushed
+// already.
+func (p *Provider) flushTrace(id string, group *traceGroup) {
+	g := p.traces
+	g.mu.Lock()
+	if g.groups[id] != group {
+		g.mu.Unlock()
+		return
+	}
+	delete(g.groups, id)
+	g.mu.Unlock()
+
+	_ = p.enqueueBatch(group.entries) // Drops are counted in Stats
+}
+
+// flushTraces buffers all pending groups and stops grouping.
+func (p *Provider) flushTraces() {
+	g := p.traces
+	g.mu.Lock()
+	g.closed = true
+	groups := g.groups
+	g.groups = nil
+	g.mu.Unlock()
+
+	for _, group := range groups {
+		group.timer.Stop()
+		_ = p.enqueueBatch(group.entries) // Best effort: dropped if the buffer is full
+	}
+}
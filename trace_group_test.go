@@ -0,0 +1,106 @@
+// trace_group_test.go: Tests for grouping records by trace ID
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+// readMessages reads n records and returns their messages.
+func readMessages(t *testing.T, provider *Provider, n int) []string {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	msgs := make([]string, 0, n)
+	for len(msgs) < n {
+		record, err := provider.Read(ctx)
+		if err != nil || record == nil {
+			t.Fatalf("Read failed after %v: %v", msgs, err)
+		}
+		msgs = append(msgs, record.Msg)
+	}
+	return msgs
+}
+
+func TestWithTraceGrouping_EmitsTracesContiguously(t *testing.T) {
+	provider := New(100, WithTraceGrouping(TraceGroupingConfig{Window: 20 * time.Millisecond}))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+	logger := slog.New(provider)
+
+	logger.Info("a1", "trace_id", "a")
+	logger.Info("b1", "trace_id", "b")
+	logger.Info("untraced")
+	logger.Info("a2", "trace_id", "a")
+	logger.Info("b2", "trace_id", "b")
+
+	msgs := readMessages(t, provider, 5)
+	if msgs[0] != "untraced" {
+		t.Errorf("Expected untraced record first, got %v", msgs)
+	}
+	for i := 1; i < 5; i += 2 {
+		if msgs[i][0] != msgs[i+1][0] || msgs[i][1] != '1' || msgs[i+1][1] != '2' {
+			t.Errorf("Expected trace groups in order, got %v", msgs)
+		}
+	}
+}
+
+func TestWithTraceGrouping_MaxRecordsFlushes(t *testing.T) {
+	provider := New(100, WithTraceGrouping(TraceGroupingConfig{Window: time.Hour, MaxRecords: 2}))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+	logger := slog.New(provider)
+
+	logger.Info("1", "trace_id", "t")
+	if got := provider.queue.len(); got != 0 {
+		t.Fatalf("Expected the first record held, got %d buffered", got)
+	}
+	logger.Info("2", "trace_id", "t")
+	if got := provider.queue.len(); got != 2 {
+		t.Errorf("Expected the full group flushed, got %d buffered", got)
+	}
+}
+
+func TestWithTraceGrouping_FlushedOnClose(t *testing.T) {
+	provider := New(100, WithTraceGrouping(TraceGroupingConfig{Window: time.Hour}))
+	logger := slog.New(provider)
+
+	logger.Info("held", "trace_id", "t")
+	_ = provider.Close()
+
+	if msgs := readMessages(t, provider, 1); msgs[0] != "held" {
+		t.Errorf("Expected held record after Close, got %v", msgs)
+	}
+	if err := provider.Handle(context.Background(), slog.NewRecord(time.Now(), slog.LevelInfo, "late", 0)); err != ErrClosed {
+		t.Errorf("Expected ErrClosed after Close, got %v", err)
+	}
+}
+
+func TestWithTraceGrouping_CustomTraceID(t *testing.T) {
+	type traceKey struct{}
+	provider := New(100, WithTraceGrouping(TraceGroupingConfig{
+		Window:     time.Hour,
+		MaxRecords: 2,
+		TraceID: func(ctx context.Context, _ slog.Record) string {
+			id, _ := ctx.Value(traceKey{}).(string)
+			return id
+		},
+	}))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+	logger := slog.New(provider)
+
+	ctx := context.WithValue(context.Background(), traceKey{}, "t")
+	logger.InfoContext(ctx, "1")
+	logger.Info("untraced", "trace_id", "ignored")
+	logger.InfoContext(ctx, "2")
+
+	if msgs := readMessages(t, provider, 3); msgs[0] != "untraced" || msgs[1] != "1" || msgs[2] != "2" {
+		t.Errorf("Unexpected order %v", msgs)
+	}
+}
@@ -0,0 +1,177 @@
+// transaction.go: Log transactions emitting related records contiguously
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrTransactionDone is returned by Commit and Rollback on a transaction
+// that was already committed or rolled back.
+var ErrTransactionDone = errors.New("slog provider transaction already committed or rolled back")
+
+// ErrTransactionDropped is returned by Commit when the buffer has no room
+// for all records of the transaction within the WithRetryGrace period. The
+// records are dropped together and counted in Stats().Dropped.
+var ErrTransactionDropped = errors.New("slog provider transaction dropped: buffer full")
+
+// txKey is the context key of the transaction opened with Begin.
+type txKey struct{}
+
+// Transaction collects the records logged with its context so that they are
+// buffered together on Commit, as one contiguous batch that records logged
+// concurrently cannot interleave with.
+type Transaction struct {
+	p       *Provider
+	mu      sync.Mutex
+	entries []entry
+	done    bool
+}
+
+// Begin opens a log transaction and returns a context bound to it. Records
+// logged through the provider with the returned context, or a context
+// derived from it, pass the usual checks in Handle and are then held by the
+// transaction instead of being buffered:
+//
+//	ctx, tx := provider.Begin(ctx)
+//	defer func() { _ = tx.Rollback() }()
+//	logger.InfoContext(ctx, "job started", "job", id)
+//	logger.InfoContext(ctx, "step done", "step", 1)
+//	return tx.Commit() // Both records appear together
+//
+// A transaction holds at most as many records as the buffer capacity,
+// because larger batches could never be buffered; further records are
+// dropped. Records logged with the context after Commit or Rollback are
+// buffered immediately.
+func (p *Provider) Begin(ctx context.Context) (context.Context, *Transaction) {
+	tx := &Transaction{p: p}
+	return context.WithValue(ctx, txKey{}, tx), tx
+}
+
+// Commit buffers the records of the transaction as one contiguous batch,
+// all or none: when the buffer lacks room for the batch it is retried for
+// the WithRetryGrace period, then dropped with ErrTransactionDropped.
+// Commit returns ErrClosed if the provider is closed.
+func (tx *Transaction) Commit() error {
+	entries, err := tx.finish()
+	if err != nil || len(entries) == 0 {
+		return err
+	}
+	return tx.p.enqueueBatch(entries)
+}
+
+// Rollback discards the records of the transaction. After Commit it
+// returns ErrTransactionDone, so it can be deferred unconditionally.
+func (tx *Transaction) Rollback() error {
+	_, err := tx.finish()
+	return err
+}
+
+// Len returns the number of records held by the transaction.
+func (tx *Transaction) Len() int {
+	tx.mu.Lock()
+	defer tx.mu.Unlock()
+	return len(tx.entries)
+}
+
+// finish ends the transaction and returns its records.
+func (tx *Transaction) finish() ([]entry, error) {
+	tx.mu.Lock()
+	defer tx.mu.Unlock()
+	if tx.done {
+		return nil, ErrTransactionDone
+	}
+	tx.done = true
+	entries := tx.entries
+	tx.entries = nil
+	return entries, nil
+}
+
+// add holds e if the transaction is still open, reporting whether it did.
+// Entries beyond the buffer capacity are handled and dropped at once, with
+// the accounting of enqueue.
+func (tx *Transaction) add(e entry) bool {
+	tx.mu.Lock()
+	defer tx.mu.Unlock()
+	if tx.done {
+		return false
+	}
+	if len(tx.entries) >= tx.p.queue.cap() {
+		if tx.p.opts.sizeAccounting {
+			e.size = entrySize(&e)
+		}
+		tx.p.stats.handled.Add(1)
+		tx.p.stats.handledBytes.Add(uint64(e.size)) // #nosec G115 -- size is never negative
+		tx.p.stats.dropped.Add(1)
+		return true
+	}
+	tx.entries = append(tx.entries, e)
+	return true
+}
+
+// transactionFor returns the open transaction of p carried by ctx, if any.
+func (p *Provider) transactionFor(ctx context.Context) *Transaction {
+	if ctx == nil {
+		return nil
+	}
+	tx, _ := ctx.Value(txKey{}).(*Transaction)
+	if tx == nil || tx.p != p {
+		return nil
+	}
+	return tx
+}
+
+// enqueueBatch stores entries contiguously, all or none, following the
+// accounting rules of enqueue.
+func (p *Provider) enqueueBatch(entries []entry) error {
+	batch := entries[:0]
+	for _, e := range entries {
+		if p.opts.sizeAccounting {
+			e.size = entrySize(&e)
+		}
+		p.stats.handled.Add(1)
+		p.stats.handledBytes.Add(uint64(e.size)) // #nosec G115 -- size is never negative
+		if p.opts.faults.bufferFull(e.record) {
+			p.stats.dropped.Add(1)
+			continue // Injected fault: drop as if the buffer were full
+		}
+		batch = append(batch, e)
+	}
+	if len(batch) == 0 {
+		return nil
+	}
+
+	if p.opts.sequence {
+		p.seqMu.Lock()
+		defer p.seqMu.Unlock()
+		for i := range batch {
+			p.seq++
+			batch[i].seq = p.seq
+		}
+	}
+
+	push := func() pushResult { return p.queue.pushAll(batch) }
+	result := push()
+	if result == pushFull && p.opts.retryGrace > 0 {
+		result = p.retryPush(push)
+	}
+	switch result {
+	case pushClosed:
+		p.stats.dropped.Add(uint64(len(batch)))
+		return ErrClosed
+	case pushFull:
+		p.stats.dropped.Add(uint64(len(batch)))
+		return ErrTransactionDropped
+	default:
+		if p.watchdog != nil {
+			p.watchdog.arm()
+		}
+		return nil
+	}
+}
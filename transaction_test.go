@@ -0,0 +1,156 @@
+// transaction_test.go: Tests for log transactions
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"testing"
+)
+
+func TestTransaction_CommitIsContiguous(t *testing.T) {
+	provider := New(100)
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+	logger := slog.New(provider)
+
+	ctx, tx := provider.Begin(context.Background())
+	logger.InfoContext(ctx, "step 1")
+	logger.Info("outside")
+	logger.InfoContext(ctx, "step 2")
+
+	if tx.Len() != 2 || provider.queue.len() != 1 {
+		t.Fatalf("Expected 2 held and 1 buffered record, got %d and %d", tx.Len(), provider.queue.len())
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("Commit failed: %v", err)
+	}
+
+	for _, want := range []string{"outside", "step 1", "step 2"} {
+		record, err := provider.Read(context.Background())
+		if err != nil || record == nil || record.Msg != want {
+			t.Fatalf("Expected %q, got %v (err=%v)", want, record, err)
+		}
+	}
+}
+
+func TestTransaction_NoInterleaving(t *testing.T) {
+	provider := New(10000, WithSequence())
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+	logger := slog.New(provider)
+
+	const steps = 20
+	var wg sync.WaitGroup
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx, tx := provider.Begin(context.Background())
+			for i := 0; i < steps; i++ {
+				logger.InfoContext(ctx, "step", "worker", w)
+				logger.Info("noise")
+			}
+			if err := tx.Commit(); err != nil {
+				t.Errorf("Commit failed: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	run, worker := 0, int64(-1)
+	for provider.queue.len() > 0 {
+		record, _ := provider.Read(context.Background())
+		if record.Msg != "step" {
+			if run != 0 {
+				t.Fatalf("Transaction interrupted after %d records", run)
+			}
+			continue
+		}
+		f, _ := findField(record, "worker")
+		if run == 0 {
+			worker = f.IntValue()
+		} else if f.IntValue() != worker {
+			t.Fatalf("Records of workers %d and %d interleaved", worker, f.IntValue())
+		}
+		if run++; run == steps {
+			run = 0
+		}
+	}
+}
+
+func TestTransaction_Rollback(t *testing.T) {
+	provider := New(100)
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+	logger := slog.New(provider)
+
+	ctx, tx := provider.Begin(context.Background())
+	logger.InfoContext(ctx, "discarded")
+	if err := tx.Rollback(); err != nil {
+		t.Fatalf("Rollback failed: %v", err)
+	}
+	if err := tx.Commit(); !errors.Is(err, ErrTransactionDone) {
+		t.Errorf("Expected ErrTransactionDone from Commit after Rollback, got %v", err)
+	}
+
+	logger.InfoContext(ctx, "after")
+	if got := provider.queue.len(); got != 1 {
+		t.Errorf("Expected only the record logged after Rollback, got %d buffered", got)
+	}
+}
+
+func TestTransaction_DroppedWhenBufferFull(t *testing.T) {
+	provider := New(3)
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+	logger := slog.New(provider)
+
+	ctx, tx := provider.Begin(context.Background())
+	logger.InfoContext(ctx, "a")
+	logger.InfoContext(ctx, "b")
+	logger.Info("fills")
+	logger.Info("buffer")
+
+	if err := tx.Commit(); !errors.Is(err, ErrTransactionDropped) {
+		t.Fatalf("Expected ErrTransactionDropped, got %v", err)
+	}
+	if stats := provider.Stats(); stats.Dropped != 2 || stats.Buffered != 2 {
+		t.Errorf("Expected the whole batch dropped, got %+v", stats)
+	}
+}
+
+func TestTransaction_OverflowKeepsAccountingConsistent(t *testing.T) {
+	provider := New(2, WithSizeAccounting())
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+	logger := slog.New(provider)
+
+	ctx, tx := provider.Begin(context.Background())
+	for i := 0; i < 4; i++ {
+		logger.InfoContext(ctx, "step", "i", i)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("Commit failed: %v", err)
+	}
+	if stats := provider.Stats(); stats.Handled != 4 || stats.Dropped != 2 || stats.Buffered != 2 {
+		t.Errorf("Expected 4 handled, 2 dropped and 2 buffered, got %+v", stats)
+	}
+	if err := provider.Verify(); err != nil {
+		t.Errorf("Verify failed after a transaction overflow: %v", err)
+	}
+}
+
+func TestTransaction_OtherProvider(t *testing.T) {
+	provider := New(100)
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+	other := New(100)
+	defer func() { _ = other.Close() }() // Ignore error in test cleanup
+
+	ctx, tx := other.Begin(context.Background())
+	slog.New(provider).InfoContext(ctx, "not held")
+	if tx.Len() != 0 || provider.queue.len() != 1 {
+		t.Errorf("Expected the record buffered by its own provider")
+	}
+}
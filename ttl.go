@@ -0,0 +1,57 @@
+// ttl.go: Per-level expiry of stale buffered records
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"log/slog"
+	"sort"
+	"time"
+)
+
+// levelTTL is the time to live of records up to a level.
+type levelTTL struct {
+	level slog.Level
+	ttl   time.Duration
+}
+
+// WithRecordTTL discards records that waited in the buffer longer than the
+// time to live of their level, instead of emitting them late.
+//
+// When a consumer stalls for minutes, draining ancient debug records on
+// recovery is pointless and delays fresh errors. ttls maps levels to times
+// to live; a record uses the TTL of the lowest configured level at or above
+// its own level, and records above every configured level never expire:
+//
+//	provider := slogprovider.New(10000, slogprovider.WithRecordTTL(map[slog.Level]time.Duration{
+//	    slog.LevelDebug: 30 * time.Second, // Debug and below
+//	    slog.LevelInfo:  5 * time.Minute,  // Above Debug up to Info
+//	}))
+//
+// Age is measured from the record time at Read. Expired records are counted
+// in Stats().Expired; records without a time and non-positive TTLs never
+// expire. The map is copied.
+func WithRecordTTL(ttls map[slog.Level]time.Duration) Option {
+	sorted := make([]levelTTL, 0, len(ttls))
+	for level, ttl := range ttls {
+		sorted = append(sorted, levelTTL{level: level, ttl: ttl})
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].level < sorted[j].level })
+	return func(o *options) { o.ttls = sorted }
+}
+
+// expired reports whether e outlived the TTL of its level.
+func (o *options) expired(e *entry) bool {
+	if e.record.Time.IsZero() {
+		return false
+	}
+	for _, t := range o.ttls {
+		if e.record.Level <= t.level {
+			return t.ttl > 0 && o.now().Sub(e.record.Time) > t.ttl
+		}
+	}
+	return false
+}
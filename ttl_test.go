@@ -0,0 +1,58 @@
+// ttl_test.go: Tests for per-level record expiry
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func TestWithRecordTTL_DiscardsStaleRecords(t *testing.T) {
+	provider := New(100, WithMinLevel(slog.LevelDebug), WithRecordTTL(map[slog.Level]time.Duration{
+		slog.LevelDebug: time.Minute,
+		slog.LevelInfo:  time.Hour,
+	}))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	stale := time.Now().Add(-10 * time.Minute)
+	for _, r := range []slog.Record{
+		slog.NewRecord(stale.Add(time.Minute), slog.LevelDebug, "stale debug", 0),
+		slog.NewRecord(stale, slog.LevelDebug, "stale debug", 0),
+		slog.NewRecord(stale, slog.LevelInfo, "old info", 0),
+		slog.NewRecord(stale.Add(-time.Hour), slog.LevelError, "ancient error", 0),
+		slog.NewRecord(time.Now(), slog.LevelDebug, "fresh debug", 0),
+	} {
+		if err := provider.Handle(context.Background(), r); err != nil {
+			t.Fatalf("Handle failed: %v", err)
+		}
+	}
+
+	msgs := readMessages(t, provider, 3)
+	if msgs[0] != "old info" || msgs[1] != "ancient error" || msgs[2] != "fresh debug" {
+		t.Errorf("Unexpected records %v", msgs)
+	}
+	if stats := provider.Stats(); stats.Expired != 2 {
+		t.Errorf("Expected 2 expired records, got %+v", stats)
+	}
+	if err := provider.Verify(); err != nil {
+		t.Errorf("Verify failed: %v", err)
+	}
+}
+
+func TestWithRecordTTL_ZeroTimeNeverExpires(t *testing.T) {
+	provider := New(100, WithRecordTTL(map[slog.Level]time.Duration{slog.LevelError: time.Nanosecond}))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	if err := provider.Handle(context.Background(), slog.NewRecord(time.Time{}, slog.LevelInfo, "untimed", 0)); err != nil {
+		t.Fatalf("Handle failed: %v", err)
+	}
+	if msgs := readMessages(t, provider, 1); msgs[0] != "untimed" {
+		t.Errorf("Unexpected records %v", msgs)
+	}
+}
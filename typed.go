@@ -0,0 +1,117 @@
+// typed.go: Typed attribute constructors with direct Iris conversion
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/agilira/iris"
+)
+
+// Typed attribute constructors for hot call sites.
+//
+// Values of named types such as
+//
+//	type UserID int64
+//
+// are stored by slog.Any as slog.KindAny, which boxes the value and converts
+// it through its String form on the Read path. The constructors below accept
+// any type with the matching underlying type and store it as the native slog
+// kind instead, so the value is neither boxed nor formatted:
+//
+//	logger.Info("login", slogprovider.Int("user", userID))
+//
+// Field passes a prebuilt Iris field through the provider unchanged, for
+// Iris types that slog has no kind for (bytes, secrets, errors, ...). Build
+// such attributes once and reuse them to avoid allocating per call.
+
+// Int returns an Attr for a signed integer of any named type.
+func Int[T ~int | ~int8 | ~int16 | ~int32 | ~int64](key string, v T) slog.Attr {
+	return slog.Int64(key, int64(v))
+}
+
+// Uint returns an Attr for an unsigned integer of any named type.
+func Uint[T ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr](key string, v T) slog.Attr {
+	return slog.Uint64(key, uint64(v))
+}
+
+// Float returns an Attr for a floating-point number of any named type.
+func Float[T ~float32 | ~float64](key string, v T) slog.Attr {
+	return slog.Float64(key, float64(v))
+}
+
+// String returns an Attr for a string of any named type.
+func String[T ~string](key string, v T) slog.Attr {
+	return slog.String(key, string(v))
+}
+
+// Bool returns an Attr for a boolean of any named type.
+func Bool[T ~bool](key string, v T) slog.Attr {
+	return slog.Bool(key, bool(v))
+}
+
+// Field returns an Attr carrying f, keyed f.Key(). DefaultFieldConverter
+// emits f as is, under the key adapted to the provider's naming conventions;
+// other slog handlers see the field's String form.
+func Field(f iris.Field) slog.Attr {
+	return slog.Any(f.K, &irisValue{field: f})
+}
+
+// Bytes returns an Attr converted to an iris.Bytes field rather than the
+// String form of the slice.
+func Bytes(key string, v []byte) slog.Attr {
+	return Field(iris.Bytes(key, v))
+}
+
+// irisValue wraps a prebuilt field in a slog.Value.
+type irisValue struct {
+	field iris.Field
+}
+
+// String implements fmt.Stringer for handlers other than the provider.
+func (v *irisValue) String() string {
+	f := v.field
+	switch {
+	case f.IsString():
+		return f.StringValue()
+	case f.IsInt():
+		return strconv.FormatInt(f.IntValue(), 10)
+	case f.IsUint():
+		return strconv.FormatUint(f.UintValue(), 10)
+	case f.IsFloat():
+		return strconv.FormatFloat(f.FloatValue(), 'g', -1, 64)
+	case f.IsBool():
+		return strconv.FormatBool(f.BoolValue())
+	case f.IsDuration():
+		return f.DurationValue().String()
+	case f.IsTime():
+		return f.TimeValue().Format(time.RFC3339Nano)
+	case f.IsBytes():
+		return string(f.BytesValue())
+	case f.Obj != nil:
+		return fmt.Sprint(f.Obj)
+	default:
+		return f.Str
+	}
+}
+
+// prebuiltField returns the field carried by value, if any, keyed key.
+func prebuiltField(key string, value slog.Value) (iris.Field, bool) {
+	if value.Kind() != slog.KindAny {
+		return iris.Field{}, false
+	}
+	v, ok := value.Any().(*irisValue)
+	if !ok || v == nil {
+		return iris.Field{}, false
+	}
+	f := v.field
+	f.K = key
+	return f, true
+}
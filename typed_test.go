@@ -0,0 +1,104 @@
+// typed_test.go: Tests for typed attribute constructors
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agilira/iris"
+)
+
+type userID int64
+
+type shard uint8
+
+type ratio float32
+
+type region string
+
+type flag bool
+
+func TestTypedAttrs_UseNativeKinds(t *testing.T) {
+	tests := []struct {
+		attr slog.Attr
+		kind slog.Kind
+	}{
+		{Int("user", userID(42)), slog.KindInt64},
+		{Uint("shard", shard(3)), slog.KindUint64},
+		{Float("ratio", ratio(0.5)), slog.KindFloat64},
+		{String("region", region("eu")), slog.KindString},
+		{Bool("flag", flag(true)), slog.KindBool},
+	}
+	for _, tt := range tests {
+		if got := tt.attr.Value.Kind(); got != tt.kind {
+			t.Errorf("%s: expected kind %v, got %v", tt.attr.Key, tt.kind, got)
+		}
+	}
+
+	if f := ConvertAttr(Int("user", userID(42)))[0]; !f.IsInt() || f.IntValue() != 42 {
+		t.Errorf("Expected int field 42, got %+v", f)
+	}
+}
+
+func TestTypedAttrs_NoAllocations(t *testing.T) {
+	id := userID(42)
+	allocs := testing.AllocsPerRun(100, func() {
+		_ = Int("user", id)
+	})
+	if allocs != 0 {
+		t.Errorf("Expected no allocations, got %v", allocs)
+	}
+}
+
+func TestField_PassesThroughConversion(t *testing.T) {
+	payload := []byte{0x01, 0x02}
+	f := ConvertAttr(Bytes("payload", payload))[0]
+	if !f.IsBytes() || !bytes.Equal(f.BytesValue(), payload) || f.Key() != "payload" {
+		t.Errorf("Expected bytes field, got %+v", f)
+	}
+
+	secret := ConvertAttr(Field(iris.Secret("token", "hunter2")))[0]
+	if secret.Type() != iris.Secret("", "").Type() || secret.Key() != "token" {
+		t.Errorf("Expected secret field, got %+v", secret)
+	}
+}
+
+func TestField_UsesAdaptedKey(t *testing.T) {
+	record := slog.NewRecord(time.Now(), slog.LevelInfo, "msg", 0)
+	record.AddAttrs(Field(iris.Int64("user.id", 7)))
+
+	converted := ConvertRecord(record, WithJournald(JournaldConfig{UppercaseFields: true}))
+	if f, ok := findField(converted, "USER_ID"); !ok || f.IntValue() != 7 {
+		t.Errorf("Expected USER_ID=7, got %+v (found=%v)", f, ok)
+	}
+}
+
+func TestField_StringForOtherHandlers(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+	logger.Info("msg", Field(iris.Int64("n", 7)), Bytes("b", []byte("raw")))
+
+	if out := buf.String(); !strings.Contains(out, "n=7") || !strings.Contains(out, "b=raw") {
+		t.Errorf("Unexpected text output %q", out)
+	}
+}
+
+func TestField_CountsAsConvertible(t *testing.T) {
+	provider := New(100, WithStrictTyping(StrictTyping{Reject: true}))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	record := slog.NewRecord(time.Now(), slog.LevelInfo, "msg", 0)
+	record.AddAttrs(Bytes("payload", []byte("x")))
+	if err := provider.Handle(context.Background(), record); err != nil {
+		t.Errorf("Expected Field attribute to be accepted, got %v", err)
+	}
+}
@@ -0,0 +1,97 @@
+// validate.go: Structured validation errors for settings and options
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Sentinel errors wrapped by the ValidationError values that NewChecked,
+// NewWithConfig, Config.Validate and Provider.UpdateConfig report, for use
+// with errors.Is.
+var (
+	ErrInvalidBufferSize  = errors.New("invalid buffer size")
+	ErrInvalidDropPolicy  = errors.New("invalid drop policy")
+	ErrInvalidRetryGrace  = errors.New("invalid retry grace")
+	ErrInvalidTTL         = errors.New("invalid record TTL")
+	ErrInvalidSampling    = errors.New("invalid sampling")
+	ErrInvalidHook        = errors.New("invalid hook")
+	ErrInvalidOption      = errors.New("invalid option")
+	ErrConflictingOptions = errors.New("conflicting options")
+	ErrNotUpdatable       = errors.New("setting cannot be updated")
+)
+
+// ValidationError describes one invalid setting. Several are joined in the
+// returned error when more than one setting is invalid:
+//
+//	provider, err := slogprovider.NewChecked(0, slogprovider.WithRetryGrace(-time.Second))
+//	if errors.Is(err, slogprovider.ErrInvalidBufferSize) {
+//	    ...
+//	}
+type ValidationError struct {
+	Err    error  // Sentinel error classifying the problem, e.g. ErrInvalidBufferSize
+	Detail string // Description of the offending setting and value
+}
+
+// Error implements error.
+func (e *ValidationError) Error() string {
+	return e.Detail
+}
+
+// Unwrap returns the sentinel error.
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// invalid returns a ValidationError of kind err.
+func invalid(err error, format string, args ...any) error {
+	return &ValidationError{Err: err, Detail: fmt.Sprintf(format, args...)}
+}
+
+// NewChecked is New with validation: instead of creating a provider, it
+// returns an error describing every invalid setting, such as a buffer size
+// that is not positive (with which New creates a provider that drops every
+// record), a negative retry grace or TTL, or options that contradict each
+// other. Every problem is a *ValidationError wrapping one of the ErrInvalid*
+// sentinels or ErrConflictingOptions.
+func NewChecked(bufferSize int, opts ...Option) (*Provider, error) {
+	o := newOptions(opts)
+	if err := o.validate(bufferSize); err != nil {
+		return nil, fmt.Errorf("slog provider: invalid options: %w", err)
+	}
+	return New(bufferSize, opts...), nil
+}
+
+// validate checks the options applied to a buffer of bufferSize records.
+func (o *options) validate(bufferSize int) error {
+	var errs []error
+	if bufferSize <= 0 {
+		errs = append(errs, invalid(ErrInvalidBufferSize, "buffer size must be positive, got %d", bufferSize))
+	}
+	if o.retryGrace < 0 {
+		errs = append(errs, invalid(ErrInvalidRetryGrace, "retry grace must not be negative, got %s", o.retryGrace))
+	}
+	for _, t := range o.ttls {
+		if t.ttl <= 0 {
+			errs = append(errs, invalid(ErrInvalidTTL, "record TTL of level %s must be positive, got %s", o.levelName(t.level), t.ttl))
+		}
+	}
+	if o.deadlineMargin < 0 {
+		errs = append(errs, invalid(ErrInvalidOption, "deadline margin must not be negative, got %s", o.deadlineMargin))
+	}
+	if o.recent < 0 {
+		errs = append(errs, invalid(ErrInvalidOption, "recent records must not be negative, got %d", o.recent))
+	}
+	if o.weight != nil && o.dropOldest {
+		errs = append(errs, invalid(ErrConflictingOptions, "WithDropOldest has no effect with WithWeightedEviction"))
+	}
+	if o.dropPolicy != nil && (o.weight != nil || o.dropOldest) {
+		errs = append(errs, invalid(ErrConflictingOptions, "WithDropPolicy has no effect with WithDropOldest or WithWeightedEviction"))
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,67 @@
+// validate_test.go: Tests for structured validation errors
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewChecked_ValidOptions(t *testing.T) {
+	provider, err := NewChecked(10, WithDropOldest(), WithRetryGrace(time.Millisecond))
+	if err != nil {
+		t.Fatalf("NewChecked failed: %v", err)
+	}
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+	if provider.Cap() != 10 {
+		t.Errorf("Cap() = %d, want 10", provider.Cap())
+	}
+}
+
+func TestNewChecked_ReportsTypedErrors(t *testing.T) {
+	_, err := NewChecked(0,
+		WithRetryGrace(-time.Second),
+		WithRecordTTL(map[slog.Level]time.Duration{slog.LevelDebug: -time.Minute}),
+		WithRecentRecords(-1),
+		WithDropOldest(),
+		WithWeightedEviction(nil),
+	)
+	if err == nil {
+		t.Fatal("Expected invalid options to be rejected")
+	}
+	for _, want := range []error{ErrInvalidBufferSize, ErrInvalidRetryGrace, ErrInvalidTTL, ErrInvalidOption, ErrConflictingOptions} {
+		if !errors.Is(err, want) {
+			t.Errorf("Expected %v in %q", want, err)
+		}
+	}
+	if errors.Is(err, ErrInvalidDropPolicy) {
+		t.Errorf("Unexpected %v in %q", ErrInvalidDropPolicy, err)
+	}
+	if !strings.HasPrefix(err.Error(), "slog provider: invalid options: buffer size must be positive, got 0") {
+		t.Errorf("Unexpected message %q", err)
+	}
+
+	var verr *ValidationError
+	if !errors.As(err, &verr) || verr.Err != ErrInvalidBufferSize {
+		t.Errorf("Expected a *ValidationError, got %#v", verr)
+	}
+}
+
+func TestNewWithConfig_TypedErrors(t *testing.T) {
+	_, err := NewWithConfig(Config{BufferSize: 10, DropPolicy: "drop_random"})
+	if !errors.Is(err, ErrInvalidDropPolicy) {
+		t.Errorf("Expected %v, got %v", ErrInvalidDropPolicy, err)
+	}
+
+	_, err = NewWithConfig(Config{BufferSize: 10, DropPolicy: EvictWeighted, Options: []Option{WithDropOldest()}})
+	if !errors.Is(err, ErrConflictingOptions) {
+		t.Errorf("Expected conflicting Options to be reported, got %v", err)
+	}
+}
@@ -0,0 +1,50 @@
+// verify.go: Internal consistency self-check
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Verify cross-checks the provider's internal state and returns an error
+// describing every inconsistency found, or nil. It checks that:
+//
+//   - every handled record is accounted for as buffered, converted, expired,
+//     read-filtered or dropped
+//     (Handled = Buffered + Converted + Expired + ReadFiltered + Dropped)
+//   - the buffer does not exceed its capacity
+//   - with WithSequence, exactly one index was assigned per handled record
+//
+// The provider does not pool records: each Read converts into a fresh
+// iris.Record that Iris copies, so there are no pooled records to leak.
+//
+// Verify is meant for tests, including downstream tests run with -race, to
+// catch integration misuse such as reading a provider from a second
+// consumer. The counters are only exact when no Handle or Read call is in
+// flight, so call Verify once logging has quiesced.
+func (p *Provider) Verify() error {
+	var errs []error
+	stats := p.Stats()
+
+	if accounted := stats.Buffered + stats.Converted + stats.Expired + stats.ReadFiltered + stats.Dropped; stats.Handled != accounted {
+		errs = append(errs, fmt.Errorf("record accounting mismatch: handled %d, buffered %d + converted %d + expired %d + read-filtered %d + dropped %d = %d",
+			stats.Handled, stats.Buffered, stats.Converted, stats.Expired, stats.ReadFiltered, stats.Dropped, accounted))
+	}
+	if n, c := p.queue.len(), p.queue.cap(); n > c {
+		errs = append(errs, fmt.Errorf("buffer length %d exceeds capacity %d", n, c))
+	}
+	if p.opts.sequence {
+		p.seqMu.Lock()
+		seq := p.seq - p.seqBase
+		p.seqMu.Unlock()
+		if seq != stats.Handled {
+			errs = append(errs, fmt.Errorf("sequence mismatch: assigned %d indexes for %d handled records", seq, stats.Handled))
+		}
+	}
+	return errors.Join(errs...)
+}
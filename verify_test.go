@@ -0,0 +1,59 @@
+// verify_test.go: Tests for the internal consistency self-check
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestVerify_ConsistentAfterConcurrentUse(t *testing.T) {
+	provider := New(64, WithSequence())
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	logger := slog.New(provider)
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				logger.Info("load")
+			}
+		}()
+	}
+	wg.Wait()
+	for i := 0; i < 10; i++ {
+		if _, err := provider.Read(context.Background()); err != nil {
+			t.Fatalf("Read() error = %v", err)
+		}
+	}
+
+	if err := provider.Verify(); err != nil {
+		t.Fatalf("Verify() = %v", err)
+	}
+	stats := provider.Stats()
+	if stats.Handled != 400 || stats.Converted != 10 || stats.Buffered != 54 || stats.Dropped != 336 {
+		t.Errorf("Stats() = %+v", stats)
+	}
+}
+
+func TestVerify_DetectsAccountingMismatch(t *testing.T) {
+	provider := New(8)
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	slog.New(provider).Info("counted")
+	provider.stats.handled.Add(1) // Simulate a record lost without accounting
+
+	err := provider.Verify()
+	if err == nil || !strings.Contains(err.Error(), "record accounting mismatch") {
+		t.Errorf("Verify() = %v, want accounting mismatch", err)
+	}
+}
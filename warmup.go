@@ -0,0 +1,65 @@
+// warmup.go: Warm-up of provider memory and conversion paths at New
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/agilira/iris"
+)
+
+// WithWarmUp moves one-time costs from the first records to New, for
+// latency-critical services that cannot afford first-request allocation
+// spikes and cold-path jitter. At New, the provider:
+//
+//   - touches every buffer slot, so the operating system commits the buffer
+//     memory up front instead of on first use;
+//   - pre-resolves the minimum levels of the named loggers (group paths such
+//     as "db.pool") whenever runtime rules are applied with SetRules, so the
+//     first record of each logger does not pay the rule lookup;
+//   - converts one sample value of every slog kind with
+//     DefaultFieldConverter, initializing the conversion paths without
+//     calling application converters, hooks or middleware.
+//
+// Warm-up does not buffer records or change any counter.
+func WithWarmUp(loggers ...string) Option {
+	return func(o *options) {
+		o.warmUp = true
+		o.warmLoggers = append(o.warmLoggers, loggers...)
+	}
+}
+
+// warmUp performs the WithWarmUp work.
+func (p *Provider) warmUp() {
+	p.queue.touch()
+
+	now := time.Now()
+	samples := []slog.Value{
+		slog.StringValue("warm-up"),
+		slog.Int64Value(1),
+		slog.Uint64Value(1),
+		slog.Float64Value(1),
+		slog.BoolValue(true),
+		slog.DurationValue(time.Second),
+		slog.TimeValue(now),
+		slog.AnyValue(errors.New("warm-up")),
+	}
+	record := iris.NewRecord(iris.Info, "warm-up")
+	for _, value := range samples {
+		record.AddField(DefaultFieldConverter.ConvertField("warm-up", value))
+	}
+	record.Reset()
+}
+
+// warmRules pre-resolves the warm-up logger names against r.
+func (o *options) warmRules(r *activeRules) {
+	for _, name := range o.warmLoggers {
+		r.levelFor(name)
+	}
+}
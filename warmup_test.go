@@ -0,0 +1,47 @@
+// warmup_test.go: Tests for warm-up at New
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"log/slog"
+	"testing"
+)
+
+func TestWithWarmUp_LeavesProviderUntouched(t *testing.T) {
+	provider := New(100, WithWarmUp(), WithSizeAccounting())
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	if stats := provider.Stats(); stats != (Stats{}) {
+		t.Errorf("Expected warm-up not to change counters, got %+v", stats)
+	}
+	if err := provider.Verify(); err != nil {
+		t.Errorf("Verify failed after warm-up: %v", err)
+	}
+
+	record := readRecord(t, provider, func(logger *slog.Logger) { logger.Info("first") })
+	if record.Msg != "first" {
+		t.Errorf("Unexpected first record %q", record.Msg)
+	}
+}
+
+func TestWithWarmUp_PreResolvesRuleLevels(t *testing.T) {
+	provider := New(10, WithWarmUp("db", "http.client"))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	if err := provider.SetRules(&Rules{Levels: map[string]slog.Level{"db": slog.LevelWarn}}); err != nil {
+		t.Fatalf("SetRules failed: %v", err)
+	}
+	rules := provider.rules.Load()
+	for _, name := range []string{"db", "http.client"} {
+		if _, ok := rules.resolved.Load(name); !ok {
+			t.Errorf("Expected level of %q to be pre-resolved", name)
+		}
+	}
+	if _, ok := rules.resolved.Load("other"); ok {
+		t.Error("Expected only warm-up loggers to be pre-resolved")
+	}
+}
@@ -0,0 +1,156 @@
+// watchdog.go: Detection of a stuck or absent consumer
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync/atomic"
+	"time"
+)
+
+// errorsBuffer is the capacity of the Errors channel.
+const errorsBuffer = 16
+
+// WatchdogConfig configures the consumer watchdog.
+type WatchdogConfig struct {
+	// Timeout is how long buffered records may wait without any Read before
+	// the consumer is reported as stuck. Defaults to 10 seconds.
+	Timeout time.Duration
+
+	// Output receives a one-line diagnostic for each stall. Defaults to
+	// os.Stderr; use io.Discard to rely on Errors only.
+	Output io.Writer
+
+	// Strict makes the next Handle call panic with the *StallError once a
+	// stall has been detected, which is useful in development and tests.
+	Strict bool
+}
+
+// StallError reports that records are buffered but nobody reads them.
+type StallError struct {
+	Idle     time.Duration // Time since the last consumer activity
+	Buffered int           // Records waiting in the buffer
+}
+
+// Error implements error.
+func (e *StallError) Error() string {
+	return fmt.Sprintf("slog provider: %d records buffered but no Read for %v; is the Iris reader logger started?",
+		e.Buffered, e.Idle.Round(time.Millisecond))
+}
+
+// WithWatchdog detects a stuck or absent consumer, such as a reader logger
+// whose Start method was never called, which otherwise looks like all logs
+// silently vanishing.
+//
+// The watchdog arms when the first record is buffered. When records stay
+// buffered for cfg.Timeout without any Read or ReadBatch call, it writes a
+// diagnostic to cfg.Output, sends a *StallError on Errors and, with
+// cfg.Strict, makes Handle panic. Each stall is reported once; consumer
+// activity re-arms the watchdog. The watchdog goroutine stops on Close.
+func WithWatchdog(cfg WatchdogConfig) Option {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 10 * time.Second
+	}
+	if cfg.Output == nil {
+		cfg.Output = os.Stderr
+	}
+	return func(o *options) { o.watchdog = &cfg }
+}
+
+// Errors returns the channel on which the provider reports asynchronous
+// problems, such as watchdog stalls. Events are dropped when the channel is
+// full, so a slow receiver never blocks the provider. The channel is never
+// closed.
+func (p *Provider) Errors() <-chan error {
+	return p.errs
+}
+
+// reportError sends err on the Errors channel without blocking.
+func (p *Provider) reportError(err error) {
+	select {
+	case p.errs <- err:
+	default:
+	}
+}
+
+// watchdog tracks consumer activity for WithWatchdog.
+type watchdog struct {
+	cfg      WatchdogConfig
+	activity atomic.Int64               // Unix nanoseconds of the last consumer activity, 0 until armed
+	reported atomic.Bool                // The current stall has been reported
+	stall    atomic.Pointer[StallError] // Last detected stall, for Strict
+}
+
+// newWatchdog returns the watchdog for cfg, or nil when disabled.
+func newWatchdog(cfg *WatchdogConfig) *watchdog {
+	if cfg == nil {
+		return nil
+	}
+	return &watchdog{cfg: *cfg}
+}
+
+// arm starts the stall timer when the first record is buffered.
+func (w *watchdog) arm() {
+	if w.activity.Load() == 0 {
+		w.activity.CompareAndSwap(0, time.Now().UnixNano())
+	}
+}
+
+// touch records consumer activity.
+func (w *watchdog) touch() {
+	w.activity.Store(time.Now().UnixNano())
+	if w.reported.Load() {
+		w.reported.Store(false)
+		w.stall.Store(nil)
+	}
+}
+
+// check panics with the detected stall in strict mode.
+func (w *watchdog) check() {
+	if w.cfg.Strict {
+		if err := w.stall.Load(); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// run polls for stalls until the provider is closed.
+func (w *watchdog) run(p *Provider) {
+	interval := max(w.cfg.Timeout/4, 10*time.Millisecond)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.closed:
+			return
+		case now := <-ticker.C:
+			w.poll(p, now)
+		}
+	}
+}
+
+// poll reports a stall if records have waited longer than the timeout.
+func (w *watchdog) poll(p *Provider, now time.Time) {
+	last := w.activity.Load()
+	buffered := p.queue.len()
+	if last == 0 || buffered == 0 || w.reported.Load() {
+		return
+	}
+	idle := now.Sub(time.Unix(0, last))
+	if idle < w.cfg.Timeout {
+		return
+	}
+
+	err := &StallError{Idle: idle, Buffered: buffered}
+	w.reported.Store(true)
+	w.stall.Store(err)
+	p.reportError(err)
+	_, _ = fmt.Fprintln(w.cfg.Output, err.Error()) // Best effort diagnostic
+}
@@ -0,0 +1,91 @@
+// watchdog_test.go: Tests for the consumer watchdog
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// lockedBuffer is a bytes.Buffer safe for concurrent use.
+type lockedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestWithWatchdog_ReportsAbsentConsumer(t *testing.T) {
+	var out lockedBuffer
+	provider := New(10, WithWatchdog(WatchdogConfig{Timeout: 20 * time.Millisecond, Output: &out}))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	slog.New(provider).Info("nobody reads this")
+
+	select {
+	case err := <-provider.Errors():
+		var stall *StallError
+		if !errors.As(err, &stall) || stall.Buffered != 1 || stall.Idle < 20*time.Millisecond {
+			t.Errorf("Errors() = %v, want *StallError for 1 record", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("no stall reported")
+	}
+	if !strings.Contains(out.String(), "is the Iris reader logger started?") {
+		t.Errorf("diagnostic output = %q", out.String())
+	}
+
+	select {
+	case err := <-provider.Errors():
+		t.Errorf("stall reported twice: %v", err)
+	case <-time.After(60 * time.Millisecond):
+	}
+}
+
+func TestWithWatchdog_QuietWithActiveConsumer(t *testing.T) {
+	provider := New(10, WithWatchdog(WatchdogConfig{Timeout: 20 * time.Millisecond, Output: io.Discard}))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	readRecord(t, provider, func(l *slog.Logger) { l.Info("consumed") })
+	select {
+	case err := <-provider.Errors():
+		t.Errorf("unexpected stall: %v", err)
+	case <-time.After(80 * time.Millisecond):
+	}
+}
+
+func TestWithWatchdog_StrictPanicsInHandle(t *testing.T) {
+	provider := New(10, WithWatchdog(WatchdogConfig{Timeout: 10 * time.Millisecond, Output: io.Discard, Strict: true}))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	logger := slog.New(provider)
+	logger.Info("stuck")
+	<-provider.Errors()
+
+	defer func() {
+		if _, ok := recover().(*StallError); !ok {
+			t.Error("Handle did not panic with *StallError")
+		}
+	}()
+	logger.Info("after stall")
+}
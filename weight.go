@@ -0,0 +1,84 @@
+// weight.go: Cost-aware eviction of buffered records on overflow
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import "time"
+
+// RecordWeight returns the value of keeping a record buffered; records with
+// a higher weight are kept in preference to records with a lower one. Age
+// is zero for records being handled.
+type RecordWeight func(meta RecordMeta) float64
+
+// DefaultRecordWeight weighs records by level first, then by their number of
+// attributes, and lowers the weight of old records:
+//
+//	weight = 4*level + min(attrs, 32)/4 - age in minutes
+//
+// A level step (4 slog levels, e.g. Debug to Info) outweighs any number of
+// attributes, so a rich Error is always kept over a Debug heartbeat.
+func DefaultRecordWeight(meta RecordMeta) float64 {
+	return 4*float64(meta.Level) + float64(min(meta.Attrs, 32))/4 - meta.Age.Minutes()
+}
+
+// WithWeightedEviction replaces the drop-newest overflow behavior with
+// cost-aware eviction. When the buffer is full, the incoming record is
+// weighed against the buffered ones: the buffered record with the lowest
+// weight is evicted to make room if it weighs less than the incoming record,
+// otherwise the incoming record is dropped as usual.
+//
+//	provider := slogprovider.New(1000, slogprovider.WithWeightedEviction(nil))
+//
+// A nil weight uses DefaultRecordWeight. Evicted records are counted in
+// Stats().Dropped and Stats().Evicted. Finding the record to evict is linear
+// in the buffer size and weighs every buffered record, so weights should be
+// cheap; the RecordMeta Size is only computed when WithSizeAccounting is
+// set.
+func WithWeightedEviction(weight RecordWeight) Option {
+	if weight == nil {
+		weight = DefaultRecordWeight
+	}
+	return func(o *options) { o.weight = weight }
+}
+
+// pushWeighted buffers e in a full queue by evicting the lightest buffered
+// entry, if it weighs less than e.
+func (p *Provider) pushWeighted(e entry) pushResult {
+	now := time.Now()
+	incoming := p.opts.weight(entryMeta(&e, now, false))
+	result := p.queue.pushEvicting(e, func(buffered *entry) (float64, bool) {
+		w := p.opts.weight(entryMeta(buffered, now, false))
+		return w, w < incoming
+	})
+	if result == pushed {
+		p.stats.dropped.Add(1)
+		p.stats.evicted.Add(1)
+	}
+	return result
+}
+
+// WithDropOldest replaces the drop-newest overflow behavior with ring buffer
+// semantics: when the buffer is full, the oldest buffered record is evicted
+// to make room for the incoming one, so the buffer always holds the most
+// recent records.
+//
+//	provider := slogprovider.New(1000, slogprovider.WithDropOldest())
+//
+// Evicted records are counted in Stats().Dropped and Stats().Evicted. It has
+// no effect with WithWeightedEviction, which decides evictions itself;
+// NewChecked reports the combination as ErrConflictingOptions.
+func WithDropOldest() Option {
+	return func(o *options) { o.dropOldest = true }
+}
+
+// pushDroppingOldest buffers e in a full queue by evicting the oldest
+// buffered entries.
+func (p *Provider) pushDroppingOldest(e entry) pushResult {
+	result, evicted := p.queue.pushDroppingOldest(e)
+	p.stats.dropped.Add(uint64(evicted)) // #nosec G115 -- count is never negative
+	p.stats.evicted.Add(uint64(evicted)) // #nosec G115 -- count is never negative
+	return result
+}
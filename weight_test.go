@@ -0,0 +1,109 @@
+// weight_test.go: Tests for cost-aware eviction
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package slogprovider
+
+import (
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func TestWithWeightedEviction_EvictsLightestRecord(t *testing.T) {
+	provider := New(3, WithMinLevel(slog.LevelDebug), WithWeightedEviction(nil))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+	logger := slog.New(provider)
+
+	logger.Info("info")
+	logger.Debug("heartbeat 1")
+	logger.Debug("heartbeat 2")
+	logger.Error("failure", "a", 1, "b", 2)
+	logger.Debug("heartbeat 3") // Fresher, so heavier than heartbeat 2
+
+	msgs := readMessages(t, provider, 3)
+	if msgs[0] != "info" || msgs[1] != "failure" || msgs[2] != "heartbeat 3" {
+		t.Errorf("Unexpected records %v", msgs)
+	}
+	stats := provider.Stats()
+	if stats.Evicted != 2 || stats.Dropped != 2 {
+		t.Errorf("Expected 2 evictions, got %+v", stats)
+	}
+	if err := provider.Verify(); err != nil {
+		t.Errorf("Verify failed: %v", err)
+	}
+}
+
+func TestWithWeightedEviction_DropsLighterIncoming(t *testing.T) {
+	provider := New(2, WithMinLevel(slog.LevelDebug), WithWeightedEviction(nil))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+	logger := slog.New(provider)
+
+	logger.Warn("warning 1")
+	logger.Warn("warning 2")
+	logger.Debug("heartbeat")
+
+	msgs := readMessages(t, provider, 2)
+	if msgs[0] != "warning 1" || msgs[1] != "warning 2" {
+		t.Errorf("Unexpected records %v", msgs)
+	}
+	if stats := provider.Stats(); stats.Evicted != 0 || stats.Dropped != 1 {
+		t.Errorf("Expected the heartbeat to be dropped, got %+v", stats)
+	}
+}
+
+func TestWithWeightedEviction_CustomWeight(t *testing.T) {
+	// Prefer the most recent records regardless of level.
+	newest := func(meta RecordMeta) float64 { return -meta.Age.Seconds() }
+	provider := New(2, WithWeightedEviction(newest))
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+
+	for i, msg := range []string{"old", "older", "new"} {
+		at := time.Now().Add(-time.Duration(3-i) * time.Minute)
+		if i == 1 {
+			at = time.Now().Add(-time.Hour)
+		}
+		handleAt(t, provider, msg, at)
+	}
+
+	msgs := readMessages(t, provider, 2)
+	if msgs[0] != "old" || msgs[1] != "new" {
+		t.Errorf("Unexpected records %v", msgs)
+	}
+}
+
+func TestWithDropOldest_KeepsMostRecentRecords(t *testing.T) {
+	provider := New(2, WithDropOldest())
+	defer func() { _ = provider.Close() }() // Ignore error in test cleanup
+	logger := slog.New(provider)
+
+	for _, msg := range []string{"one", "two", "three", "four"} {
+		logger.Info(msg)
+	}
+
+	msgs := readMessages(t, provider, 2)
+	if msgs[0] != "three" || msgs[1] != "four" {
+		t.Errorf("Unexpected records %v", msgs)
+	}
+	if stats := provider.Stats(); stats.Evicted != 2 || stats.Dropped != 2 {
+		t.Errorf("Expected 2 evictions, got %+v", stats)
+	}
+	if err := provider.Verify(); err != nil {
+		t.Errorf("Verify failed: %v", err)
+	}
+}
+
+func TestDefaultRecordWeight(t *testing.T) {
+	richDebug := DefaultRecordWeight(RecordMeta{Level: slog.LevelDebug, Attrs: 40})
+	plainInfo := DefaultRecordWeight(RecordMeta{Level: slog.LevelInfo})
+	if richDebug >= plainInfo {
+		t.Errorf("Level must outweigh attributes: debug %v, info %v", richDebug, plainInfo)
+	}
+	fresh := DefaultRecordWeight(RecordMeta{Level: slog.LevelInfo, Attrs: 2})
+	stale := DefaultRecordWeight(RecordMeta{Level: slog.LevelInfo, Attrs: 2, Age: 10 * time.Minute})
+	if stale >= fresh {
+		t.Errorf("Age must lower the weight: stale %v, fresh %v", stale, fresh)
+	}
+}